@@ -1,12 +1,467 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Memory metrics selectable with --mem-metric
+const (
+	MemoryMetricRSS = "rss" // Resident set size; double-counts shared pages
+	MemoryMetricPSS = "pss" // Proportional set size; shared pages split between sharers
+)
+
+// Scopes selectable with --scope, limiting the list to the processes
+// sharing the invoking shell's session or process group
+const (
+	ScopeSession = "session" // Everything started from the same terminal login
+	ScopePGroup  = "pgroup"  // The shell's process group
+)
+
+// DefaultTimeFormat is the Go layout used for displayed timestamps
+const DefaultTimeFormat = "2006-01-02 15:04:05"
+
+// SortMode selects how the process list is ordered
+type SortMode int
+
+const (
+	SortByCPU       SortMode = iota // CPU descending
+	SortByMemory                    // Memory descending
+	SortByComposite                 // CPU and memory weighted together, so idle memory hogs surface
+)
+
+var sortModeNames = []string{"cpu", "mem", "composite"}
+
+func (s SortMode) String() string {
+	if s >= 0 && int(s) < len(sortModeNames) {
+		return sortModeNames[s]
+	}
+	return "unknown"
+}
+
+// Next returns the following sort mode, wrapping around
+func (s SortMode) Next() SortMode {
+	return (s + 1) % SortMode(len(sortModeNames))
+}
+
+// ParseSortMode converts a --sort value to a SortMode
+func ParseSortMode(name string) (SortMode, error) {
+	for i, n := range sortModeNames {
+		if n == name {
+			return SortMode(i), nil
+		}
+	}
+	return SortByCPU, fmt.Errorf("unknown sort mode %q (expected cpu, mem or composite)", name)
+}
+
+// ChildSort selects how an expanded process's children are ordered,
+// independently of the main list's SortMode
+type ChildSort int
+
+const (
+	ChildSortFollow ChildSort = iota // Same order as the main list
+	ChildSortCPU                     // CPU descending
+	ChildSortMemory                  // Memory descending
+	ChildSortPID                     // PID ascending
+)
+
+var childSortNames = []string{"follow", "cpu", "mem", "pid"}
+
+func (c ChildSort) String() string {
+	if c >= 0 && int(c) < len(childSortNames) {
+		return childSortNames[c]
+	}
+	return "unknown"
+}
+
+// Next returns the following child sort, wrapping around
+func (c ChildSort) Next() ChildSort {
+	return (c + 1) % ChildSort(len(childSortNames))
+}
+
+// Prev returns the preceding child sort, wrapping around
+func (c ChildSort) Prev() ChildSort {
+	n := ChildSort(len(childSortNames))
+	return (c + n - 1) % n
+}
+
+// ParseChildSort converts a --child-sort value to a ChildSort
+func ParseChildSort(name string) (ChildSort, error) {
+	for i, n := range childSortNames {
+		if n == name {
+			return ChildSort(i), nil
+		}
+	}
+	return ChildSortFollow, fmt.Errorf("unknown child sort %q (expected follow, cpu, mem or pid)", name)
+}
+
+// GroupSort selects how the per-category totals are ordered, independently
+// of the main list's SortMode
+type GroupSort int
+
+const (
+	GroupSortFollow GroupSort = iota // Same key as the main list, applied to the totals
+	GroupSortCPU                     // Total CPU descending
+	GroupSortMemory                  // Total memory descending
+	GroupSortCount                   // Process count descending, to spot what's forking
+)
+
+var groupSortNames = []string{"follow", "cpu", "mem", "count"}
+
+func (g GroupSort) String() string {
+	if g >= 0 && int(g) < len(groupSortNames) {
+		return groupSortNames[g]
+	}
+	return "unknown"
+}
+
+// Next returns the following group sort, wrapping around
+func (g GroupSort) Next() GroupSort {
+	return (g + 1) % GroupSort(len(groupSortNames))
+}
+
+// Prev returns the preceding group sort, wrapping around
+func (g GroupSort) Prev() GroupSort {
+	n := GroupSort(len(groupSortNames))
+	return (g + n - 1) % n
+}
+
+// ParseGroupSort converts a --group-sort value to a GroupSort
+func ParseGroupSort(name string) (GroupSort, error) {
+	for i, n := range groupSortNames {
+		if n == name {
+			return GroupSort(i), nil
+		}
+	}
+	return GroupSortFollow, fmt.Errorf("unknown group sort %q (expected follow, cpu, mem or count)", name)
+}
+
+// IconThresholds are the CPU percentages at which a process's status icon
+// changes tier: ◉ at High and above, ● at Medium, ◎ at Active, ○ below
+type IconThresholds struct {
+	High   float64
+	Medium float64
+	Active float64
+}
+
+// CPUBar selects whether rows show an inline CPU bar, and what a full bar
+// means
+type CPUBar int
+
+const (
+	CPUBarOff      CPUBar = iota // No bar
+	CPUBarAbsolute               // Full at 100% (one core)
+	CPUBarRelative               // Full at the busiest listed process
+)
+
+var cpuBarNames = []string{"off", "absolute", "relative"}
+
+func (b CPUBar) String() string {
+	if b >= 0 && int(b) < len(cpuBarNames) {
+		return cpuBarNames[b]
+	}
+	return "unknown"
+}
+
+// Next returns the following bar mode, wrapping around
+func (b CPUBar) Next() CPUBar {
+	return (b + 1) % CPUBar(len(cpuBarNames))
+}
+
+// Prev returns the preceding bar mode, wrapping around
+func (b CPUBar) Prev() CPUBar {
+	n := CPUBar(len(cpuBarNames))
+	return (b + n - 1) % n
+}
+
+// ParseCPUBar converts a --cpu-bar value to a CPUBar
+func ParseCPUBar(name string) (CPUBar, error) {
+	for i, n := range cpuBarNames {
+		if n == name {
+			return CPUBar(i), nil
+		}
+	}
+	return CPUBarOff, fmt.Errorf("unknown CPU bar mode %q (expected off, absolute or relative)", name)
+}
+
+// BarGlyphs are the characters progress bars are drawn with: Full for
+// filled cells, Empty for the rest, and optionally Partial for the cell
+// where the fill ends, from least to most filled (e.g. "▏▎▍▌▋▊▉")
+type BarGlyphs struct {
+	Full    rune
+	Empty   rune
+	Partial []rune
+}
+
+// BarPresets are the named glyph sets --bar-chars accepts, in the order
+// listed in help
+var BarPresets = []struct {
+	Name   string
+	Glyphs BarGlyphs
+}{
+	{"blocks", BarGlyphs{Full: '█', Empty: '░'}},
+	{"smooth", BarGlyphs{Full: '█', Empty: ' ', Partial: []rune("▏▎▍▌▋▊▉")}},
+	{"squares", BarGlyphs{Full: '■', Empty: '□'}},
+	{"ascii", BarGlyphs{Full: '#', Empty: '-'}},
+}
+
+// DefaultBarGlyphs is the "blocks" preset
+var DefaultBarGlyphs = BarPresets[0].Glyphs
+
+// BarPreset returns the index in BarPresets of the preset glyphs are, or
+// -1 for custom characters
+func BarPreset(glyphs BarGlyphs) int {
+	for i, preset := range BarPresets {
+		if preset.Glyphs.Full == glyphs.Full && preset.Glyphs.Empty == glyphs.Empty && slices.Equal(preset.Glyphs.Partial, glyphs.Partial) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseBarGlyphs converts a --bar-chars value: a preset name, or the full
+// and empty characters followed by any partial ones, e.g. "#-" or "█ ▌"
+func ParseBarGlyphs(value string) (BarGlyphs, error) {
+	for _, preset := range BarPresets {
+		if preset.Name == value {
+			return preset.Glyphs, nil
+		}
+	}
+	runes := []rune(value)
+	if len(runes) < 2 {
+		return BarGlyphs{}, fmt.Errorf("%q is neither a preset (blocks, smooth, squares or ascii) nor at least a full and an empty character", value)
+	}
+	for _, r := range runes {
+		if !unicode.IsPrint(r) {
+			return BarGlyphs{}, fmt.Errorf("%q contains the unprintable character %U", value, r)
+		}
+	}
+	glyphs := BarGlyphs{Full: runes[0], Empty: runes[1]}
+	if len(runes) > 2 {
+		glyphs.Partial = runes[2:]
+	}
+	return glyphs, nil
+}
+
+// Optional column names, as used in ColumnOrder. They match the config
+// file keys that turn each column on.
+const (
+	ColumnCPUBar     = "cpu_bar"
+	ColumnMemPercent = "mem_percent"
+	ColumnCPUTime    = "cpu_time"
+	ColumnAvgCPU     = "avg_cpu"
+	ColumnFaults     = "faults"
+	ColumnTTY        = "tty"
+)
+
+// DefaultColumnOrder is the display order of the optional columns, between
+// MEMORY and CHILD
+var DefaultColumnOrder = []string{ColumnCPUBar, ColumnMemPercent, ColumnCPUTime, ColumnAvgCPU, ColumnFaults, ColumnTTY}
+
+// ParseColumnOrder validates a column order. Columns it leaves out follow
+// in their default order, so a saved order keeps working when columns are
+// added.
+func ParseColumnOrder(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(DefaultColumnOrder))
+	order := make([]string, 0, len(DefaultColumnOrder))
+	for _, name := range names {
+		if !slices.Contains(DefaultColumnOrder, name) {
+			return nil, fmt.Errorf("unknown column %q (expected %s)", name, strings.Join(DefaultColumnOrder, ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %q listed twice", name)
+		}
+		seen[name] = true
+		order = append(order, name)
+	}
+	for _, name := range DefaultColumnOrder {
+		if !seen[name] {
+			order = append(order, name)
+		}
+	}
+	return order, nil
+}
+
+// ColorProfile overrides tcell's detection of how many colors the terminal
+// supports
+type ColorProfile int
+
+const (
+	ColorProfileAuto      ColorProfile = iota // Whatever tcell detects
+	ColorProfileTrueColor                     // 24-bit RGB
+	ColorProfile256                           // The xterm 256-color palette
+	ColorProfile16                            // The basic 16 ANSI colors
+	ColorProfileMono                          // Default foreground only, selection in reverse video
+)
+
+var colorProfileNames = []string{"auto", "truecolor", "256", "16", "mono"}
+
+func (p ColorProfile) String() string {
+	if p >= 0 && int(p) < len(colorProfileNames) {
+		return colorProfileNames[p]
+	}
+	return "unknown"
+}
+
+// ParseColorProfile converts a --color-profile value to a ColorProfile
+func ParseColorProfile(name string) (ColorProfile, error) {
+	for i, n := range colorProfileNames {
+		if n == name {
+			return ColorProfile(i), nil
+		}
+	}
+	return ColorProfileAuto, fmt.Errorf("unknown color profile %q (expected auto, truecolor, 256, 16 or mono)", name)
+}
+
+// memoryUnits are the suffixes ParseMemorySize accepts. They're binary
+// multiples, like every size brieftop shows; no suffix means MB.
+var memoryUnits = map[string]uint64{
+	"": 1 << 20, "B": 1,
+	"K": 1 << 10, "KB": 1 << 10, "KIB": 1 << 10,
+	"M": 1 << 20, "MB": 1 << 20, "MIB": 1 << 20,
+	"G": 1 << 30, "GB": 1 << 30, "GIB": 1 << 30,
+	"T": 1 << 40, "TB": 1 << 40, "TIB": 1 << 40,
+}
+
+// ParseMemorySize converts a --memory value such as "2G", "500M", "1.5g" or
+// "1024K" to bytes. A bare number is MB, as --memory always took.
+func ParseMemorySize(value string) (uint64, error) {
+	trimmed := strings.TrimSpace(value)
+	number, unit := trimmed, ""
+	if i := strings.IndexFunc(trimmed, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' }); i >= 0 {
+		number, unit = trimmed[:i], strings.ToUpper(strings.TrimSpace(trimmed[i:]))
+	}
+	invalid := fmt.Errorf("invalid size %q (expected a number with an optional unit, e.g. 500M or 2G)", value)
+	if number == "" {
+		return 0, invalid
+	}
+	multiplier, ok := memoryUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q in %q (expected K, M, G or T, or a bare number of MB)", unit, value)
+	}
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	return uint64(size * float64(multiplier)), nil
+}
+
+// DefaultPrecision and MaxPrecision bound the decimal places shown for CPU
+// and memory values
+const (
+	DefaultPrecision = 1
+	MaxPrecision     = 3
+)
+
+// MaxScanWorkers caps the scan worker pool; past this, goroutines just
+// contend on /proc instead of overlapping their reads
+const MaxScanWorkers = 64
+
+// DefaultIconThresholds match the resource level colors
+var DefaultIconThresholds = IconThresholds{High: 50, Medium: 20, Active: 5}
+
+// Validate checks the thresholds are non-negative and descending
+func (t IconThresholds) Validate() error {
+	if t.Active < 0 || t.Medium < t.Active || t.High < t.Medium {
+		return fmt.Errorf("icon thresholds must satisfy high >= medium >= active >= 0, got %v/%v/%v", t.High, t.Medium, t.Active)
+	}
+	return nil
+}
+
+// CategoryRule tags processes whose name or command line contains Pattern
+// (case-insensitive) with Category. The first matching rule wins.
+type CategoryRule struct {
+	Category string `json:"category"`
+	Pattern  string `json:"pattern"`
+}
+
+// DefaultCategoryRules cover common desktop and server applications
+var DefaultCategoryRules = []CategoryRule{
+	{"browser", "chrome"},
+	{"browser", "chromium"},
+	{"browser", "firefox"},
+	{"browser", "safari"},
+	{"browser", "brave"},
+	{"editor", "code"},
+	{"editor", "vim"},
+	{"editor", "emacs"},
+	{"editor", "idea"},
+	{"database", "postgres"},
+	{"database", "mysqld"},
+	{"database", "mongod"},
+	{"database", "redis-server"},
+	{"container", "docker"},
+	{"container", "containerd"},
+	{"container", "podman"},
+	{"runtime", "java"},
+	{"runtime", "node"},
+	{"runtime", "python"},
+}
 
 type Config struct {
-	CPUThreshold    float64
-	MemoryThreshold uint64
-	RefreshRate     time.Duration
-	ShowThreads     bool
+	mu                   sync.RWMutex // Guards fields changed at runtime from the UI
+	CPUThreshold         float64
+	MemoryThreshold      uint64
+	RefreshRate          time.Duration
+	CPUWindow            time.Duration // CPU % is averaged over this long; 0 uses each refresh's reading
+	Linger               time.Duration // Exited processes stay listed this long; 0 drops them at once
+	CPUBlocking          time.Duration // The first system CPU reading is measured over this long; 0 uses the time since startup
+	BarGlyphs            BarGlyphs     // Characters progress bars are drawn with
+	ShowThreads          bool
+	CollapseThreads      bool // Merge an expanded process's threads into one summary row
+	AggregateAllChildren bool // Sum every child into its parent, not only same-named ones
+	SumThreads           bool // Also sum children taken for threads into their parent, whatever their name
+	TreeView             bool // List every process as an indented hierarchy, ignoring the thresholds
+	MaxTreeDepth         int  // Deepest --tree level listed; 0 for no limit
+	RefreshOnKey         bool // Navigation and expand keys trigger an immediate (rate-limited) refresh
+	QuietStart           bool
+	IntervalAlign        bool // Refreshes land on wall-clock multiples of RefreshRate
+	Profile              bool // Show scan and render timings in the footer
+	ScanWorkers          int  // Goroutines reading process info during a scan
+	MemoryMetric         string
+	CategoryRules        []CategoryRule
+	Exclude              []string // Name globs of processes never listed
+	Critical             []string // Names of must-run processes: always listed, highlighted, and reported when gone
+	CriticalBell         bool     // Ring the terminal bell when a critical process goes missing
+	HideSelf             bool
+	Scope                string // ScopeSession or ScopePGroup to only list that group's processes; "" lists all
+	ScopeID              int32  // The session or process group ID Scope matches
+	SortMode             SortMode
+	ChildSort            ChildSort           // Order of an expanded process's children
+	GroupSort            GroupSort           // Order of the per-category totals
+	ShowMemPercent       bool                // Show each process's share of system RAM
+	CPUDelta             bool                // CPU column shows the change since the previous refresh
+	ShowTTY              bool                // Show each process's controlling terminal
+	ShowCPUTime          bool                // Show cumulative CPU time as a column
+	ShowAvgCPU           bool                // Show CPU averaged since process start as a column
+	ShowFaults           bool                // Show major page faults per second as a column
+	ShowSecurityContext  bool                // Show the SELinux/AppArmor label in the detail pane
+	SummaryOnly          bool                // Show only the system metrics, large, without the process list
+	StackedMemory        bool                // Split the header memory bar into used, buffers and cache
+	CollapseHeader       bool                // Hide the system metrics while the list is scrolled down
+	OnelineHeader        bool                // Summarize the system metrics on one line, as on narrow terminals
+	HeaderDeltas         bool                // Follow the header's CPU and memory percentages with their change since the previous refresh
+	WindowTitle          bool                // Put the busiest process and memory use in the terminal's title
+	HighlightTop         bool                // Mark the listed processes using the most CPU and the most memory
+	HeatColumn           bool                // Draw each row's resource level as a colored block in the left margin
+	AlertCPU             float64             // CPU % at which a process raises an alert; 0 disables
+	AlertMemory          uint64              // Memory in bytes at which a process raises an alert; 0 disables
+	AlertCommand         string              // Shell command run when a process crosses an alert threshold
+	CPUBar               CPUBar              // Inline per-process CPU bar and its scale
+	ColumnOrder          []string            // Display order of the optional columns; nil for DefaultColumnOrder
+	ColorProfile         ColorProfile        // Forced terminal color capability; auto leaves it to tcell
+	Precision            int                 // Decimal places for displayed CPU and memory values
+	IconThresholds       IconThresholds      // CPU breakpoints for the status icon tiers
+	TimeFormat           string              // Go layout string for displayed timestamps
+	TimeZone             *time.Location      // Zone displayed timestamps are converted to
+	KeyBindings          map[string][]string // Action name → keys, overriding the default keymap
+	Layout               string              // Named layout of expanded processes that y saves and j restores
 }
 
 func New() *Config {
@@ -15,29 +470,714 @@ func New() *Config {
 		MemoryThreshold: 50 * 1024 * 1024, // 50MB in bytes
 		RefreshRate:     time.Second,
 		ShowThreads:     true,
+		MemoryMetric:    MemoryMetricRSS,
+		CategoryRules:   append([]CategoryRule(nil), DefaultCategoryRules...),
+		IconThresholds:  DefaultIconThresholds,
+		ScanWorkers:     clampScanWorkers(runtime.NumCPU()),
+		Precision:       DefaultPrecision,
+		TimeFormat:      DefaultTimeFormat,
+		TimeZone:        time.Local,
+		Layout:          DefaultLayout,
+		BarGlyphs:       DefaultBarGlyphs,
 	}
 }
 
 func (c *Config) SetCPUThreshold(threshold float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.CPUThreshold = threshold
 }
 
 func (c *Config) SetMemoryThreshold(threshold uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.MemoryThreshold = threshold
 }
 
 func (c *Config) SetRefreshRate(rate time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.RefreshRate = rate
 }
 
+func (c *Config) SetShowThreads(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowThreads = show
+}
+
+func (c *Config) SetCollapseThreads(collapse bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CollapseThreads = collapse
+}
+
+func (c *Config) SetAggregateAllChildren(all bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AggregateAllChildren = all
+}
+
+func (c *Config) SetSumThreads(sum bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SumThreads = sum
+}
+
+func (c *Config) SetTreeView(tree bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TreeView = tree
+}
+
+// SetMaxTreeDepth limits how many levels below a root the tree view lists;
+// 0 (or less) removes the limit
+func (c *Config) SetMaxTreeDepth(depth int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxTreeDepth = max(depth, 0)
+}
+
+func (c *Config) SetRefreshOnKey(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RefreshOnKey = enabled
+}
+
+func (c *Config) SetCPUWindow(window time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CPUWindow = window
+}
+
+func (c *Config) SetBarGlyphs(glyphs BarGlyphs) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.BarGlyphs = glyphs
+}
+
+func (c *Config) GetBarGlyphs() BarGlyphs {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BarGlyphs
+}
+
+func (c *Config) SetCPUBlocking(blocking time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CPUBlocking = blocking
+}
+
+func (c *Config) GetCPUBlocking() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CPUBlocking
+}
+
+func (c *Config) SetLinger(linger time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Linger = linger
+}
+
+func (c *Config) SetQuietStart(quiet bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.QuietStart = quiet
+}
+
+func (c *Config) SetIntervalAlign(align bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.IntervalAlign = align
+}
+
+func (c *Config) SetProfile(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Profile = enabled
+}
+
+// SetScanWorkers sets the scan worker pool size, clamped to
+// 1..MaxScanWorkers
+func (c *Config) SetScanWorkers(workers int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ScanWorkers = clampScanWorkers(workers)
+}
+
+func clampScanWorkers(workers int) int {
+	if workers < 1 {
+		return 1
+	}
+	if workers > MaxScanWorkers {
+		return MaxScanWorkers
+	}
+	return workers
+}
+
+func (c *Config) SetPrecision(precision int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Precision = clampPrecision(precision)
+}
+
+func clampPrecision(precision int) int {
+	if precision < 0 {
+		return 0
+	}
+	if precision > MaxPrecision {
+		return MaxPrecision
+	}
+	return precision
+}
+
+func (c *Config) SetMemoryMetric(metric string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MemoryMetric = metric
+}
+
+// SetScope only lists processes whose session or process group (per scope)
+// is id; an empty scope lists all
+func (c *Config) SetScope(scope string, id int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Scope, c.ScopeID = scope, id
+}
+
+func (c *Config) GetScope() (string, int32) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Scope, c.ScopeID
+}
+
+func (c *Config) SetHideSelf(hide bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HideSelf = hide
+}
+
+func (c *Config) SetTimeFormat(layout string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TimeFormat = layout
+}
+
+func (c *Config) SetTimeZone(loc *time.Location) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TimeZone = loc
+}
+
+func (c *Config) SetSortMode(mode SortMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SortMode = mode
+}
+
+func (c *Config) SetCPUBar(bar CPUBar) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CPUBar = bar
+}
+
+func (c *Config) SetChildSort(sort ChildSort) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ChildSort = sort
+}
+
+func (c *Config) SetGroupSort(sort GroupSort) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.GroupSort = sort
+}
+
+func (c *Config) SetShowMemPercent(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowMemPercent = show
+}
+
+func (c *Config) SetCPUDelta(delta bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CPUDelta = delta
+}
+
+func (c *Config) SetShowTTY(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowTTY = show
+}
+
+func (c *Config) SetShowAvgCPU(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowAvgCPU = show
+}
+
+func (c *Config) SetSummaryOnly(summary bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SummaryOnly = summary
+}
+
+func (c *Config) SetAlertCPU(threshold float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AlertCPU = threshold
+}
+
+func (c *Config) SetAlertMemory(threshold uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AlertMemory = threshold
+}
+
+func (c *Config) SetAlertCommand(command string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.AlertCommand = command
+}
+
+func (c *Config) SetColorProfile(profile ColorProfile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ColorProfile = profile
+}
+
+func (c *Config) SetShowFaults(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowFaults = show
+}
+
+func (c *Config) SetShowSecurityContext(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowSecurityContext = show
+}
+
+func (c *Config) SetShowCPUTime(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowCPUTime = show
+}
+
+func (c *Config) SetIconThresholds(thresholds IconThresholds) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.IconThresholds = thresholds
+}
+
+// SetKeyBinding rebinds action to keys, replacing its default keys
+func (c *Config) SetKeyBinding(action string, keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.KeyBindings == nil {
+		c.KeyBindings = make(map[string][]string)
+	}
+	c.KeyBindings[action] = append([]string(nil), keys...)
+}
+
+// AddCategoryRule adds a rule that takes precedence over existing ones
+func (c *Config) AddCategoryRule(rule CategoryRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CategoryRules = append([]CategoryRule{rule}, c.CategoryRules...)
+}
+
+// AddExclude hides processes whose name matches the glob pattern
+func (c *Config) AddExclude(pattern string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Exclude = append(c.Exclude, pattern)
+}
+
+// AddCritical watches for processes named name, by comm or executable
+func (c *Config) AddCritical(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Critical = append(c.Critical, name)
+}
+
+func (c *Config) GetCritical() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Critical
+}
+
+func (c *Config) SetCriticalBell(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CriticalBell = enabled
+}
+
+func (c *Config) GetCriticalBell() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CriticalBell
+}
+
 func (c *Config) GetCPUThreshold() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.CPUThreshold
 }
 
 func (c *Config) GetMemoryThreshold() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.MemoryThreshold
 }
 
 func (c *Config) GetRefreshRate() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.RefreshRate
 }
+
+func (c *Config) GetShowThreads() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowThreads
+}
+
+func (c *Config) GetCollapseThreads() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CollapseThreads
+}
+
+func (c *Config) GetAggregateAllChildren() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AggregateAllChildren
+}
+
+func (c *Config) GetSumThreads() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SumThreads
+}
+
+func (c *Config) GetTreeView() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.TreeView
+}
+
+func (c *Config) GetMaxTreeDepth() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxTreeDepth
+}
+
+func (c *Config) GetRefreshOnKey() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RefreshOnKey
+}
+
+func (c *Config) GetCPUWindow() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CPUWindow
+}
+
+func (c *Config) GetLinger() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Linger
+}
+
+func (c *Config) GetQuietStart() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.QuietStart
+}
+
+func (c *Config) GetIntervalAlign() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.IntervalAlign
+}
+
+func (c *Config) GetProfile() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Profile
+}
+
+func (c *Config) GetScanWorkers() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ScanWorkers
+}
+
+func (c *Config) GetMemoryMetric() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MemoryMetric
+}
+
+func (c *Config) GetCategoryRules() []CategoryRule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CategoryRules
+}
+
+// SetColumnOrder sets the display order of the optional columns; order
+// should come from ParseColumnOrder
+func (c *Config) SetColumnOrder(order []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ColumnOrder = append([]string(nil), order...)
+}
+
+// GetColumnOrder returns a copy of the optional column order
+func (c *Config) GetColumnOrder() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.ColumnOrder == nil {
+		return append([]string(nil), DefaultColumnOrder...)
+	}
+	return append([]string(nil), c.ColumnOrder...)
+}
+
+func (c *Config) GetExclude() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Exclude
+}
+
+func (c *Config) GetHideSelf() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HideSelf
+}
+
+// FormatTime formats t with the configured layout and time zone. Every
+// timestamp brieftop shows goes through here so output is consistent.
+func (c *Config) FormatTime(t time.Time) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return t.In(c.TimeZone).Format(c.TimeFormat)
+}
+
+func (c *Config) GetSortMode() SortMode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SortMode
+}
+
+func (c *Config) GetCPUBar() CPUBar {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CPUBar
+}
+
+func (c *Config) GetChildSort() ChildSort {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ChildSort
+}
+
+func (c *Config) GetGroupSort() GroupSort {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.GroupSort
+}
+
+func (c *Config) GetShowMemPercent() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowMemPercent
+}
+
+func (c *Config) GetCPUDelta() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CPUDelta
+}
+
+func (c *Config) GetShowTTY() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowTTY
+}
+
+func (c *Config) GetShowAvgCPU() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowAvgCPU
+}
+
+func (c *Config) GetSummaryOnly() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SummaryOnly
+}
+
+func (c *Config) GetAlertCPU() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AlertCPU
+}
+
+func (c *Config) GetAlertMemory() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AlertMemory
+}
+
+func (c *Config) GetAlertCommand() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AlertCommand
+}
+
+func (c *Config) GetColorProfile() ColorProfile {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ColorProfile
+}
+
+func (c *Config) SetStackedMemory(stacked bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.StackedMemory = stacked
+}
+
+func (c *Config) GetStackedMemory() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StackedMemory
+}
+
+func (c *Config) SetCollapseHeader(collapse bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CollapseHeader = collapse
+}
+
+func (c *Config) GetCollapseHeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CollapseHeader
+}
+
+func (c *Config) SetOnelineHeader(oneline bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.OnelineHeader = oneline
+}
+
+func (c *Config) GetOnelineHeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.OnelineHeader
+}
+
+func (c *Config) SetHeaderDeltas(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HeaderDeltas = enabled
+}
+
+func (c *Config) GetHeaderDeltas() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HeaderDeltas
+}
+
+func (c *Config) SetWindowTitle(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.WindowTitle = enabled
+}
+
+func (c *Config) GetWindowTitle() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.WindowTitle
+}
+
+func (c *Config) SetHighlightTop(highlight bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HighlightTop = highlight
+}
+
+func (c *Config) GetHighlightTop() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HighlightTop
+}
+
+func (c *Config) SetHeatColumn(show bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HeatColumn = show
+}
+
+func (c *Config) GetHeatColumn() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HeatColumn
+}
+
+func (c *Config) GetShowFaults() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowFaults
+}
+
+func (c *Config) GetShowSecurityContext() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowSecurityContext
+}
+
+func (c *Config) GetPrecision() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Precision
+}
+
+func (c *Config) GetShowCPUTime() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowCPUTime
+}
+
+func (c *Config) GetIconThresholds() IconThresholds {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.IconThresholds
+}
+
+func (c *Config) SetLayout(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Layout = name
+}
+
+func (c *Config) GetLayout() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Layout
+}
+
+// GetKeyBindings returns a copy of the configured key overrides
+func (c *Config) GetKeyBindings() map[string][]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	bindings := make(map[string][]string, len(c.KeyBindings))
+	for action, keys := range c.KeyBindings {
+		bindings[action] = append([]string(nil), keys...)
+	}
+	return bindings
+}
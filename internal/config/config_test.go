@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -29,6 +30,14 @@ func TestNew(t *testing.T) {
 	if !cfg.ShowThreads {
 		t.Error("Expected ShowThreads to be true")
 	}
+
+	if cfg.HideSelf {
+		t.Error("Expected HideSelf to be false")
+	}
+
+	if cfg.MemoryMetric != MemoryMetricRSS {
+		t.Errorf("Expected MemoryMetric to be %q, got %q", MemoryMetricRSS, cfg.MemoryMetric)
+	}
 }
 
 func TestSetCPUThreshold(t *testing.T) {
@@ -73,3 +82,797 @@ func TestSetRefreshRate(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCPUWindow(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetCPUWindow() != 0 {
+		t.Errorf("Expected CPUWindow to default to 0, got %v", cfg.GetCPUWindow())
+	}
+
+	cfg.SetCPUWindow(3 * time.Second)
+	if cfg.GetCPUWindow() != 3*time.Second {
+		t.Errorf("Expected CPUWindow to be 3s, got %v", cfg.GetCPUWindow())
+	}
+}
+
+func TestSetLinger(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetLinger() != 0 {
+		t.Errorf("Expected Linger to default to 0, got %v", cfg.GetLinger())
+	}
+
+	cfg.SetLinger(5 * time.Second)
+	if cfg.GetLinger() != 5*time.Second {
+		t.Errorf("Expected Linger to be 5s, got %v", cfg.GetLinger())
+	}
+}
+
+func TestSetCPUBlocking(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetCPUBlocking() != 0 {
+		t.Errorf("Expected CPUBlocking to default to 0, got %v", cfg.GetCPUBlocking())
+	}
+
+	cfg.SetCPUBlocking(200 * time.Millisecond)
+	if cfg.GetCPUBlocking() != 200*time.Millisecond {
+		t.Errorf("Expected CPUBlocking to be 200ms, got %v", cfg.GetCPUBlocking())
+	}
+}
+
+func TestSetIntervalAlign(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetIntervalAlign() {
+		t.Error("Expected IntervalAlign to default to false")
+	}
+
+	cfg.SetIntervalAlign(true)
+	if !cfg.GetIntervalAlign() {
+		t.Error("Expected IntervalAlign to be true after SetIntervalAlign(true)")
+	}
+}
+
+func TestSetQuietStart(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetQuietStart() {
+		t.Error("Expected QuietStart to default to false")
+	}
+
+	cfg.SetQuietStart(true)
+	if !cfg.GetQuietStart() {
+		t.Error("Expected QuietStart to be true after SetQuietStart(true)")
+	}
+}
+
+func TestSetProfile(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetProfile() {
+		t.Error("Expected Profile to default to false")
+	}
+
+	cfg.SetProfile(true)
+	if !cfg.GetProfile() {
+		t.Error("Expected Profile to be true after SetProfile(true)")
+	}
+}
+
+func TestSetMaxTreeDepth(t *testing.T) {
+	cfg := New()
+
+	if depth := cfg.GetMaxTreeDepth(); depth != 0 {
+		t.Errorf("Expected no default tree depth limit, got %d", depth)
+	}
+
+	cfg.SetMaxTreeDepth(3)
+	if depth := cfg.GetMaxTreeDepth(); depth != 3 {
+		t.Errorf("SetMaxTreeDepth(3): got %d", depth)
+	}
+	cfg.SetMaxTreeDepth(-1)
+	if depth := cfg.GetMaxTreeDepth(); depth != 0 {
+		t.Errorf("SetMaxTreeDepth(-1): got %d, expected 0", depth)
+	}
+}
+
+func TestSetScanWorkers(t *testing.T) {
+	cfg := New()
+
+	if workers := cfg.GetScanWorkers(); workers < 1 || workers > MaxScanWorkers {
+		t.Errorf("Expected default ScanWorkers within 1..%d, got %d", MaxScanWorkers, workers)
+	}
+
+	tests := []struct {
+		workers  int
+		expected int
+	}{
+		{4, 4},
+		{0, 1},
+		{-3, 1},
+		{MaxScanWorkers + 1, MaxScanWorkers},
+	}
+	for _, tt := range tests {
+		cfg.SetScanWorkers(tt.workers)
+		if got := cfg.GetScanWorkers(); got != tt.expected {
+			t.Errorf("SetScanWorkers(%d): got %d, expected %d", tt.workers, got, tt.expected)
+		}
+	}
+}
+
+func TestSetPrecision(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetPrecision() != DefaultPrecision {
+		t.Errorf("Expected default precision %d, got %d", DefaultPrecision, cfg.GetPrecision())
+	}
+
+	tests := []struct {
+		precision int
+		expected  int
+	}{
+		{0, 0},
+		{2, 2},
+		{-1, 0},
+		{MaxPrecision + 1, MaxPrecision},
+	}
+	for _, tt := range tests {
+		cfg.SetPrecision(tt.precision)
+		if got := cfg.GetPrecision(); got != tt.expected {
+			t.Errorf("SetPrecision(%d): got %d, expected %d", tt.precision, got, tt.expected)
+		}
+	}
+}
+
+func TestAddExclude(t *testing.T) {
+	cfg := New()
+
+	if len(cfg.GetExclude()) != 0 {
+		t.Errorf("Expected no exclude patterns by default, got %v", cfg.GetExclude())
+	}
+
+	cfg.AddExclude("kworker*")
+	cfg.AddExclude("rcu_*")
+	if got := cfg.GetExclude(); len(got) != 2 || got[0] != "kworker*" || got[1] != "rcu_*" {
+		t.Errorf("Expected [kworker* rcu_*], got %v", got)
+	}
+}
+
+func TestSetScope(t *testing.T) {
+	cfg := New()
+
+	if scope, _ := cfg.GetScope(); scope != "" {
+		t.Errorf("Expected every process to be in scope by default, got %q", scope)
+	}
+
+	cfg.SetScope(ScopeSession, 4321)
+	if scope, id := cfg.GetScope(); scope != ScopeSession || id != 4321 {
+		t.Errorf("Expected session 4321, got %s %d", scope, id)
+	}
+}
+
+func TestAddCritical(t *testing.T) {
+	cfg := New()
+
+	if len(cfg.GetCritical()) != 0 || cfg.GetCriticalBell() {
+		t.Errorf("Expected no critical processes or bell by default, got %v", cfg.GetCritical())
+	}
+
+	cfg.AddCritical("postgres")
+	cfg.AddCritical("nginx")
+	cfg.SetCriticalBell(true)
+	if got := cfg.GetCritical(); len(got) != 2 || got[0] != "postgres" || got[1] != "nginx" {
+		t.Errorf("Expected [postgres nginx], got %v", got)
+	}
+	if !cfg.GetCriticalBell() {
+		t.Error("Expected CriticalBell to be true")
+	}
+}
+
+func TestSetShowThreads(t *testing.T) {
+	cfg := New()
+
+	cfg.SetShowThreads(false)
+	if cfg.GetShowThreads() {
+		t.Error("Expected ShowThreads to be false after SetShowThreads(false)")
+	}
+
+	cfg.SetShowThreads(true)
+	if !cfg.GetShowThreads() {
+		t.Error("Expected ShowThreads to be true after SetShowThreads(true)")
+	}
+}
+
+func TestSetCollapseThreads(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetCollapseThreads() {
+		t.Error("Expected threads to be listed individually by default")
+	}
+
+	cfg.SetCollapseThreads(true)
+	if !cfg.GetCollapseThreads() {
+		t.Error("Expected CollapseThreads to be true")
+	}
+}
+
+func TestSetRefreshOnKey(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetRefreshOnKey() {
+		t.Error("Expected refresh-on-key to be off by default")
+	}
+
+	cfg.SetRefreshOnKey(true)
+	if !cfg.GetRefreshOnKey() {
+		t.Error("Expected RefreshOnKey to be true")
+	}
+}
+
+func TestSetShowTTY(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetShowTTY() {
+		t.Error("Expected TTY column to be hidden by default")
+	}
+
+	cfg.SetShowTTY(true)
+	if !cfg.GetShowTTY() {
+		t.Error("Expected ShowTTY to be true")
+	}
+}
+
+func TestSetShowCPUTime(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetShowCPUTime() {
+		t.Error("Expected CPU time column to be hidden by default")
+	}
+
+	cfg.SetShowCPUTime(true)
+	if !cfg.GetShowCPUTime() {
+		t.Error("Expected ShowCPUTime to be true")
+	}
+}
+
+func TestSetShowAvgCPU(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetShowAvgCPU() {
+		t.Error("Expected average CPU column to be hidden by default")
+	}
+
+	cfg.SetShowAvgCPU(true)
+	if !cfg.GetShowAvgCPU() {
+		t.Error("Expected ShowAvgCPU to be true")
+	}
+}
+
+func TestSetShowFaults(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetShowFaults() {
+		t.Error("Expected fault rate column to be hidden by default")
+	}
+
+	cfg.SetShowFaults(true)
+	if !cfg.GetShowFaults() {
+		t.Error("Expected ShowFaults to be true")
+	}
+}
+
+func TestSetTreeView(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetTreeView() {
+		t.Error("Expected the filtered list by default")
+	}
+
+	cfg.SetTreeView(true)
+	if !cfg.GetTreeView() {
+		t.Error("Expected TreeView to be true")
+	}
+}
+
+func TestParseMemorySize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected uint64
+		wantErr  bool
+	}{
+		{"50", 50 << 20, false},
+		{"500M", 500 << 20, false},
+		{"2G", 2 << 30, false},
+		{"1.5g", 1536 << 20, false},
+		{"1024K", 1 << 20, false},
+		{"1 GiB", 1 << 30, false},
+		{"0", 0, false},
+		{"2X", 0, true},
+		{"-5M", 0, true},
+		{"G", 0, true},
+		{"1.2.3M", 0, true},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseMemorySize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMemorySize(%q) error = %v; wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if result != tt.expected {
+			t.Errorf("ParseMemorySize(%q) = %d; expected %d", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestSetLayout(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetLayout() != DefaultLayout {
+		t.Errorf("Expected the %q layout by default, got %q", DefaultLayout, cfg.GetLayout())
+	}
+
+	cfg.SetLayout("debugging chrome")
+	if cfg.GetLayout() != "debugging chrome" {
+		t.Errorf("Expected layout %q, got %q", "debugging chrome", cfg.GetLayout())
+	}
+}
+
+func TestSetSumThreads(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetSumThreads() {
+		t.Error("Expected threads to follow the name check by default")
+	}
+
+	cfg.SetSumThreads(true)
+	if !cfg.GetSumThreads() {
+		t.Error("Expected SumThreads to be true")
+	}
+}
+
+func TestSetAggregateAllChildren(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetAggregateAllChildren() {
+		t.Error("Expected the relatedness heuristic by default")
+	}
+
+	cfg.SetAggregateAllChildren(true)
+	if !cfg.GetAggregateAllChildren() {
+		t.Error("Expected AggregateAllChildren to be true")
+	}
+}
+
+func TestSetCPUDelta(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetCPUDelta() {
+		t.Error("Expected absolute CPU by default")
+	}
+
+	cfg.SetCPUDelta(true)
+	if !cfg.GetCPUDelta() {
+		t.Error("Expected CPUDelta to be true")
+	}
+}
+
+func TestSetHighlightTop(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetHighlightTop() {
+		t.Error("Expected no highlighting by default")
+	}
+
+	cfg.SetHighlightTop(true)
+	if !cfg.GetHighlightTop() {
+		t.Error("Expected HighlightTop to be true")
+	}
+}
+
+func TestSetHeatColumn(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetHeatColumn() {
+		t.Error("Expected no heat column by default")
+	}
+
+	cfg.SetHeatColumn(true)
+	if !cfg.GetHeatColumn() {
+		t.Error("Expected HeatColumn to be true")
+	}
+}
+
+func TestSetOnelineHeader(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetOnelineHeader() {
+		t.Error("Expected the full header by default")
+	}
+
+	cfg.SetOnelineHeader(true)
+	if !cfg.GetOnelineHeader() {
+		t.Error("Expected OnelineHeader to be true")
+	}
+}
+
+func TestSetHeaderDeltas(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetHeaderDeltas() {
+		t.Error("Expected header deltas to be off by default")
+	}
+
+	cfg.SetHeaderDeltas(true)
+	if !cfg.GetHeaderDeltas() {
+		t.Error("Expected HeaderDeltas to be true")
+	}
+}
+
+func TestSetWindowTitle(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetWindowTitle() {
+		t.Error("Expected the window title to be left alone by default")
+	}
+
+	cfg.SetWindowTitle(true)
+	if !cfg.GetWindowTitle() {
+		t.Error("Expected WindowTitle to be true")
+	}
+}
+
+func TestSetCollapseHeader(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetCollapseHeader() {
+		t.Error("Expected a fixed header by default")
+	}
+
+	cfg.SetCollapseHeader(true)
+	if !cfg.GetCollapseHeader() {
+		t.Error("Expected CollapseHeader to be true")
+	}
+}
+
+func TestSetStackedMemory(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetStackedMemory() {
+		t.Error("Expected a plain memory bar by default")
+	}
+
+	cfg.SetStackedMemory(true)
+	if !cfg.GetStackedMemory() {
+		t.Error("Expected StackedMemory to be true")
+	}
+}
+
+func TestSetShowSecurityContext(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetShowSecurityContext() {
+		t.Error("Expected the security context to be hidden by default")
+	}
+
+	cfg.SetShowSecurityContext(true)
+	if !cfg.GetShowSecurityContext() {
+		t.Error("Expected ShowSecurityContext to be true")
+	}
+}
+
+func TestSetSummaryOnly(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetSummaryOnly() {
+		t.Error("Expected the process list to be shown by default")
+	}
+
+	cfg.SetSummaryOnly(true)
+	if !cfg.GetSummaryOnly() {
+		t.Error("Expected SummaryOnly to be true")
+	}
+}
+
+func TestSetAlerts(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetAlertCPU() != 0 || cfg.GetAlertMemory() != 0 || cfg.GetAlertCommand() != "" {
+		t.Error("Expected alerts to be disabled by default")
+	}
+
+	cfg.SetAlertCPU(90)
+	cfg.SetAlertMemory(2 * 1024 * 1024 * 1024)
+	cfg.SetAlertCommand("notify-send brieftop")
+	if cfg.GetAlertCPU() != 90 {
+		t.Errorf("Expected alert CPU 90, got %v", cfg.GetAlertCPU())
+	}
+	if cfg.GetAlertMemory() != 2*1024*1024*1024 {
+		t.Errorf("Expected alert memory 2GB, got %d", cfg.GetAlertMemory())
+	}
+	if cfg.GetAlertCommand() != "notify-send brieftop" {
+		t.Errorf("Expected alert command to be set, got %q", cfg.GetAlertCommand())
+	}
+}
+
+func TestIconThresholds(t *testing.T) {
+	cfg := New()
+
+	if cfg.GetIconThresholds() != DefaultIconThresholds {
+		t.Errorf("Expected default icon thresholds, got %+v", cfg.GetIconThresholds())
+	}
+
+	custom := IconThresholds{High: 80, Medium: 40, Active: 1}
+	cfg.SetIconThresholds(custom)
+	if cfg.GetIconThresholds() != custom {
+		t.Errorf("Expected %+v, got %+v", custom, cfg.GetIconThresholds())
+	}
+
+	tests := []struct {
+		name       string
+		thresholds IconThresholds
+		wantErr    bool
+	}{
+		{"Default", DefaultIconThresholds, false},
+		{"Equal tiers", IconThresholds{High: 10, Medium: 10, Active: 10}, false},
+		{"Not descending", IconThresholds{High: 20, Medium: 50, Active: 5}, true},
+		{"Negative", IconThresholds{High: 50, Medium: 20, Active: -1}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.thresholds.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v; wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAddCategoryRule(t *testing.T) {
+	cfg := New()
+
+	if len(cfg.GetCategoryRules()) != len(DefaultCategoryRules) {
+		t.Fatalf("Expected %d default rules, got %d", len(DefaultCategoryRules), len(cfg.GetCategoryRules()))
+	}
+
+	rule := CategoryRule{Category: "build", Pattern: "cargo"}
+	cfg.AddCategoryRule(rule)
+
+	rules := cfg.GetCategoryRules()
+	if rules[0] != rule {
+		t.Errorf("Expected added rule to take precedence, got %+v first", rules[0])
+	}
+	if len(DefaultCategoryRules) > 0 && DefaultCategoryRules[0] == rule {
+		t.Error("AddCategoryRule modified DefaultCategoryRules")
+	}
+}
+
+func TestSetKeyBinding(t *testing.T) {
+	cfg := New()
+
+	if len(cfg.GetKeyBindings()) != 0 {
+		t.Fatalf("Expected no key overrides by default, got %v", cfg.GetKeyBindings())
+	}
+
+	cfg.SetKeyBinding("sort", []string{"x"})
+	bindings := cfg.GetKeyBindings()
+	if len(bindings["sort"]) != 1 || bindings["sort"][0] != "x" {
+		t.Errorf("Expected sort bound to x, got %v", bindings["sort"])
+	}
+
+	bindings["sort"][0] = "y"
+	if cfg.GetKeyBindings()["sort"][0] != "x" {
+		t.Error("GetKeyBindings returned shared storage")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	cfg := New()
+	instant := time.Date(2024, 3, 15, 12, 30, 45, 0, time.UTC)
+
+	cfg.SetTimeZone(time.UTC)
+	if got := cfg.FormatTime(instant); got != "2024-03-15 12:30:45" {
+		t.Errorf("Expected default layout in UTC, got %q", got)
+	}
+
+	cfg.SetTimeFormat("15:04 MST")
+	cfg.SetTimeZone(time.FixedZone("EST", -5*60*60))
+	if got := cfg.FormatTime(instant); got != "07:30 EST" {
+		t.Errorf("Expected custom layout and zone, got %q", got)
+	}
+}
+
+func TestParseSortMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected SortMode
+		wantErr  bool
+	}{
+		{"cpu", SortByCPU, false},
+		{"mem", SortByMemory, false},
+		{"composite", SortByComposite, false},
+		{"name", SortByCPU, true},
+	}
+
+	for _, tt := range tests {
+		mode, err := ParseSortMode(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSortMode(%q) error = %v; wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if mode != tt.expected {
+			t.Errorf("ParseSortMode(%q) = %v; expected %v", tt.name, mode, tt.expected)
+		}
+		if !tt.wantErr && mode.String() != tt.name {
+			t.Errorf("SortMode(%d).String() = %q; expected %q", mode, mode.String(), tt.name)
+		}
+	}
+
+	if SortByComposite.Next() != SortByCPU {
+		t.Error("Expected Next() to wrap from composite to cpu")
+	}
+}
+
+func TestParseGroupSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected GroupSort
+		wantErr  bool
+	}{
+		{"follow", GroupSortFollow, false},
+		{"cpu", GroupSortCPU, false},
+		{"mem", GroupSortMemory, false},
+		{"count", GroupSortCount, false},
+		{"pid", GroupSortFollow, true},
+	}
+
+	for _, tt := range tests {
+		sort, err := ParseGroupSort(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseGroupSort(%q) error = %v; wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if sort != tt.expected {
+			t.Errorf("ParseGroupSort(%q) = %v; expected %v", tt.name, sort, tt.expected)
+		}
+		if !tt.wantErr && sort.String() != tt.name {
+			t.Errorf("GroupSort(%d).String() = %q; expected %q", sort, sort.String(), tt.name)
+		}
+	}
+}
+
+func TestParseChildSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected ChildSort
+		wantErr  bool
+	}{
+		{"follow", ChildSortFollow, false},
+		{"cpu", ChildSortCPU, false},
+		{"mem", ChildSortMemory, false},
+		{"pid", ChildSortPID, false},
+		{"name", ChildSortFollow, true},
+	}
+
+	for _, tt := range tests {
+		sort, err := ParseChildSort(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseChildSort(%q) error = %v; wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if sort != tt.expected {
+			t.Errorf("ParseChildSort(%q) = %v; expected %v", tt.name, sort, tt.expected)
+		}
+		if !tt.wantErr && sort.String() != tt.name {
+			t.Errorf("ChildSort(%d).String() = %q; expected %q", sort, sort.String(), tt.name)
+		}
+	}
+
+	if ChildSortPID.Next() != ChildSortFollow {
+		t.Error("Expected Next() to wrap from pid to follow")
+	}
+	if ChildSortFollow.Prev() != ChildSortPID {
+		t.Error("Expected Prev() to wrap from follow to pid")
+	}
+	if New().GetChildSort() != ChildSortFollow {
+		t.Error("Expected children to follow the main sort by default")
+	}
+}
+
+func TestParseCPUBar(t *testing.T) {
+	for _, bar := range []CPUBar{CPUBarOff, CPUBarAbsolute, CPUBarRelative} {
+		parsed, err := ParseCPUBar(bar.String())
+		if err != nil || parsed != bar {
+			t.Errorf("ParseCPUBar(%q) = %v, %v; expected %v", bar.String(), parsed, err, bar)
+		}
+	}
+	if _, err := ParseCPUBar("log"); err == nil {
+		t.Error("Expected an error for an unknown bar mode")
+	}
+	if CPUBarRelative.Next() != CPUBarOff || CPUBarOff.Prev() != CPUBarRelative {
+		t.Error("Expected Next() and Prev() to wrap around")
+	}
+
+	cfg := New()
+	if cfg.GetCPUBar() != CPUBarOff {
+		t.Error("Expected the CPU bar to be off by default")
+	}
+	cfg.SetCPUBar(CPUBarRelative)
+	if cfg.GetCPUBar() != CPUBarRelative {
+		t.Error("Expected CPUBar to be relative after SetCPUBar(CPUBarRelative)")
+	}
+}
+
+func TestParseColorProfile(t *testing.T) {
+	for _, profile := range []ColorProfile{ColorProfileAuto, ColorProfileTrueColor, ColorProfile256, ColorProfile16, ColorProfileMono} {
+		parsed, err := ParseColorProfile(profile.String())
+		if err != nil || parsed != profile {
+			t.Errorf("ParseColorProfile(%q) = %v, %v; expected %v", profile.String(), parsed, err, profile)
+		}
+	}
+	if _, err := ParseColorProfile("8"); err == nil {
+		t.Error("Expected an error for an unknown color profile")
+	}
+
+	cfg := New()
+	if cfg.GetColorProfile() != ColorProfileAuto {
+		t.Error("Expected color detection to be automatic by default")
+	}
+	cfg.SetColorProfile(ColorProfileMono)
+	if cfg.GetColorProfile() != ColorProfileMono {
+		t.Error("Expected ColorProfile to be mono after SetColorProfile(ColorProfileMono)")
+	}
+}
+
+func TestParseColumnOrder(t *testing.T) {
+	order, err := ParseColumnOrder([]string{ColumnTTY, ColumnFaults})
+	if err != nil {
+		t.Fatalf("ParseColumnOrder() error = %v", err)
+	}
+	expected := []string{ColumnTTY, ColumnFaults, ColumnCPUBar, ColumnMemPercent, ColumnCPUTime, ColumnAvgCPU}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("ParseColumnOrder = %v; expected unlisted columns appended: %v", order, expected)
+	}
+
+	for _, names := range [][]string{{"pid"}, {ColumnTTY, ColumnTTY}} {
+		if _, err := ParseColumnOrder(names); err == nil {
+			t.Errorf("ParseColumnOrder(%v) accepted an invalid order", names)
+		}
+	}
+
+	cfg := New()
+	if strings.Join(cfg.GetColumnOrder(), ",") != strings.Join(DefaultColumnOrder, ",") {
+		t.Errorf("Expected the default column order, got %v", cfg.GetColumnOrder())
+	}
+	cfg.GetColumnOrder()[0] = ColumnTTY
+	if cfg.GetColumnOrder()[0] != ColumnCPUBar {
+		t.Error("GetColumnOrder returned the config's own slice")
+	}
+}
+
+func TestParseBarGlyphs(t *testing.T) {
+	for _, preset := range BarPresets {
+		if glyphs, err := ParseBarGlyphs(preset.Name); err != nil || glyphs.Full != preset.Glyphs.Full {
+			t.Errorf("ParseBarGlyphs(%q) = %v, %v; expected the preset", preset.Name, glyphs, err)
+		}
+	}
+	if i := BarPreset(BarPresets[2].Glyphs); i != 2 {
+		t.Errorf("BarPreset(squares) = %d; expected 2", i)
+	}
+	glyphs, err := ParseBarGlyphs("=.▌")
+	if err != nil || glyphs.Full != '=' || glyphs.Empty != '.' || string(glyphs.Partial) != "▌" {
+		t.Errorf("ParseBarGlyphs(\"=.▌\") = %+v, %v", glyphs, err)
+	}
+	if i := BarPreset(glyphs); i != -1 {
+		t.Errorf("BarPreset(custom) = %d; expected -1", i)
+	}
+	for _, bad := range []string{"", "#", "#\t"} {
+		if _, err := ParseBarGlyphs(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+
+	cfg := New()
+	if cfg.GetBarGlyphs().Full != '█' {
+		t.Errorf("Expected block bars by default, got %+v", cfg.GetBarGlyphs())
+	}
+	cfg.SetBarGlyphs(BarGlyphs{Full: '#', Empty: '-'})
+	if cfg.GetBarGlyphs().Full != '#' {
+		t.Error("Expected # bars after SetBarGlyphs")
+	}
+}
@@ -0,0 +1,335 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// File is the on-disk config format (JSON). Every field is optional: unset
+// fields keep their defaults, and command line flags override the file.
+type File struct {
+	CPUThreshold         *float64            `json:"cpu,omitempty"`
+	MemoryThreshold      *uint64             `json:"memory_mb,omitempty"`
+	RefreshRate          string              `json:"refresh,omitempty"`
+	CPUWindow            string              `json:"cpu_window,omitempty"`
+	Linger               string              `json:"linger,omitempty"`       // How long exited processes stay listed
+	CPUBlocking          string              `json:"cpu_blocking,omitempty"` // How long the first system CPU reading takes
+	BarChars             string              `json:"bar_chars,omitempty"`    // Progress bar preset or characters, as --bar-chars
+	MemoryMetric         string              `json:"mem_metric,omitempty"`
+	SortMode             string              `json:"sort,omitempty"`
+	ChildSort            string              `json:"child_sort,omitempty"`
+	GroupSort            string              `json:"group_sort,omitempty"`
+	TimeFormat           string              `json:"time_format,omitempty"`
+	TimeZone             string              `json:"timezone,omitempty"`
+	ShowThreads          *bool               `json:"show_threads,omitempty"`
+	CollapseThreads      *bool               `json:"collapse_threads,omitempty"`
+	AggregateAllChildren *bool               `json:"aggregate_all_children,omitempty"`
+	SumThreads           *bool               `json:"sum_threads,omitempty"`
+	TreeView             *bool               `json:"tree_view,omitempty"`
+	ShowMemPercent       *bool               `json:"mem_percent,omitempty"`
+	CPUDelta             *bool               `json:"cpu_delta,omitempty"`
+	ShowTTY              *bool               `json:"tty,omitempty"`
+	ShowCPUTime          *bool               `json:"cpu_time,omitempty"`
+	ShowAvgCPU           *bool               `json:"avg_cpu,omitempty"`
+	ShowFaults           *bool               `json:"faults,omitempty"`
+	ShowSecurityContext  *bool               `json:"security_context,omitempty"`
+	StackedMemory        *bool               `json:"stacked_memory,omitempty"`
+	CollapseHeader       *bool               `json:"collapse_header,omitempty"`
+	OnelineHeader        *bool               `json:"oneline_header,omitempty"`
+	HeaderDeltas         *bool               `json:"header_deltas,omitempty"`
+	WindowTitle          *bool               `json:"window_title,omitempty"`
+	HighlightTop         *bool               `json:"highlight_top,omitempty"`
+	HeatColumn           *bool               `json:"heat,omitempty"`
+	CPUBar               string              `json:"cpu_bar,omitempty"`
+	ColumnOrder          []string            `json:"column_order,omitempty"` // Optional columns left to right; unlisted ones follow
+	Precision            *int                `json:"precision,omitempty"`    // Decimal places for CPU and memory values
+	ColorProfile         string              `json:"color_profile,omitempty"`
+	IconThresholds       []float64           `json:"icon_thresholds,omitempty"` // High, medium, active CPU %
+	HideSelf             *bool               `json:"hide_self,omitempty"`
+	QuietStart           *bool               `json:"quiet_start,omitempty"`
+	IntervalAlign        *bool               `json:"interval_align,omitempty"`
+	RefreshOnKey         *bool               `json:"refresh_on_key,omitempty"`
+	ScanWorkers          *int                `json:"scan_workers,omitempty"`
+	MaxTreeDepth         *int                `json:"max_tree_depth,omitempty"` // Deepest tree view level; 0 for no limit
+	Categories           []CategoryRule      `json:"categories,omitempty"`     // First match wins, ahead of the defaults
+	Exclude              []string            `json:"exclude,omitempty"`        // Name globs of processes never listed
+	Critical             []string            `json:"critical,omitempty"`       // Names of must-run processes
+	CriticalBell         *bool               `json:"critical_bell,omitempty"`
+	KeyBindings          map[string][]string `json:"keys,omitempty"` // Action → keys, as with --bind
+	AlertCPU             *float64            `json:"alert_cpu,omitempty"`
+	AlertMemory          *uint64             `json:"alert_memory_mb,omitempty"`
+	AlertCommand         string              `json:"alert_command,omitempty"` // Run with sh -c when a process crosses an alert threshold
+}
+
+// DefaultPath is where brieftop looks for a config file when --config isn't
+// given, e.g. ~/.config/brieftop/config.json on Linux
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "brieftop", "config.json"), nil
+}
+
+// ReadFile parses a config file. Unknown fields are errors so a typo
+// doesn't silently leave a setting at its default.
+func ReadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var f File
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Validate reports every problem with the file's values rather than
+// stopping at the first. Key binding actions are checked by the UI.
+func (f *File) Validate() []error {
+	var errs []error
+	if f.CPUThreshold != nil && *f.CPUThreshold < 0 {
+		errs = append(errs, fmt.Errorf("cpu: %v must not be negative", *f.CPUThreshold))
+	}
+	if f.AlertCPU != nil && *f.AlertCPU < 0 {
+		errs = append(errs, fmt.Errorf("alert_cpu: %v must not be negative", *f.AlertCPU))
+	}
+	if f.RefreshRate != "" {
+		if rate, err := time.ParseDuration(f.RefreshRate); err != nil {
+			errs = append(errs, fmt.Errorf("refresh: %w", err))
+		} else if rate <= 0 {
+			errs = append(errs, fmt.Errorf("refresh: %v must be positive", rate))
+		}
+	}
+	if f.CPUWindow != "" {
+		if window, err := time.ParseDuration(f.CPUWindow); err != nil {
+			errs = append(errs, fmt.Errorf("cpu_window: %w", err))
+		} else if window < 0 {
+			errs = append(errs, fmt.Errorf("cpu_window: %v must not be negative", window))
+		}
+	}
+	if _, err := ParseColumnOrder(f.ColumnOrder); err != nil {
+		errs = append(errs, fmt.Errorf("column_order: %w", err))
+	}
+	if f.Linger != "" {
+		if linger, err := time.ParseDuration(f.Linger); err != nil {
+			errs = append(errs, fmt.Errorf("linger: %w", err))
+		} else if linger < 0 {
+			errs = append(errs, fmt.Errorf("linger: %v must not be negative", linger))
+		}
+	}
+	if f.BarChars != "" {
+		if _, err := ParseBarGlyphs(f.BarChars); err != nil {
+			errs = append(errs, fmt.Errorf("bar_chars: %w", err))
+		}
+	}
+	if f.CPUBlocking != "" {
+		if blocking, err := time.ParseDuration(f.CPUBlocking); err != nil {
+			errs = append(errs, fmt.Errorf("cpu_blocking: %w", err))
+		} else if blocking < 0 {
+			errs = append(errs, fmt.Errorf("cpu_blocking: %v must not be negative", blocking))
+		}
+	}
+	if f.MemoryMetric != "" && f.MemoryMetric != MemoryMetricRSS && f.MemoryMetric != MemoryMetricPSS {
+		errs = append(errs, fmt.Errorf("mem_metric: %q must be %q or %q", f.MemoryMetric, MemoryMetricRSS, MemoryMetricPSS))
+	}
+	if f.SortMode != "" {
+		if _, err := ParseSortMode(f.SortMode); err != nil {
+			errs = append(errs, fmt.Errorf("sort: %w", err))
+		}
+	}
+	if f.ChildSort != "" {
+		if _, err := ParseChildSort(f.ChildSort); err != nil {
+			errs = append(errs, fmt.Errorf("child_sort: %w", err))
+		}
+	}
+	if f.GroupSort != "" {
+		if _, err := ParseGroupSort(f.GroupSort); err != nil {
+			errs = append(errs, fmt.Errorf("group_sort: %w", err))
+		}
+	}
+	if f.CPUBar != "" {
+		if _, err := ParseCPUBar(f.CPUBar); err != nil {
+			errs = append(errs, fmt.Errorf("cpu_bar: %w", err))
+		}
+	}
+	if f.ColorProfile != "" {
+		if _, err := ParseColorProfile(f.ColorProfile); err != nil {
+			errs = append(errs, fmt.Errorf("color_profile: %w", err))
+		}
+	}
+	if f.TimeZone != "" {
+		if _, err := time.LoadLocation(f.TimeZone); err != nil {
+			errs = append(errs, fmt.Errorf("timezone: %w", err))
+		}
+	}
+	if f.Precision != nil && (*f.Precision < 0 || *f.Precision > MaxPrecision) {
+		errs = append(errs, fmt.Errorf("precision: %d must be between 0 and %d", *f.Precision, MaxPrecision))
+	}
+	if f.MaxTreeDepth != nil && *f.MaxTreeDepth < 0 {
+		errs = append(errs, fmt.Errorf("max_tree_depth: %d must not be negative", *f.MaxTreeDepth))
+	}
+	if f.ScanWorkers != nil && (*f.ScanWorkers < 1 || *f.ScanWorkers > MaxScanWorkers) {
+		errs = append(errs, fmt.Errorf("scan_workers: %d must be between 1 and %d", *f.ScanWorkers, MaxScanWorkers))
+	}
+	if f.IconThresholds != nil {
+		if len(f.IconThresholds) != 3 {
+			errs = append(errs, fmt.Errorf("icon_thresholds: expected [high, medium, active], got %d values", len(f.IconThresholds)))
+		} else if err := iconThresholds(f.IconThresholds).Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("icon_thresholds: %w", err))
+		}
+	}
+	for i, rule := range f.Categories {
+		if rule.Category == "" || rule.Pattern == "" {
+			errs = append(errs, fmt.Errorf("categories[%d]: category and pattern are both required", i))
+		}
+	}
+	for i, pattern := range f.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("exclude[%d]: %q: %w", i, pattern, err))
+		}
+	}
+	actions := make([]string, 0, len(f.KeyBindings))
+	for action := range f.KeyBindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		if len(f.KeyBindings[action]) == 0 {
+			errs = append(errs, fmt.Errorf("keys.%s: no keys given", action))
+		}
+	}
+	return errs
+}
+
+// Apply copies the file's settings into cfg. It assumes Validate passed;
+// values that fail to parse are skipped.
+func (f *File) Apply(cfg *Config) {
+	if f.CPUThreshold != nil {
+		cfg.SetCPUThreshold(*f.CPUThreshold)
+	}
+	if f.MemoryThreshold != nil {
+		cfg.SetMemoryThreshold(*f.MemoryThreshold * 1024 * 1024)
+	}
+	if rate, err := time.ParseDuration(f.RefreshRate); err == nil && rate > 0 {
+		cfg.SetRefreshRate(rate)
+	}
+	if window, err := time.ParseDuration(f.CPUWindow); err == nil && window >= 0 {
+		cfg.SetCPUWindow(window)
+	}
+	if linger, err := time.ParseDuration(f.Linger); err == nil && linger >= 0 {
+		cfg.SetLinger(linger)
+	}
+	if blocking, err := time.ParseDuration(f.CPUBlocking); err == nil && blocking >= 0 {
+		cfg.SetCPUBlocking(blocking)
+	}
+	if glyphs, err := ParseBarGlyphs(f.BarChars); err == nil {
+		cfg.SetBarGlyphs(glyphs)
+	}
+	if order, err := ParseColumnOrder(f.ColumnOrder); err == nil && len(f.ColumnOrder) > 0 {
+		cfg.SetColumnOrder(order)
+	}
+	if f.MemoryMetric != "" {
+		cfg.SetMemoryMetric(f.MemoryMetric)
+	}
+	if mode, err := ParseSortMode(f.SortMode); err == nil {
+		cfg.SetSortMode(mode)
+	}
+	if childSort, err := ParseChildSort(f.ChildSort); err == nil {
+		cfg.SetChildSort(childSort)
+	}
+	if groupSort, err := ParseGroupSort(f.GroupSort); err == nil {
+		cfg.SetGroupSort(groupSort)
+	}
+	if bar, err := ParseCPUBar(f.CPUBar); err == nil {
+		cfg.SetCPUBar(bar)
+	}
+	if f.AlertCPU != nil {
+		cfg.SetAlertCPU(*f.AlertCPU)
+	}
+	if f.AlertMemory != nil {
+		cfg.SetAlertMemory(*f.AlertMemory * 1024 * 1024)
+	}
+	if f.AlertCommand != "" {
+		cfg.SetAlertCommand(f.AlertCommand)
+	}
+	if profile, err := ParseColorProfile(f.ColorProfile); err == nil {
+		cfg.SetColorProfile(profile)
+	}
+	if f.TimeFormat != "" {
+		cfg.SetTimeFormat(f.TimeFormat)
+	}
+	if loc, err := time.LoadLocation(f.TimeZone); err == nil && f.TimeZone != "" {
+		cfg.SetTimeZone(loc)
+	}
+	if f.Precision != nil {
+		cfg.SetPrecision(*f.Precision)
+	}
+	if f.MaxTreeDepth != nil {
+		cfg.SetMaxTreeDepth(*f.MaxTreeDepth)
+	}
+	if f.ScanWorkers != nil {
+		cfg.SetScanWorkers(*f.ScanWorkers)
+	}
+	if len(f.IconThresholds) == 3 {
+		cfg.SetIconThresholds(iconThresholds(f.IconThresholds))
+	}
+	applyBool(f.ShowThreads, cfg.SetShowThreads)
+	applyBool(f.CollapseThreads, cfg.SetCollapseThreads)
+	applyBool(f.AggregateAllChildren, cfg.SetAggregateAllChildren)
+	applyBool(f.SumThreads, cfg.SetSumThreads)
+	applyBool(f.TreeView, cfg.SetTreeView)
+	applyBool(f.ShowMemPercent, cfg.SetShowMemPercent)
+	applyBool(f.CPUDelta, cfg.SetCPUDelta)
+	applyBool(f.ShowTTY, cfg.SetShowTTY)
+	applyBool(f.ShowCPUTime, cfg.SetShowCPUTime)
+	applyBool(f.ShowAvgCPU, cfg.SetShowAvgCPU)
+	applyBool(f.ShowFaults, cfg.SetShowFaults)
+	applyBool(f.StackedMemory, cfg.SetStackedMemory)
+	applyBool(f.CollapseHeader, cfg.SetCollapseHeader)
+	applyBool(f.OnelineHeader, cfg.SetOnelineHeader)
+	applyBool(f.WindowTitle, cfg.SetWindowTitle)
+	applyBool(f.HeaderDeltas, cfg.SetHeaderDeltas)
+	applyBool(f.HighlightTop, cfg.SetHighlightTop)
+	applyBool(f.HeatColumn, cfg.SetHeatColumn)
+	applyBool(f.ShowSecurityContext, cfg.SetShowSecurityContext)
+	applyBool(f.HideSelf, cfg.SetHideSelf)
+	applyBool(f.QuietStart, cfg.SetQuietStart)
+	applyBool(f.IntervalAlign, cfg.SetIntervalAlign)
+	applyBool(f.RefreshOnKey, cfg.SetRefreshOnKey)
+
+	// AddCategoryRule prepends, so add in reverse to keep the file's order
+	for i := len(f.Categories) - 1; i >= 0; i-- {
+		cfg.AddCategoryRule(f.Categories[i])
+	}
+	for _, pattern := range f.Exclude {
+		cfg.AddExclude(pattern)
+	}
+	for _, name := range f.Critical {
+		cfg.AddCritical(name)
+	}
+	applyBool(f.CriticalBell, cfg.SetCriticalBell)
+	for action, keys := range f.KeyBindings {
+		cfg.SetKeyBinding(action, keys)
+	}
+}
+
+func applyBool(value *bool, set func(bool)) {
+	if value != nil {
+		set(*value)
+	}
+}
+
+func iconThresholds(values []float64) IconThresholds {
+	return IconThresholds{High: values[0], Medium: values[1], Active: values[2]}
+}
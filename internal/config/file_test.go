@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadFileApply(t *testing.T) {
+	path := writeConfig(t, `{
+		"cpu": 10,
+		"memory_mb": 200,
+		"refresh": "2s",
+		"sort": "mem",
+		"tty": true,
+		"categories": [{"category": "build", "pattern": "cargo"}, {"category": "ci", "pattern": "runner"}],
+		"keys": {"sort": ["x"]}
+	}`)
+
+	f, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if errs := f.Validate(); len(errs) != 0 {
+		t.Fatalf("Validate() = %v; expected no problems", errs)
+	}
+
+	cfg := New()
+	f.Apply(cfg)
+
+	if cfg.GetCPUThreshold() != 10 {
+		t.Errorf("Expected CPU threshold 10, got %v", cfg.GetCPUThreshold())
+	}
+	if cfg.GetMemoryThreshold() != 200*1024*1024 {
+		t.Errorf("Expected memory threshold 200MB, got %d", cfg.GetMemoryThreshold())
+	}
+	if cfg.GetRefreshRate() != 2*time.Second {
+		t.Errorf("Expected refresh rate 2s, got %v", cfg.GetRefreshRate())
+	}
+	if cfg.GetSortMode() != SortByMemory || !cfg.GetShowTTY() {
+		t.Errorf("Expected mem sort with TTY column, got %v / %v", cfg.GetSortMode(), cfg.GetShowTTY())
+	}
+	if cfg.GetMemoryMetric() != MemoryMetricRSS || !cfg.GetShowThreads() {
+		t.Error("Expected unset fields to keep their defaults")
+	}
+	if rules := cfg.GetCategoryRules(); rules[0].Category != "build" || rules[1].Category != "ci" {
+		t.Errorf("Expected file categories first and in order, got %+v", rules[:2])
+	}
+	if keys := cfg.GetKeyBindings()["sort"]; len(keys) != 1 || keys[0] != "x" {
+		t.Errorf("Expected sort bound to x, got %v", keys)
+	}
+}
+
+func TestReadFileRejectsUnknownFields(t *testing.T) {
+	if _, err := ReadFile(writeConfig(t, `{"cpu_threshold": 10}`)); err == nil {
+		t.Error("Expected an error for a misspelled field")
+	}
+	if _, err := ReadFile(writeConfig(t, `{"cpu": "high"}`)); err == nil {
+		t.Error("Expected an error for a mistyped field")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	path := writeConfig(t, `{
+		"cpu": -1,
+		"refresh": "soon",
+		"mem_metric": "uss",
+		"sort": "name",
+		"child_sort": "size",
+		"timezone": "Mars/Olympus",
+		"categories": [{"category": "build"}],
+		"keys": {"sort": []},
+		"icon_thresholds": [5, 20, 50],
+		"scan_workers": 0,
+		"exclude": ["kworker*", "rcu_["],
+		"alert_cpu": -5,
+		"column_order": ["tty", "pid"],
+		"cpu_blocking": "-1s",
+		"bar_chars": "#",
+		"max_tree_depth": -2
+	}`)
+
+	f, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if errs := f.Validate(); len(errs) != 16 {
+		t.Errorf("Validate() found %d problems, expected 16: %v", len(errs), errs)
+	}
+}
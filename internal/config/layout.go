@@ -0,0 +1,46 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultLayout is the layout y saves to and j restores without --layout
+const DefaultLayout = "default"
+
+// Layouts are named sets of expanded processes, by name, e.g. a "chrome"
+// layout that expands chrome and its helpers for a recurring investigation
+type Layouts map[string][]string
+
+// LayoutsPath is where layouts are saved, next to the view state
+func LayoutsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "brieftop", "layouts.json"), nil
+}
+
+// ReadLayouts loads the saved layouts; a missing file has none
+func ReadLayouts(path string) (Layouts, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Layouts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	layouts := Layouts{}
+	if err := json.Unmarshal(data, &layouts); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return layouts, nil
+}
+
+// WriteFile saves the layouts, replacing the file atomically
+func (l Layouts) WriteFile(path string) error {
+	return writeJSONFile(path, l)
+}
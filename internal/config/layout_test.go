@@ -0,0 +1,30 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brieftop", "layouts.json")
+
+	layouts, err := ReadLayouts(path)
+	if err != nil || len(layouts) != 0 {
+		t.Fatalf("ReadLayouts(missing) = %v, %v; expected no layouts and no error", layouts, err)
+	}
+
+	layouts["debugging chrome"] = []string{"chrome", "chrome_crashpad"}
+	layouts[DefaultLayout] = []string{"postgres"}
+	if err := layouts.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+
+	loaded, err := ReadLayouts(path)
+	if err != nil {
+		t.Fatalf("ReadLayouts error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, layouts) {
+		t.Errorf("ReadLayouts = %v; expected %v", loaded, layouts)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// StatePath is where the view state is remembered between sessions, next
+// to the default config file
+func StatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "brieftop", "state.json"), nil
+}
+
+// ViewState captures the settings that are usually changed interactively
+// (sort orders, columns, thread display) as a File, so the state is saved
+// and restored with the same format, validation and Apply as a config file.
+// Thresholds and other config-file settings aren't included.
+func (c *Config) ViewState() *File {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flag := func(v bool) *bool { return &v }
+	return &File{
+		SortMode:             c.SortMode.String(),
+		ChildSort:            c.ChildSort.String(),
+		GroupSort:            c.GroupSort.String(),
+		CPUBar:               c.CPUBar.String(),
+		ShowThreads:          flag(c.ShowThreads),
+		CollapseThreads:      flag(c.CollapseThreads),
+		AggregateAllChildren: flag(c.AggregateAllChildren),
+		SumThreads:           flag(c.SumThreads),
+		TreeView:             flag(c.TreeView),
+		HighlightTop:         flag(c.HighlightTop),
+		HeatColumn:           flag(c.HeatColumn),
+		ShowMemPercent:       flag(c.ShowMemPercent),
+		CPUDelta:             flag(c.CPUDelta),
+		ShowTTY:              flag(c.ShowTTY),
+		ShowCPUTime:          flag(c.ShowCPUTime),
+		ShowAvgCPU:           flag(c.ShowAvgCPU),
+		ShowFaults:           flag(c.ShowFaults),
+		ColumnOrder:          append([]string(nil), c.ColumnOrder...),
+	}
+}
+
+// WriteFile saves f as indented JSON, creating its directory if needed. The
+// file is replaced atomically so an interrupted write can't leave it empty.
+func (f *File) WriteFile(path string) error {
+	return writeJSONFile(path, f)
+}
+
+// writeJSONFile saves v as indented JSON through a temporary file, so the
+// file at path is replaced atomically
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestViewStateRoundTrip(t *testing.T) {
+	cfg := New()
+	cfg.SetSortMode(SortByComposite)
+	cfg.SetChildSort(ChildSortPID)
+	cfg.SetCPUBar(CPUBarRelative)
+	cfg.SetShowThreads(false)
+	cfg.SetShowMemPercent(true)
+	cfg.SetShowCPUTime(true)
+	cfg.SetColumnOrder([]string{ColumnTTY, ColumnCPUBar, ColumnMemPercent, ColumnCPUTime, ColumnAvgCPU, ColumnFaults})
+	cfg.SetCPUThreshold(42) // Not view state
+
+	path := filepath.Join(t.TempDir(), "brieftop", "state.json")
+	if err := cfg.ViewState().WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	f, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if errs := f.Validate(); len(errs) != 0 {
+		t.Fatalf("saved state doesn't validate: %v", errs)
+	}
+
+	restored := New()
+	f.Apply(restored)
+	if restored.GetSortMode() != SortByComposite || restored.GetChildSort() != ChildSortPID || restored.GetCPUBar() != CPUBarRelative {
+		t.Errorf("sort/child sort/CPU bar not restored: %v/%v/%v", restored.GetSortMode(), restored.GetChildSort(), restored.GetCPUBar())
+	}
+	if restored.GetShowThreads() || !restored.GetShowMemPercent() || !restored.GetShowCPUTime() || restored.GetShowTTY() {
+		t.Error("column and thread toggles not restored")
+	}
+	if order := restored.GetColumnOrder(); order[0] != ColumnTTY || order[1] != ColumnCPUBar {
+		t.Errorf("column order not restored: %v", order)
+	}
+	if restored.GetCPUThreshold() != New().GetCPUThreshold() {
+		t.Errorf("CPU threshold %v leaked into the view state", restored.GetCPUThreshold())
+	}
+}
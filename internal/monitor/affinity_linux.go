@@ -0,0 +1,52 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// statProcessor is the /proc/PID/stat field holding the CPU the process
+// last ran on
+const statProcessor = 39
+
+// readAllowedCPUs returns the CPUs a process may run on (its affinity, as
+// set by taskset), e.g. "0-3,8", read from /proc/PID/status
+func readAllowedCPUs(pid int32) (string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return "", err
+	}
+	return parseStatusField(data, "Cpus_allowed_list")
+}
+
+// readLastCPU returns the CPU the process last ran on, read from
+// /proc/PID/stat. For a busy process this is usually the core it is
+// loading right now.
+func readLastCPU(pid int32) (int, error) {
+	fields, err := readStatFields(pid)
+	if err != nil {
+		return 0, err
+	}
+	field, err := statField(fields, statProcessor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(field)
+}
+
+// parseStatusField returns the value of a "Key:\tvalue" line from
+// /proc/PID/status content
+func parseStatusField(data []byte, key string) (string, error) {
+	prefix := []byte(key + ":")
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if bytes.HasPrefix(line, prefix) {
+			return string(bytes.TrimSpace(line[len(prefix):])), nil
+		}
+	}
+	return "", fmt.Errorf("no %s field found", key)
+}
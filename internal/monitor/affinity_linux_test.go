@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseStatusField(t *testing.T) {
+	status := "Name:\tpostgres\n" +
+		"Cpus_allowed:\tf\n" +
+		"Cpus_allowed_list:\t0-3\n" +
+		"Mems_allowed_list:\t0\n"
+
+	tests := []struct {
+		name     string
+		key      string
+		expected string
+		wantErr  bool
+	}{
+		{"Affinity list", "Cpus_allowed_list", "0-3", false},
+		{"Prefix of another key", "Cpus_allowed", "f", false},
+		{"Missing field", "NSpid", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseStatusField([]byte(status), tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatusField() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parseStatusField(%q) = %q; expected %q", tt.key, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadLastCPU(t *testing.T) {
+	cpu, err := readLastCPU(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("readLastCPU(self) error = %v", err)
+	}
+	if cpu < 0 {
+		t.Errorf("readLastCPU(self) = %d; expected a CPU number", cpu)
+	}
+}
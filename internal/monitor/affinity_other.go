@@ -0,0 +1,15 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readAllowedCPUs is only supported on Linux; the detail pane omits it
+func readAllowedCPUs(_ int32) (string, error) {
+	return "", errors.New("CPU affinity is not supported on this platform")
+}
+
+// readLastCPU is only supported on Linux; the detail pane omits it
+func readLastCPU(_ int32) (int, error) {
+	return 0, errors.New("last CPU is not supported on this platform")
+}
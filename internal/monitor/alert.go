@@ -0,0 +1,144 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	alertHookTimeout  = 10 * time.Second // A hung hook is killed after this long
+	alertHookInterval = 30 * time.Second // Minimum time between hook runs
+)
+
+// Alert is a process that went over an alert threshold on the last scan
+type Alert struct {
+	PID         int32
+	Name        string
+	CPUPercent  float64
+	MemoryBytes uint64
+}
+
+// String describes the alert for the footer
+func (a Alert) String() string {
+	return fmt.Sprintf("%s (PID %d) over alert threshold: %.1f%% CPU, %s", a.Name, a.PID, a.CPUPercent, FormatBytes(a.MemoryBytes))
+}
+
+// overAlert reports whether a process is at or above either alert threshold;
+// a zero threshold is disabled
+func overAlert(info *ProcessInfo, cpu float64, memory uint64) bool {
+	return (cpu > 0 && info.CPUPercent >= cpu) || (memory > 0 && info.MemoryBytes >= memory)
+}
+
+// trackAlerts records processes that crossed an alert threshold since the
+// previous scan. A process alerts once per crossing, not on every scan it
+// stays over. Callers must hold m.mu.
+func (m *Monitor) trackAlerts(all map[int32]*ProcessInfo) {
+	cpu, memory := m.config.GetAlertCPU(), m.config.GetAlertMemory()
+	over := make(map[int32]bool)
+	m.alerts = nil
+	for pid, info := range all {
+		if !overAlert(info, cpu, memory) {
+			continue
+		}
+		over[pid] = true
+		if !m.alerting[pid] {
+			m.alerts = append(m.alerts, Alert{PID: pid, Name: info.Name, CPUPercent: info.CPUPercent, MemoryBytes: info.MemoryBytes})
+		}
+	}
+	m.alerting = over
+	sort.Slice(m.alerts, func(i, j int) bool { return m.alerts[i].PID < m.alerts[j].PID })
+}
+
+// LastAlerts returns the processes that crossed an alert threshold on the
+// most recent scan, ordered by PID
+func (m *Monitor) LastAlerts() []Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.alerts
+}
+
+// AlertHook runs a shell command when a process crosses an alert threshold.
+// Runs are rate limited to one per alertHookInterval; alerts in between are
+// counted and passed to the next run as BRIEFTOP_SUPPRESSED.
+type AlertHook struct {
+	command string
+	now     func() time.Time
+
+	mu         sync.Mutex
+	last       time.Time
+	suppressed int
+	err        error // Failure of the most recent run, until Err reads it
+}
+
+// NewAlertHook returns a hook running command with sh -c (cmd /C on Windows)
+func NewAlertHook(command string) *AlertHook {
+	return &AlertHook{command: command, now: time.Now}
+}
+
+// Fire starts the hook for a in the background, unless a run started within
+// alertHookInterval; it reports whether the hook was started
+func (h *AlertHook) Fire(a Alert) bool {
+	h.mu.Lock()
+	now := h.now()
+	if !h.last.IsZero() && now.Sub(h.last) < alertHookInterval {
+		h.suppressed++
+		h.mu.Unlock()
+		return false
+	}
+	h.last = now
+	env := alertEnv(a, h.suppressed)
+	h.suppressed = 0
+	h.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+		defer cancel()
+		cmd := shellCommand(ctx, h.command)
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if ctx.Err() != nil {
+				err = fmt.Errorf("timed out after %v", alertHookTimeout)
+			} else if line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n"); line != "" {
+				err = fmt.Errorf("%w: %s", err, line)
+			}
+			h.mu.Lock()
+			h.err = err
+			h.mu.Unlock()
+		}
+	}()
+	return true
+}
+
+// Err returns and clears the failure of the most recent run, if any
+func (h *AlertHook) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	err := h.err
+	h.err = nil
+	return err
+}
+
+// alertEnv describes an alert to the hook as environment variables
+func alertEnv(a Alert, suppressed int) []string {
+	return []string{
+		fmt.Sprintf("BRIEFTOP_PID=%d", a.PID),
+		"BRIEFTOP_NAME=" + a.Name,
+		fmt.Sprintf("BRIEFTOP_CPU=%.1f", a.CPUPercent),
+		fmt.Sprintf("BRIEFTOP_MEMORY=%d", a.MemoryBytes),
+		fmt.Sprintf("BRIEFTOP_SUPPRESSED=%d", suppressed),
+	}
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestTrackAlerts(t *testing.T) {
+	cfg := config.New()
+	cfg.SetAlertCPU(80)
+	cfg.SetAlertMemory(1024 * 1024 * 1024)
+	m := &Monitor{config: cfg}
+
+	scan := func(cpu float64, memory uint64) map[int32]*ProcessInfo {
+		return map[int32]*ProcessInfo{
+			1: {PID: 1, Name: "init"},
+			2: {PID: 2, Name: "build", CPUPercent: cpu, MemoryBytes: memory},
+		}
+	}
+
+	m.trackAlerts(scan(10, 0))
+	if alerts := m.LastAlerts(); len(alerts) != 0 {
+		t.Fatalf("expected no alerts under the thresholds, got %v", alerts)
+	}
+
+	m.trackAlerts(scan(95, 0))
+	if alerts := m.LastAlerts(); len(alerts) != 1 || alerts[0].PID != 2 || alerts[0].CPUPercent != 95 {
+		t.Fatalf("expected PID 2 to alert on crossing, got %v", alerts)
+	}
+
+	// Staying over, even by the other threshold, doesn't alert again
+	m.trackAlerts(scan(50, 2*1024*1024*1024))
+	if alerts := m.LastAlerts(); len(alerts) != 0 {
+		t.Fatalf("expected no repeat alert while over, got %v", alerts)
+	}
+
+	m.trackAlerts(scan(10, 0))
+	m.trackAlerts(scan(90, 0))
+	if alerts := m.LastAlerts(); len(alerts) != 1 {
+		t.Errorf("expected a new alert after dropping below, got %v", alerts)
+	}
+
+	cfg.SetAlertCPU(0)
+	cfg.SetAlertMemory(0)
+	m.trackAlerts(scan(10, 0))
+	m.trackAlerts(scan(100, 4*1024*1024*1024))
+	if alerts := m.LastAlerts(); len(alerts) != 0 {
+		t.Errorf("expected zero thresholds to disable alerts, got %v", alerts)
+	}
+}
+
+func TestAlertHookRateLimit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	h := NewAlertHook("true")
+	h.now = func() time.Time { return now }
+
+	if !h.Fire(Alert{PID: 1, Name: "a"}) {
+		t.Fatal("expected the first alert to run the hook")
+	}
+	now = now.Add(alertHookInterval / 2)
+	if h.Fire(Alert{PID: 2, Name: "b"}) || h.Fire(Alert{PID: 3, Name: "c"}) {
+		t.Fatal("expected alerts within the interval to be suppressed")
+	}
+	if h.suppressed != 2 {
+		t.Errorf("suppressed = %d; expected 2", h.suppressed)
+	}
+	now = now.Add(alertHookInterval)
+	if !h.Fire(Alert{PID: 4, Name: "d"}) {
+		t.Error("expected the hook to run again after the interval")
+	}
+	if h.suppressed != 0 {
+		t.Errorf("suppressed = %d after a run; expected 0", h.suppressed)
+	}
+}
+
+func TestAlertEnv(t *testing.T) {
+	env := alertEnv(Alert{PID: 42, Name: "ffmpeg", CPUPercent: 97.25, MemoryBytes: 2048}, 3)
+	expected := []string{"BRIEFTOP_PID=42", "BRIEFTOP_NAME=ffmpeg", "BRIEFTOP_CPU=97.2", "BRIEFTOP_MEMORY=2048", "BRIEFTOP_SUPPRESSED=3"}
+	if len(env) != len(expected) {
+		t.Fatalf("alertEnv = %v; expected %v", env, expected)
+	}
+	for i := range expected {
+		if env[i] != expected[i] {
+			t.Errorf("alertEnv[%d] = %q; expected %q", i, env[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"sort"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// stuckRefreshes is how many consecutive refreshes a process must spend in
+// uninterruptible sleep before it is reported as stuck. Short D-state waits
+// are normal I/O; ones that persist usually mean a hung mount or bad disk.
+const stuckRefreshes = 3
+
+// IsBlocked reports whether the process is in uninterruptible sleep (D state)
+func (p *ProcessInfo) IsBlocked() bool {
+	return p.State == process.Blocked
+}
+
+// trackBlocked counts consecutive refreshes each process has been blocked
+// and records the ones that are stuck; callers must hold m.mu
+func (m *Monitor) trackBlocked(all map[int32]*ProcessInfo) {
+	streaks := make(map[int32]int)
+	m.stuck = m.stuck[:0]
+	for pid, info := range all {
+		if !info.IsBlocked() {
+			continue
+		}
+		streaks[pid] = m.blockedStreaks[pid] + 1
+		info.BlockedRefreshes = streaks[pid]
+		if info.BlockedRefreshes >= stuckRefreshes {
+			m.stuck = append(m.stuck, info)
+		}
+	}
+	m.blockedStreaks = streaks
+	sort.Slice(m.stuck, func(i, j int) bool { return m.stuck[i].PID < m.stuck[j].PID })
+}
+
+// StuckProcesses returns processes that have stayed in D state for several
+// consecutive refreshes, ordered by PID
+func (m *Monitor) StuckProcesses() []*ProcessInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*ProcessInfo(nil), m.stuck...)
+}
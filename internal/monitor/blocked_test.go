@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func TestTrackBlocked(t *testing.T) {
+	m := &Monitor{}
+
+	scan := func(blocked ...int32) map[int32]*ProcessInfo {
+		all := map[int32]*ProcessInfo{
+			1: {PID: 1, Name: "init", State: process.Sleep},
+			2: {PID: 2, Name: "nfs-client", State: process.Sleep},
+			3: {PID: 3, Name: "dd", State: process.Sleep},
+		}
+		for _, pid := range blocked {
+			all[pid].State = process.Blocked
+		}
+		return all
+	}
+
+	for i := 1; i < stuckRefreshes; i++ {
+		m.trackBlocked(scan(2, 3))
+		if stuck := m.StuckProcesses(); len(stuck) != 0 {
+			t.Fatalf("refresh %d: expected nothing stuck yet, got %d", i, len(stuck))
+		}
+	}
+
+	// PID 3 recovers just before the threshold, PID 2 stays blocked
+	all := scan(2)
+	m.trackBlocked(all)
+	stuck := m.StuckProcesses()
+	if len(stuck) != 1 || stuck[0].PID != 2 {
+		t.Fatalf("expected only PID 2 stuck, got %v", stuck)
+	}
+	if all[2].BlockedRefreshes != stuckRefreshes {
+		t.Errorf("BlockedRefreshes = %d, expected %d", all[2].BlockedRefreshes, stuckRefreshes)
+	}
+
+	// A blocked streak restarts after the process wakes up
+	m.trackBlocked(scan())
+	m.trackBlocked(scan(2))
+	if stuck := m.StuckProcesses(); len(stuck) != 0 {
+		t.Errorf("expected streak to reset, got %d stuck", len(stuck))
+	}
+}
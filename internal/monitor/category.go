@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// UncategorizedName is the category for processes no rule matched
+const UncategorizedName = "other"
+
+// HostName groups processes outside any container in the container view
+const HostName = "host"
+
+// CategorySummary totals the listed processes belonging to one category
+type CategorySummary struct {
+	Category     string
+	ProcessCount int
+	CPUPercent   float64
+	MemoryBytes  uint64
+}
+
+// matchCategory returns the category of the first rule whose pattern is
+// contained in text (case-insensitive), or "" if none match
+func matchCategory(text string, rules []config.CategoryRule) string {
+	text = strings.ToLower(text)
+	for _, rule := range rules {
+		if rule.Pattern != "" && strings.Contains(text, strings.ToLower(rule.Pattern)) {
+			return rule.Category
+		}
+	}
+	return ""
+}
+
+// SummarizeCategories sums resources per category across the given
+// (already aggregated) processes, ordered by groupSort descending;
+// GroupSortFollow applies the main list's mode to the totals
+func SummarizeCategories(procs []*ProcessInfo, groupSort config.GroupSort, mode config.SortMode) []CategorySummary {
+	return summarizeBy(procs, func(proc *ProcessInfo) string {
+		if proc.Category == "" {
+			return UncategorizedName
+		}
+		return proc.Category
+	}, groupSort, mode)
+}
+
+// SummarizeContainers sums resources per container like
+// SummarizeCategories, with the container name in Category and host
+// processes under HostName. Children count towards their listed parent's
+// container.
+func SummarizeContainers(procs []*ProcessInfo, groupSort config.GroupSort, mode config.SortMode) []CategorySummary {
+	return summarizeBy(procs, func(proc *ProcessInfo) string {
+		if proc.ContainerID == "" {
+			return HostName
+		}
+		return proc.ContainerName
+	}, groupSort, mode)
+}
+
+// summarizeBy sums resources per group, as named by groupOf
+func summarizeBy(procs []*ProcessInfo, groupOf func(*ProcessInfo) string, groupSort config.GroupSort, mode config.SortMode) []CategorySummary {
+	byCategory := make(map[string]*CategorySummary)
+	for _, proc := range procs {
+		category := groupOf(proc)
+		summary, exists := byCategory[category]
+		if !exists {
+			summary = &CategorySummary{Category: category}
+			byCategory[category] = summary
+		}
+		summary.ProcessCount += 1 + len(proc.Children)
+		summary.CPUPercent += proc.CPUPercent
+		summary.MemoryBytes += proc.MemoryBytes
+	}
+
+	summaries := make([]CategorySummary, 0, len(byCategory))
+	for _, summary := range byCategory {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		ki, kj := summaries[i].sortKey(groupSort, mode), summaries[j].sortKey(groupSort, mode)
+		if ki != kj {
+			return ki > kj
+		}
+		return summaries[i].Category < summaries[j].Category
+	})
+	return summaries
+}
+
+// sortKey returns the value a category is ordered by (descending)
+func (s CategorySummary) sortKey(groupSort config.GroupSort, mode config.SortMode) float64 {
+	switch groupSort {
+	case config.GroupSortCPU:
+		return s.CPUPercent
+	case config.GroupSortMemory:
+		return float64(s.MemoryBytes)
+	case config.GroupSortCount:
+		return float64(s.ProcessCount)
+	}
+	return sortKey(&ProcessInfo{CPUPercent: s.CPUPercent, MemoryBytes: s.MemoryBytes}, mode)
+}
@@ -0,0 +1,108 @@
+package monitor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestMatchCategory(t *testing.T) {
+	rules := []config.CategoryRule{
+		{Category: "browser", Pattern: "chrome"},
+		{Category: "database", Pattern: "Postgres"},
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Exact match", "chrome", "browser"},
+		{"Substring match", "/opt/google/chrome/chrome --type=renderer", "browser"},
+		{"Case-insensitive", "postgres: checkpointer", "database"},
+		{"No match", "bash", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := matchCategory(tt.text, rules); result != tt.expected {
+				t.Errorf("matchCategory(%q) = %q; expected %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSummarizeCategories(t *testing.T) {
+	procs := []*ProcessInfo{
+		{PID: 1, Category: "browser", CPUPercent: 20, MemoryBytes: 300, Children: make([]ChildInfo, 4)},
+		{PID: 2, Category: "browser", CPUPercent: 10, MemoryBytes: 100},
+		{PID: 3, Category: "database", CPUPercent: 40, MemoryBytes: 500},
+		{PID: 4, CPUPercent: 1, MemoryBytes: 50},
+	}
+
+	summaries := SummarizeCategories(procs, config.GroupSortFollow, config.SortByCPU)
+
+	expected := []CategorySummary{
+		{Category: "database", ProcessCount: 1, CPUPercent: 40, MemoryBytes: 500},
+		{Category: "browser", ProcessCount: 6, CPUPercent: 30, MemoryBytes: 400},
+		{Category: UncategorizedName, ProcessCount: 1, CPUPercent: 1, MemoryBytes: 50},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("Expected %d summaries, got %d", len(expected), len(summaries))
+	}
+	for i := range expected {
+		if summaries[i] != expected[i] {
+			t.Errorf("Summary %d = %+v; expected %+v", i, summaries[i], expected[i])
+		}
+	}
+}
+
+func TestSummarizeCategoriesGroupSort(t *testing.T) {
+	procs := []*ProcessInfo{
+		{PID: 1, Category: "shell", CPUPercent: 1, MemoryBytes: 10, Children: make([]ChildInfo, 40)},
+		{PID: 2, Category: "database", CPUPercent: 5, MemoryBytes: 900},
+		{PID: 3, Category: "browser", CPUPercent: 30, MemoryBytes: 400},
+	}
+
+	tests := []struct {
+		groupSort config.GroupSort
+		mode      config.SortMode
+		expected  []string
+	}{
+		{config.GroupSortCount, config.SortByCPU, []string{"shell", "browser", "database"}},
+		{config.GroupSortMemory, config.SortByCPU, []string{"database", "browser", "shell"}},
+		{config.GroupSortCPU, config.SortByMemory, []string{"browser", "database", "shell"}},
+		{config.GroupSortFollow, config.SortByMemory, []string{"database", "browser", "shell"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, summary := range SummarizeCategories(procs, tt.groupSort, tt.mode) {
+			got = append(got, summary.Category)
+		}
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("SummarizeCategories(%v, %v) order = %v; expected %v", tt.groupSort, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestSummarizeContainers(t *testing.T) {
+	procs := []*ProcessInfo{
+		{PID: 1, ContainerID: "a1", ContainerName: "web", CPUPercent: 20, MemoryBytes: 300, Children: make([]ChildInfo, 2)},
+		{PID: 2, ContainerID: "a1", ContainerName: "web", CPUPercent: 10, MemoryBytes: 100},
+		{PID: 3, ContainerID: "b2", ContainerName: "db", CPUPercent: 5, MemoryBytes: 900},
+		{PID: 4, CPUPercent: 1, MemoryBytes: 50},
+	}
+
+	summaries := SummarizeContainers(procs, config.GroupSortMemory, config.SortByCPU)
+
+	expected := []CategorySummary{
+		{Category: "db", ProcessCount: 1, CPUPercent: 5, MemoryBytes: 900},
+		{Category: "web", ProcessCount: 4, CPUPercent: 30, MemoryBytes: 400},
+		{Category: HostName, ProcessCount: 1, CPUPercent: 1, MemoryBytes: 50},
+	}
+	if !slices.Equal(summaries, expected) {
+		t.Errorf("SummarizeContainers() = %+v; expected %+v", summaries, expected)
+	}
+}
@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// readContainer returns the ID and name of the container a process runs
+// in, or empty strings for host processes, from /proc/PID/cgroup
+func readContainer(pid int32) (id, name string) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", ""
+	}
+	if id = parseContainerID(string(data)); id == "" {
+		return "", ""
+	}
+	return id, containerName(id)
+}
+
+// parseContainerID finds a container ID in cgroup file contents. Runtimes
+// name the cgroup after the 64-hex-digit ID, e.g. "/docker/<id>" (cgroup
+// v1), "/system.slice/docker-<id>.scope", "libpod-<id>.scope" (Podman) or
+// "cri-containerd-<id>.scope". Podman's conmon monitor runs outside the
+// container, so its "libpod-conmon-<id>" cgroup doesn't count.
+func parseContainerID(cgroup string) string {
+	for _, line := range strings.Split(cgroup, "\n") {
+		// hierarchy-ID:controllers:path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		for _, segment := range strings.Split(parts[2], "/") {
+			if strings.Contains(segment, "conmon") {
+				continue
+			}
+			segment = strings.TrimSuffix(segment, ".scope")
+			if i := strings.LastIndex(segment, "-"); i >= 0 {
+				segment = segment[i+1:]
+			}
+			if isContainerID(segment) {
+				return segment
+			}
+		}
+	}
+	return ""
+}
+
+// isContainerID reports whether s is a 64-digit lowercase hex ID
+func isContainerID(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// dockerRoot is where Docker keeps container state; replaced in tests
+var dockerRoot = "/var/lib/docker"
+
+// containerName returns Docker's name for a container, which is only
+// readable as root, falling back to the short ID Docker and Podman print
+func containerName(id string) string {
+	data, err := os.ReadFile(path.Join(dockerRoot, "containers", id, "config.v2.json"))
+	if err == nil {
+		var config struct{ Name string }
+		if json.Unmarshal(data, &config) == nil && config.Name != "" {
+			return strings.TrimPrefix(config.Name, "/")
+		}
+	}
+	return id[:12]
+}
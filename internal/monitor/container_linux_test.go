@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseContainerID(t *testing.T) {
+	const id = "3f4c2a1b9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b"
+	tests := []struct {
+		name     string
+		cgroup   string
+		expected string
+	}{
+		{"Docker cgroup v2", "0::/system.slice/docker-" + id + ".scope\n", id},
+		{"Docker cgroup v1", "12:memory:/docker/" + id + "\n11:cpu:/docker/" + id + "\n", id},
+		{"Podman", "0::/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-" + id + ".scope/container\n", id},
+		{"containerd", "0::/kubepods.slice/kubepods-burstable.slice/cri-containerd-" + id + ".scope\n", id},
+		{"Podman conmon", "0::/machine.slice/libpod-conmon-" + id + ".scope\n", ""},
+		{"Host process", "0::/user.slice/user-1000.slice/session-2.scope\n", ""},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := parseContainerID(tt.cgroup); result != tt.expected {
+				t.Errorf("parseContainerID() = %q; expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	const id = "3f4c2a1b9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b"
+	dockerRoot = t.TempDir()
+	t.Cleanup(func() { dockerRoot = "/var/lib/docker" })
+
+	if name := containerName(id); name != "3f4c2a1b9e8d" {
+		t.Errorf("containerName() without Docker state = %q; expected the short ID", name)
+	}
+
+	dir := filepath.Join(dockerRoot, "containers", id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.v2.json"), []byte(`{"ID":"`+id+`","Name":"/web"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if name := containerName(id); name != "web" {
+		t.Errorf("containerName() = %q; expected web", name)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package monitor
+
+// readContainer is only supported on Linux; every process counts as a host
+// process
+func readContainer(_ int32) (id, name string) {
+	return "", ""
+}
@@ -0,0 +1,36 @@
+package monitor
+
+// previousCPU is the CPU of each row listed on the previous scan, by PID
+// and by group name
+type previousCPU struct {
+	byPID  map[int32]float64
+	byName map[string]float64
+}
+
+// trackPreviousCPU sets each listed row's PrevCPUPercent from the previous
+// scan, matched by PID or, for a process that restarted under a new PID, by
+// group name; rows that match neither are new. It then remembers this
+// scan's rows. Exited rows keep their final readings. Callers must hold m.mu.
+func (m *Monitor) trackPreviousCPU(listed []*ProcessInfo) {
+	current := previousCPU{
+		byPID:  make(map[int32]float64, len(listed)),
+		byName: make(map[string]float64, len(listed)),
+	}
+	for _, info := range listed {
+		if info.Exited {
+			continue
+		}
+		if prev, ok := m.prevCPU.byPID[info.PID]; ok {
+			info.PrevCPUPercent = &prev
+		} else if prev, ok := m.prevCPU.byName[info.GroupName()]; ok {
+			info.PrevCPUPercent = &prev
+		}
+
+		current.byPID[info.PID] = info.CPUPercent
+		// Rows are sorted, so a shared name keeps the busiest row's reading
+		if _, seen := current.byName[info.GroupName()]; !seen {
+			current.byName[info.GroupName()] = info.CPUPercent
+		}
+	}
+	m.prevCPU = current
+}
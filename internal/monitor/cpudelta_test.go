@@ -0,0 +1,49 @@
+package monitor
+
+import "testing"
+
+func TestTrackPreviousCPU(t *testing.T) {
+	m := New(nil)
+	m.trackPreviousCPU([]*ProcessInfo{
+		{PID: 1, Name: "postgres", CPUPercent: 10},
+		{PID: 2, Name: "worker", CPUPercent: 40},
+	})
+
+	stayed := &ProcessInfo{PID: 1, Name: "postgres", CPUPercent: 25}
+	restarted := &ProcessInfo{PID: 3, Name: "worker", CPUPercent: 5}
+	started := &ProcessInfo{PID: 4, Name: "make", CPUPercent: 80}
+	gone := &ProcessInfo{PID: 2, Name: "worker", CPUPercent: 40, Exited: true}
+	m.trackPreviousCPU([]*ProcessInfo{stayed, restarted, started, gone})
+
+	tests := []struct {
+		name     string
+		proc     *ProcessInfo
+		expected *float64
+	}{
+		{"Same PID", stayed, ptr(10.0)},
+		{"Restarted, matched by name", restarted, ptr(40.0)},
+		{"New", started, nil},
+		{"Exited", gone, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.proc.PrevCPUPercent
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("PrevCPUPercent = %v; expected %v", deref(got), deref(tt.expected))
+			}
+		})
+	}
+
+	if _, ok := m.prevCPU.byPID[2]; ok {
+		t.Error("Expected an exited row not to be remembered")
+	}
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -0,0 +1,55 @@
+package monitor
+
+import "time"
+
+// cpuSample is one CPU percentage reading
+type cpuSample struct {
+	at      time.Time
+	percent float64
+}
+
+// cpuWindow averages CPU readings over a trailing window (--cpu-window), so
+// sub-second spikes don't make the list jump around at fast refresh rates
+type cpuWindow struct {
+	samples []cpuSample
+}
+
+// add records a reading taken at `at` and returns the mean of the readings
+// within window of it. A zero window disables averaging.
+func (w *cpuWindow) add(at time.Time, percent float64, window time.Duration) float64 {
+	if window <= 0 {
+		w.samples = w.samples[:0]
+		return percent
+	}
+
+	w.samples = append(w.samples, cpuSample{at: at, percent: percent})
+	cutoff := at.Add(-window)
+	drop := 0
+	for drop < len(w.samples)-1 && !w.samples[drop].at.After(cutoff) {
+		drop++
+	}
+	w.samples = append(w.samples[:0], w.samples[drop:]...)
+
+	var total float64
+	for _, s := range w.samples {
+		total += s.percent
+	}
+	return total / float64(len(w.samples))
+}
+
+// smoothCPU averages a process's CPU reading over the configured window
+func (m *Monitor) smoothCPU(pid int32, at time.Time, percent float64) float64 {
+	window := m.config.GetCPUWindow()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if window <= 0 {
+		delete(m.cpuHistory, pid)
+		return percent
+	}
+	w, ok := m.cpuHistory[pid]
+	if !ok {
+		w = &cpuWindow{}
+		m.cpuHistory[pid] = w
+	}
+	return w.add(at, percent, window)
+}
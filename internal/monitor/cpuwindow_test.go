@@ -0,0 +1,35 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCPUWindowAverages(t *testing.T) {
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	var w cpuWindow
+	window := 1500 * time.Millisecond
+	steps := []struct {
+		ms       int
+		percent  float64
+		expected float64
+	}{
+		{0, 100, 100},
+		{500, 0, 50},
+		{1000, 20, 40},
+		{1500, 40, 20}, // The reading at 0 is now outside the window
+		{2000, 0, 20},  // (20 + 40 + 0) / 3 after dropping 500
+		{9000, 10, 10}, // Everything older has aged out
+	}
+	for _, s := range steps {
+		if got := w.add(at(s.ms), s.percent, window); got != s.expected {
+			t.Errorf("at %dms: average = %v; expected %v", s.ms, got, s.expected)
+		}
+	}
+
+	if got := w.add(at(9500), 60, 0); got != 60 || len(w.samples) != 0 {
+		t.Errorf("zero window: got %v with %d samples kept; expected the raw reading and none kept", got, len(w.samples))
+	}
+}
@@ -0,0 +1,42 @@
+package monitor
+
+import "strings"
+
+// isCritical reports whether a process goes by the critical name, as its
+// comm or executable name (case-insensitively)
+func isCritical(info *ProcessInfo, name string) bool {
+	return strings.EqualFold(info.Name, name) || strings.EqualFold(info.ExeName, name)
+}
+
+// trackCritical marks the critical processes and records the critical names
+// that stopped running since the previous scan, or on the first scan the
+// ones not running at all. Callers must hold m.mu.
+func (m *Monitor) trackCritical(all map[int32]*ProcessInfo) {
+	names := m.config.GetCritical()
+	running := make(map[string]bool, len(names))
+	for _, info := range all {
+		for _, name := range names {
+			if isCritical(info, name) {
+				info.Critical = true
+				running[name] = true
+			}
+		}
+	}
+
+	m.criticalMissing = nil
+	for _, name := range names {
+		if !running[name] && (m.criticalRunning == nil || m.criticalRunning[name]) {
+			m.criticalMissing = append(m.criticalMissing, name)
+		}
+	}
+	m.criticalRunning = running
+}
+
+// LastMissingCritical returns the critical names that stopped running on
+// the most recent scan, in the configured order. A name is reported once
+// when it goes missing, not on every scan it stays gone.
+func (m *Monitor) LastMissingCritical() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.criticalMissing
+}
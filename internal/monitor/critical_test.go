@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestCriticalProcesses(t *testing.T) {
+	cfg := config.New()
+	cfg.AddCritical("postgres")
+	cfg.AddCritical("NGINX")
+	cfg.AddCritical("redis")
+	m := New(cfg)
+	handles := []procHandle{
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "postgres"},
+		&fakeProc{pid: 11, ppid: 1, name: "nginx"},
+		&fakeProc{pid: 12, ppid: 1, name: "bash"},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	scan := func() []int32 {
+		procs, err := m.GetFilteredProcesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pids []int32
+		for _, proc := range procs {
+			if !proc.Critical {
+				t.Errorf("%s listed below the thresholds without being critical", proc.Name)
+			}
+			pids = append(pids, proc.PID)
+		}
+		slices.Sort(pids)
+		return pids
+	}
+
+	// Idle, but listed; redis never started, which the first scan reports
+	if pids := scan(); !slices.Equal(pids, []int32{10, 11}) {
+		t.Errorf("listed %v; expected the critical PIDs 10 and 11", pids)
+	}
+	if missing := m.LastMissingCritical(); !slices.Equal(missing, []string{"redis"}) {
+		t.Errorf("missing on the first scan = %v; expected [redis]", missing)
+	}
+	scan()
+	if missing := m.LastMissingCritical(); len(missing) != 0 {
+		t.Errorf("redis reported missing again: %v", missing)
+	}
+
+	handles = handles[:2]
+	scan()
+	if missing := m.LastMissingCritical(); !slices.Equal(missing, []string{"NGINX"}) {
+		t.Errorf("missing after nginx exited = %v; expected [NGINX]", missing)
+	}
+}
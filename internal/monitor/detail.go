@@ -0,0 +1,143 @@
+package monitor
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ProcessDetail holds information too costly to collect for every process on
+// every scan; it is fetched for a single process when the detail pane is open.
+// The scan keeps to cheap fields (name, CPU, memory, state) and anything
+// that means extra /proc reads per process belongs here instead. Fields that
+// couldn't be read are left empty, with the reason in Unavailable.
+type ProcessDetail struct {
+	PID              int32
+	Name             string
+	Exe              string
+	Cmdline          string
+	Cwd              string            // Working directory; empty if unsupported or unreadable
+	AllowedCPUs      string            // CPU affinity list, e.g. "0-3"
+	LastCPU          int               // CPU the process last ran on; -1 if unreadable
+	Scheduling       string            // Scheduling policy, with the RT priority for SCHED_FIFO/RR
+	CPUTime          time.Duration     // Cumulative user+system CPU time of this process alone
+	Started          time.Time         // When the process started; zero if unreadable
+	AvgCPU           float64           // CPUTime as a percentage of the time since Started
+	PIDNamespace     uint64            // PID namespace inode; 0 if unreadable
+	HostPIDNamespace bool              // PIDNamespace is brieftop's own, i.e. not containerized relative to us
+	NamespacePID     string            // The process's PID inside its namespace
+	MajorFaults      uint64            // Page faults that needed a disk read, since start
+	MinorFaults      uint64            // Page faults served from memory, since start
+	MajorFaultRate   float64           // Major faults per second lately; 0 until two readings
+	SecurityContext  string            // SELinux context or AppArmor profile; only read with --security-context
+	FDs              int32             // Open file descriptors; -1 if unreadable
+	Connections      int               // Open network connections; -1 if unreadable
+	Unavailable      map[string]string // Why a field is empty, by field name, e.g. "Exe": "permission denied"
+}
+
+// markUnavailable records why field couldn't be read
+func (d *ProcessDetail) markUnavailable(field string, err error) {
+	if d.Unavailable == nil {
+		d.Unavailable = make(map[string]string)
+	}
+	d.Unavailable[field] = unavailableReason(err)
+}
+
+// unavailableReason describes a failed read briefly: the common permission
+// and exited-process cases by name, anything else by its message
+func unavailableReason(err error) string {
+	switch {
+	case errors.Is(err, os.ErrPermission):
+		return "permission denied"
+	case errors.Is(err, os.ErrNotExist):
+		return "process exited"
+	}
+	return err.Error()
+}
+
+// GetProcessDetail reads the detail pane fields for one process
+func (m *Monitor) GetProcessDetail(pid int32) (*ProcessDetail, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &ProcessDetail{PID: pid}
+	if detail.Name, err = p.Name(); err != nil {
+		detail.markUnavailable("Name", err)
+	}
+	if detail.Exe, err = p.Exe(); err != nil {
+		detail.markUnavailable("Exe", err)
+	}
+	if detail.Cmdline, err = p.Cmdline(); err != nil {
+		detail.markUnavailable("Cmdline", err)
+	}
+	if detail.Cwd, err = p.Cwd(); err != nil {
+		detail.markUnavailable("Cwd", err)
+	}
+	if detail.AllowedCPUs, err = readAllowedCPUs(pid); err != nil {
+		detail.markUnavailable("AllowedCPUs", err)
+	}
+	detail.LastCPU = -1
+	detail.FDs, detail.Connections = -1, -1
+	if fds, err := p.NumFDs(); err == nil {
+		detail.FDs = fds
+	}
+	// Walks the system's socket tables, the priciest read here
+	if conns, err := p.Connections(); err == nil {
+		detail.Connections = len(conns)
+	}
+	if cpu, err := readLastCPU(pid); err == nil {
+		detail.LastCPU = cpu
+	}
+	if detail.Scheduling, err = readScheduling(pid); err != nil {
+		detail.markUnavailable("Scheduling", err)
+	}
+	if times, err := p.Times(); err == nil {
+		detail.CPUTime = time.Duration((times.User + times.System) * float64(time.Second))
+	}
+	if created, err := p.CreateTime(); err == nil {
+		detail.Started = time.UnixMilli(created)
+		detail.AvgCPU = lifetimeAvgCPU(detail.CPUTime.Seconds(), detail.Started, time.Now())
+	}
+	if ns, err := readPIDNamespace(pid); err == nil {
+		detail.PIDNamespace = ns
+		own, err := readPIDNamespace(int32(os.Getpid()))
+		detail.HostPIDNamespace = err == nil && own == ns
+	} else if !errors.Is(err, errNoNamespaces) {
+		detail.markUnavailable("PIDNamespace", err)
+	}
+	if detail.NamespacePID, err = readNamespacePID(pid); err != nil && !errors.Is(err, errNoNamespaces) {
+		detail.markUnavailable("NamespacePID", err)
+	}
+	if m.config.GetShowSecurityContext() {
+		if detail.SecurityContext, err = readSecurityContext(pid); err != nil {
+			detail.markUnavailable("SecurityContext", err)
+		}
+	}
+	if faults, err := p.PageFaults(); err == nil {
+		detail.MajorFaults = faults.MajorFaults
+		detail.MinorFaults = faults.MinorFaults
+		// With the column shown the scan already tracks the rate; reading
+		// it again here would split its interval
+		if m.config.GetShowFaults() {
+			detail.MajorFaultRate = m.lastMajorFaultRate(pid)
+		} else {
+			detail.MajorFaultRate = m.majorFaultRate(pid, faults.MajorFaults, time.Now())
+		}
+	}
+	return detail, nil
+}
+
+// Environ reads a process's environment as KEY=value strings. Only the
+// process's owner (or root) may read it, so expect a permission error for
+// other users' processes.
+func (m *Monitor) Environ(pid int32) ([]string, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+	return p.Environ()
+}
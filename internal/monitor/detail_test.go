@@ -0,0 +1,33 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestUnavailableReason(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&fs.PathError{Op: "readlink", Path: "/proc/1/exe", Err: fs.ErrPermission}, "permission denied"},
+		{fmt.Errorf("reading cwd: %w", os.ErrNotExist), "process exited"},
+		{errors.New("CPU affinity is not supported on this platform"), "CPU affinity is not supported on this platform"},
+	}
+	for _, tt := range tests {
+		if got := unavailableReason(tt.err); got != tt.expected {
+			t.Errorf("unavailableReason(%v) = %q; expected %q", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestMarkUnavailable(t *testing.T) {
+	var detail ProcessDetail
+	detail.markUnavailable("Exe", fs.ErrPermission)
+	if got := detail.Unavailable["Exe"]; got != "permission denied" {
+		t.Errorf("Unavailable[Exe] = %q; expected permission denied", got)
+	}
+}
@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// errExcluded reports a process skipped because its name matched an
+// exclude pattern
+var errExcluded = errors.New("process excluded")
+
+// isExcluded reports whether name matches any of the glob patterns (as in
+// path.Match, e.g. "kworker*" or "rcu_?"). Malformed patterns are rejected
+// when the config is loaded; any that get here never match.
+func isExcluded(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	// Names aren't paths: kernel threads like "kworker/0:1" should match
+	// "kworker*", but path.Match never lets * cross a slash
+	name = strings.ReplaceAll(name, "/", "\x00")
+	for _, pattern := range patterns {
+		matched, err := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), name)
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludePattern returns a pattern matching exactly name, escaping any
+// glob metacharacters in it
+func ExcludePattern(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
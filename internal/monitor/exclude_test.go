@@ -0,0 +1,25 @@
+package monitor
+
+import "testing"
+
+func TestIsExcluded(t *testing.T) {
+	patterns := []string{"kworker*", "rcu_?", ExcludePattern("weird[1]*")}
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"kworker/0:1-events", true},
+		{"rcu_b", true},
+		{"rcu_sched", false},
+		{"weird[1]*", true},
+		{"weird1x", false},
+		{"postgres", false},
+	}
+
+	for _, tt := range tests {
+		if got := isExcluded(tt.name, patterns); got != tt.expected {
+			t.Errorf("isExcluded(%q) = %v; expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package monitor
+
+import "time"
+
+// ThrashFaultRate is the major page-fault rate, per second, at which a
+// process is considered to be thrashing. Each major fault is a read from
+// disk (usually swap), so a sustained rate this high means the process
+// spends most of its time waiting for its own memory.
+const ThrashFaultRate = 100
+
+// faultSample is a process's major fault count at one reading, and the rate
+// since the reading before it
+type faultSample struct {
+	count uint64
+	at    time.Time
+	rate  float64
+}
+
+// IsThrashing reports whether the process is faulting pages in at or above
+// ThrashFaultRate
+func (p *ProcessInfo) IsThrashing() bool {
+	return p.MajorFaultRate >= ThrashFaultRate
+}
+
+// IsThrashing reports whether the child is faulting pages in at or above
+// ThrashFaultRate
+func (c ChildInfo) IsThrashing() bool {
+	return c.MajorFaultRate >= ThrashFaultRate
+}
+
+// majorFaultRate records pid's major fault count and returns the faults per
+// second since its previous reading. The first reading has no baseline and
+// reports 0, as does a counter that went backwards (a reused PID).
+func (m *Monitor) majorFaultRate(pid int32, count uint64, at time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lastFaults == nil {
+		m.lastFaults = make(map[int32]faultSample)
+	}
+	sample := faultSample{count: count, at: at}
+	if prev, ok := m.lastFaults[pid]; ok && count >= prev.count {
+		if elapsed := at.Sub(prev.at).Seconds(); elapsed > 0 {
+			sample.rate = float64(count-prev.count) / elapsed
+		}
+	}
+	m.lastFaults[pid] = sample
+	return sample.rate
+}
+
+// lastMajorFaultRate returns the rate computed at pid's most recent reading
+func (m *Monitor) lastMajorFaultRate(pid int32) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastFaults[pid].rate
+}
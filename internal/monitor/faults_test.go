@@ -0,0 +1,29 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMajorFaultRate(t *testing.T) {
+	m := &Monitor{}
+	start := time.Unix(1700000000, 0)
+
+	if rate := m.majorFaultRate(7, 1000, start); rate != 0 {
+		t.Errorf("first reading = %v; expected 0 without a baseline", rate)
+	}
+	if rate := m.majorFaultRate(7, 1500, start.Add(2*time.Second)); rate != 250 {
+		t.Errorf("rate = %v; expected 250/s", rate)
+	}
+	if rate := m.lastMajorFaultRate(7); rate != 250 {
+		t.Errorf("lastMajorFaultRate = %v; expected the last computed 250/s", rate)
+	}
+	if !(&ProcessInfo{MajorFaultRate: 250}).IsThrashing() || (ChildInfo{MajorFaultRate: 5}).IsThrashing() {
+		t.Error("expected only the high fault rate to count as thrashing")
+	}
+
+	// A reused PID starts its counter over
+	if rate := m.majorFaultRate(7, 10, start.Add(3*time.Second)); rate != 0 {
+		t.Errorf("rate after counter reset = %v; expected 0", rate)
+	}
+}
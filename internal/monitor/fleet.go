@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// HostStatus is one host's latest system metrics and task counts, or why
+// they're missing
+type HostStatus struct {
+	Host   string
+	System *SystemMetrics // nil until the first snapshot
+	Tasks  TaskCounts
+	Err    error // Why the host isn't streaming; nil while connected
+}
+
+// Status reports the host's latest metrics and connection state
+func (r *Remote) Status() HostStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := HostStatus{Host: r.target, Err: r.err}
+	if r.latest != nil {
+		status.System = r.latest.System
+		if r.latest.Tasks != nil {
+			status.Tasks = *r.latest.Tasks
+		}
+	}
+	return status
+}
+
+// Fleet streams several remote hosts at once for a per-host summary. For
+// now it only rolls up system metrics: the header shows the fleet's
+// combined CPU and memory and Hosts feeds one row per host. Processes
+// aren't merged, since PIDs from different hosts would collide.
+type Fleet struct {
+	remotes []*Remote
+}
+
+// NewFleet prepares a Remote per target; Start connects them all
+func NewFleet(targets []string, config ConfigInterface) *Fleet {
+	f := &Fleet{}
+	for _, target := range targets {
+		f.remotes = append(f.remotes, NewRemote(target, config))
+	}
+	return f
+}
+
+// Start connects to every host in the background
+func (f *Fleet) Start() {
+	for _, r := range f.remotes {
+		r.Start()
+	}
+}
+
+// Close disconnects from every host
+func (f *Fleet) Close() error {
+	var errs []error
+	for _, r := range f.remotes {
+		if err := r.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.target, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Hosts returns each host's status in the order they were given
+func (f *Fleet) Hosts() []HostStatus {
+	hosts := make([]HostStatus, len(f.remotes))
+	for i, r := range f.remotes {
+		hosts[i] = r.Status()
+	}
+	return hosts
+}
+
+// GetFilteredProcesses lists nothing; see Fleet
+func (f *Fleet) GetFilteredProcesses() ([]*ProcessInfo, error) { return nil, nil }
+
+// GetSystemMetrics combines the connected hosts' metrics: CPU weighted by
+// each host's core count, memory and swap summed
+func (f *Fleet) GetSystemMetrics() (*SystemMetrics, error) {
+	return combineMetrics(f.Hosts())
+}
+
+// combineMetrics rolls the hosts that have metrics up into one
+func combineMetrics(hosts []HostStatus) (*SystemMetrics, error) {
+	var total SystemMetrics
+	var cpuWeighted float64
+	for _, host := range hosts {
+		m := host.System
+		if host.Err != nil || m == nil {
+			continue
+		}
+		cpuWeighted += m.CPUPercent * float64(m.CPUCores)
+		total.CPUCores += m.CPUCores
+		total.MemoryTotal += m.MemoryTotal
+		total.MemoryUsed += m.MemoryUsed
+		total.MemoryAvailable += m.MemoryAvailable
+		total.MemoryCached += m.MemoryCached
+		total.MemoryBuffers += m.MemoryBuffers
+		total.SwapTotal += m.SwapTotal
+		total.SwapUsed += m.SwapUsed
+	}
+	if total.CPUCores == 0 || total.MemoryTotal == 0 {
+		return nil, errors.New("no host connected")
+	}
+	total.CPUPercent = cpuWeighted / float64(total.CPUCores)
+	total.MemoryPercent = float64(total.MemoryUsed) / float64(total.MemoryTotal) * 100
+	if total.SwapTotal > 0 {
+		total.SwapPercent = float64(total.SwapUsed) / float64(total.SwapTotal) * 100
+	}
+	return &total, nil
+}
+
+// IsPrimed reports whether any host has sent a snapshot
+func (f *Fleet) IsPrimed() bool {
+	for _, r := range f.remotes {
+		if r.IsPrimed() {
+			return true
+		}
+	}
+	return false
+}
+
+// LastTaskCounts sums the hosts' task counts
+func (f *Fleet) LastTaskCounts() TaskCounts {
+	var total TaskCounts
+	for _, host := range f.Hosts() {
+		total.Total += host.Tasks.Total
+		total.Running += host.Tasks.Running
+		total.Sleeping += host.Tasks.Sleeping
+		total.Stopped += host.Tasks.Stopped
+		total.Zombie += host.Tasks.Zombie
+	}
+	return total
+}
+
+// LastScanTimings is always zero: the scans happen on the hosts
+func (f *Fleet) LastScanTimings() ScanTimings { return ScanTimings{} }
+
+// StuckProcesses is always empty; see Fleet
+func (f *Fleet) StuckProcesses() []*ProcessInfo { return nil }
+
+// LastAlerts is always empty; run --alert-command on each host instead
+func (f *Fleet) LastAlerts() []Alert { return nil }
+
+// LastMissingCritical is always empty; see Remote
+func (f *Fleet) LastMissingCritical() []string { return nil }
+
+// GetResourceLevel buckets a reading like Monitor does
+func (f *Fleet) GetResourceLevel(cpuPercent float64, memoryMB float64) ResourceLevel {
+	return resourceLevel(cpuPercent, memoryMB)
+}
+
+// ToggleExpanded does nothing; no processes are listed
+func (f *Fleet) ToggleExpanded(pid int32) {}
+
+// GetProcessDetail is unsupported; see Remote.GetProcessDetail
+func (f *Fleet) GetProcessDetail(pid int32) (*ProcessDetail, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// ExpandedNames is unsupported; see Remote.ExpandedNames
+func (f *Fleet) ExpandedNames() ([]string, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// ExpandNames is unsupported; see Remote.ExpandedNames
+func (f *Fleet) ExpandNames(names []string) error {
+	return ErrRemoteUnsupported
+}
+
+// Environ is unsupported; see Remote.Environ
+func (f *Fleet) Environ(pid int32) ([]string, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// SendSignal is unsupported; see Remote.SendSignal
+func (f *Fleet) SendSignal(pid int32, name string, sig syscall.Signal) error {
+	return ErrRemoteUnsupported
+}
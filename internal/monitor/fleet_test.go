@@ -0,0 +1,30 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCombineMetrics checks CPU is weighted by core count and hosts that
+// are down are left out
+func TestCombineMetrics(t *testing.T) {
+	hosts := []HostStatus{
+		{Host: "big", System: &SystemMetrics{CPUPercent: 10, CPUCores: 12, MemoryTotal: 600, MemoryUsed: 150}},
+		{Host: "small", System: &SystemMetrics{CPUPercent: 70, CPUCores: 4, MemoryTotal: 200, MemoryUsed: 150, SwapTotal: 100, SwapUsed: 25}},
+		{Host: "down", System: &SystemMetrics{CPUPercent: 100, CPUCores: 64, MemoryTotal: 1000}, Err: errors.New("disconnected")},
+	}
+	m, err := combineMetrics(hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.CPUCores != 16 || m.CPUPercent != 25 {
+		t.Errorf("CPU = %.1f%% of %d cores; expected 25%% of 16", m.CPUPercent, m.CPUCores)
+	}
+	if m.MemoryTotal != 800 || m.MemoryPercent != 37.5 || m.SwapPercent != 25 {
+		t.Errorf("memory %d at %.1f%%, swap %.1f%%; expected 800 at 37.5%%, swap 25%%", m.MemoryTotal, m.MemoryPercent, m.SwapPercent)
+	}
+
+	if _, err := combineMetrics(hosts[2:]); err == nil {
+		t.Error("expected an error with no host connected")
+	}
+}
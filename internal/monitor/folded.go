@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FoldedStacks accumulates CPU time per process stack across samples, for
+// --folded. Stacks follow the aggregated families: a parent's own usage is
+// its own frame and each related child is "parent;child", so flamegraph.pl
+// stacks children on their parent. Grandchildren are already summed into
+// the child they belong to, so stacks are at most two frames deep.
+type FoldedStacks struct {
+	millis map[string]float64 // CPU milliseconds per stack
+}
+
+// NewFoldedStacks starts an empty capture
+func NewFoldedStacks() *FoldedStacks {
+	return &FoldedStacks{millis: make(map[string]float64)}
+}
+
+// Add weighs one sample's processes by the CPU time they used over elapsed,
+// the time since the previous sample
+func (f *FoldedStacks) Add(procs []*ProcessInfo, elapsed time.Duration) {
+	ms := float64(elapsed) / float64(time.Millisecond)
+	for _, proc := range procs {
+		if proc.Exited {
+			continue
+		}
+		parent := foldedFrame(proc.Name)
+		own := proc.CPUPercent
+		if len(proc.Children) > 0 {
+			own = proc.ParentCPU
+		}
+		f.millis[parent] += own / 100 * ms
+		for _, child := range proc.Children {
+			f.millis[parent+";"+foldedFrame(child.Name)] += child.CPUPercent / 100 * ms
+		}
+	}
+}
+
+// foldedFrame makes a process name safe as a frame: ';' separates frames
+// and the weight follows the last space, so semicolons become colons
+func foldedFrame(name string) string {
+	if name == "" {
+		return "[unknown]"
+	}
+	return strings.ReplaceAll(name, ";", ":")
+}
+
+// WriteTo writes one "stack weight" line per stack, sorted by stack, with
+// the weight in whole CPU milliseconds; stacks that rounded to zero are
+// left out
+func (f *FoldedStacks) WriteTo(w io.Writer) (int64, error) {
+	stacks := make([]string, 0, len(f.millis))
+	for stack := range f.millis {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	var b strings.Builder
+	for _, stack := range stacks {
+		if weight := math.Round(f.millis[stack]); weight >= 1 {
+			fmt.Fprintf(&b, "%s %.0f\n", stack, weight)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
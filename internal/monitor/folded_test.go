@@ -0,0 +1,39 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFoldedStacks(t *testing.T) {
+	procs := []*ProcessInfo{
+		{
+			PID: 100, Name: "chrome", CPUPercent: 30, ParentCPU: 10,
+			Children: []ChildInfo{
+				{PID: 101, Name: "chrome-renderer", CPUPercent: 15},
+				{PID: 102, Name: "chrome-gpu", CPUPercent: 5, IsThread: true},
+			},
+		},
+		{PID: 200, Name: "make;build", CPUPercent: 50},
+		{PID: 300, Name: "idle", CPUPercent: 0.01},
+		{PID: 400, Name: "gone", CPUPercent: 80, Exited: true},
+	}
+
+	folded := NewFoldedStacks()
+	folded.Add(procs, time.Second)
+	folded.Add(procs, 2*time.Second)
+
+	var b strings.Builder
+	if _, err := folded.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	// Three seconds at each reading: chrome's own 10% is 300ms
+	expected := "chrome 300\n" +
+		"chrome;chrome-gpu 150\n" +
+		"chrome;chrome-renderer 450\n" +
+		"make:build 1500\n"
+	if b.String() != expected {
+		t.Errorf("WriteTo() =\n%s\nexpected\n%s", b.String(), expected)
+	}
+}
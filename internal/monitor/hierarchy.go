@@ -0,0 +1,65 @@
+package monitor
+
+import "slices"
+
+// buildTree flattens every process into a pstree-style list: each root (a
+// process whose parent wasn't scanned) followed depth-first by its
+// descendants, siblings in PID order. Nothing is aggregated, so each row is
+// the process's own usage. Descendants of collapsed processes are left out.
+// Rows are copies, so the tree's expansion state never leaks into the
+// normal view's. With maxDepth > 0, rows at that depth stand in for their
+// descendants, recording how many levels were cut off in DeeperLevels.
+func buildTree(all map[int32]*ProcessInfo, children map[int32][]int32, collapsed map[int32]bool, maxDepth int) []*ProcessInfo {
+	roots := make([]int32, 0, len(all)/8)
+	for pid, info := range all {
+		if _, hasParent := all[info.PPID]; !hasParent || info.PPID == pid {
+			roots = append(roots, pid)
+		}
+	}
+	slices.Sort(roots)
+
+	tree := make([]*ProcessInfo, 0, len(all))
+	var visit func(pid int32, depth int)
+	visit = func(pid int32, depth int) {
+		node := *all[pid]
+		info := &node
+		info.MemoryMB = float64(info.MemoryBytes) / (1024 * 1024)
+		info.Depth = depth
+
+		kids := make([]int32, 0, len(children[pid]))
+		for _, child := range children[pid] {
+			if _, ok := all[child]; ok && child != pid {
+				kids = append(kids, child)
+			}
+		}
+		info.TreeChildren = len(kids)
+		info.Expanded = !collapsed[pid]
+		tree = append(tree, info)
+		if maxDepth > 0 && depth >= maxDepth && len(kids) > 0 {
+			info.DeeperLevels = subtreeHeight(all, children, pid)
+			return
+		}
+		if !info.Expanded {
+			return
+		}
+		slices.Sort(kids)
+		for _, child := range kids {
+			visit(child, depth+1)
+		}
+	}
+	for _, pid := range roots {
+		visit(pid, 0)
+	}
+	return tree
+}
+
+// subtreeHeight counts the levels of scanned descendants below pid
+func subtreeHeight(all map[int32]*ProcessInfo, children map[int32][]int32, pid int32) int {
+	height := 0
+	for _, child := range children[pid] {
+		if _, ok := all[child]; ok && child != pid {
+			height = max(height, 1+subtreeHeight(all, children, child))
+		}
+	}
+	return height
+}
@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// TestTreeView checks every process is listed in pstree order with its own
+// usage, and that collapsing a branch hides its descendants
+func TestTreeView(t *testing.T) {
+	cfg := config.New()
+	cfg.SetTreeView(true)
+	m := New(cfg)
+	handles := []procHandle{
+		&fakeProc{pid: 12, ppid: 10, name: "ld", cpu: 30},
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "make", cpu: 1},
+		&fakeProc{pid: 11, ppid: 10, name: "make", cpu: 2},
+		&fakeProc{pid: 20, ppid: 11, name: "cc1", cpu: 90},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	rows := func() []string {
+		procs, err := m.GetFilteredProcesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, proc := range procs {
+			got = append(got, fmt.Sprintf("%d:%d:%d:%.0f", proc.Depth, proc.PID, proc.TreeChildren, proc.CPUPercent))
+		}
+		return got
+	}
+
+	// Below the thresholds and not summed: make (10) keeps its own 1%
+	expected := []string{"0:1:1:0", "1:10:2:1", "2:11:1:2", "3:20:0:90", "2:12:0:30"}
+	if got := rows(); !slices.Equal(got, expected) {
+		t.Errorf("tree = %v; expected %v", got, expected)
+	}
+
+	m.ToggleExpanded(11)
+	expected = []string{"0:1:1:0", "1:10:2:1", "2:11:1:2", "2:12:0:30"}
+	if got := rows(); !slices.Equal(got, expected) {
+		t.Errorf("tree with 11 collapsed = %v; expected %v", got, expected)
+	}
+
+	// Back in the normal view nothing is expanded by the tree
+	cfg.SetTreeView(false)
+	procs, err := m.GetFilteredProcesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, proc := range procs {
+		if proc.Expanded || proc.Depth != 0 {
+			t.Errorf("tree state leaked into the normal view: %+v", proc)
+		}
+	}
+}
+
+func TestTreeMaxDepth(t *testing.T) {
+	cfg := config.New()
+	cfg.SetTreeView(true)
+	cfg.SetMaxTreeDepth(1)
+	m := New(cfg)
+	handles := []procHandle{
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "make"},
+		&fakeProc{pid: 11, ppid: 10, name: "make"},
+		&fakeProc{pid: 12, ppid: 10, name: "ld"},
+		&fakeProc{pid: 20, ppid: 11, name: "cc1"},
+		&fakeProc{pid: 30, ppid: 1, name: "sshd"},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	rows := func() []string {
+		procs, err := m.GetFilteredProcesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, proc := range procs {
+			got = append(got, fmt.Sprintf("%d:%d:%d", proc.Depth, proc.PID, proc.DeeperLevels))
+		}
+		return got
+	}
+
+	// make (10) stands in for its two hidden levels; sshd has none to hide
+	expected := []string{"0:1:0", "1:10:2", "1:30:0"}
+	if got := rows(); !slices.Equal(got, expected) {
+		t.Errorf("tree at depth 1 = %v; expected %v", got, expected)
+	}
+
+	cfg.SetMaxTreeDepth(2)
+	expected = []string{"0:1:0", "1:10:0", "2:11:1", "2:12:0", "1:30:0"}
+	if got := rows(); !slices.Equal(got, expected) {
+		t.Errorf("tree at depth 2 = %v; expected %v", got, expected)
+	}
+}
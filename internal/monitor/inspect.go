@@ -0,0 +1,159 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Inspection is the full detail of one process, written by --inspect. Fields
+// that couldn't be read are left empty (and omitted from the JSON where
+// they can be), with the reason in Unavailable under the field's JSON name.
+type Inspection struct {
+	PID             int32             `json:"pid"`
+	PPID            int32             `json:"ppid"`
+	Name            string            `json:"name"`
+	Exe             string            `json:"exe,omitempty"`
+	Cmdline         string            `json:"cmdline,omitempty"`
+	Cwd             string            `json:"cwd,omitempty"`
+	User            string            `json:"user,omitempty"`
+	State           string            `json:"state,omitempty"`
+	Started         *time.Time        `json:"started,omitempty"`
+	UptimeSeconds   float64           `json:"uptime_seconds,omitempty"`
+	CPUSeconds      float64           `json:"cpu_seconds"`
+	AvgCPUPercent   float64           `json:"avg_cpu_percent"`
+	AllowedCPUs     string            `json:"allowed_cpus,omitempty"`
+	LastCPU         *int              `json:"last_cpu,omitempty"` // CPU the process last ran on
+	Memory          InspectionMemory  `json:"memory"`
+	Threads         int32             `json:"threads,omitempty"`
+	FDs             int32             `json:"fds,omitempty"`
+	Connections     int               `json:"connections,omitempty"` // Open network connections
+	IO              *InspectionIO     `json:"io,omitempty"`          // nil when unreadable, e.g. another user's process
+	MajorFaults     uint64            `json:"major_faults"`
+	MinorFaults     uint64            `json:"minor_faults"`
+	PIDNamespace    uint64            `json:"pid_namespace,omitempty"`
+	NamespacePID    string            `json:"namespace_pid,omitempty"`
+	SecurityContext string            `json:"security_context,omitempty"` // Only read with --security-context
+	Unavailable     map[string]string `json:"unavailable,omitempty"`      // Why a field is missing, e.g. "user": "permission denied"
+}
+
+// inspectionFields maps ProcessDetail's field names to Inspection's JSON
+// names, to carry over the detail's unavailable reasons
+var inspectionFields = map[string]string{
+	"Name":            "name",
+	"Exe":             "exe",
+	"Cmdline":         "cmdline",
+	"Cwd":             "cwd",
+	"AllowedCPUs":     "allowed_cpus",
+	"PIDNamespace":    "pid_namespace",
+	"NamespacePID":    "namespace_pid",
+	"SecurityContext": "security_context",
+}
+
+// markUnavailable records why the field with JSON name field couldn't be read
+func (i *Inspection) markUnavailable(field string, err error) {
+	if i.Unavailable == nil {
+		i.Unavailable = make(map[string]string)
+	}
+	i.Unavailable[field] = unavailableReason(err)
+}
+
+// InspectionMemory breaks down a process's memory in bytes
+type InspectionMemory struct {
+	RSS  uint64 `json:"rss"`
+	PSS  uint64 `json:"pss,omitempty"` // Linux only
+	VMS  uint64 `json:"vms"`
+	Swap uint64 `json:"swap,omitempty"`
+}
+
+// InspectionIO is a process's cumulative I/O since start
+type InspectionIO struct {
+	ReadBytes  uint64 `json:"read_bytes"`
+	WriteBytes uint64 `json:"write_bytes"`
+	ReadCount  uint64 `json:"read_count"`
+	WriteCount uint64 `json:"write_count"`
+}
+
+// Inspect collects the detail pane's fields for one process, plus the
+// owner, state, memory breakdown, thread and FD counts and I/O totals
+func (m *Monitor) Inspect(pid int32) (*Inspection, error) {
+	detail, err := m.GetProcessDetail(pid)
+	if errors.Is(err, process.ErrorProcessNotRunning) {
+		return nil, fmt.Errorf("no process with PID %d", pid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PID %d: %w", pid, err)
+	}
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, fmt.Errorf("no process with PID %d", pid)
+	}
+
+	ins := &Inspection{
+		PID:             pid,
+		Name:            detail.Name,
+		Exe:             detail.Exe,
+		Cmdline:         detail.Cmdline,
+		Cwd:             detail.Cwd,
+		CPUSeconds:      detail.CPUTime.Seconds(),
+		AvgCPUPercent:   detail.AvgCPU,
+		AllowedCPUs:     detail.AllowedCPUs,
+		MajorFaults:     detail.MajorFaults,
+		MinorFaults:     detail.MinorFaults,
+		PIDNamespace:    detail.PIDNamespace,
+		NamespacePID:    detail.NamespacePID,
+		SecurityContext: detail.SecurityContext,
+	}
+	for field, reason := range detail.Unavailable {
+		if name, ok := inspectionFields[field]; ok {
+			if ins.Unavailable == nil {
+				ins.Unavailable = make(map[string]string)
+			}
+			ins.Unavailable[name] = reason
+		}
+	}
+	if detail.LastCPU >= 0 {
+		ins.LastCPU = &detail.LastCPU
+	}
+	ins.FDs = max(detail.FDs, 0)
+	ins.Connections = max(detail.Connections, 0)
+	if !detail.Started.IsZero() {
+		ins.Started = &detail.Started
+		ins.UptimeSeconds = time.Since(detail.Started).Seconds()
+	}
+	if ins.PPID, err = p.Ppid(); err != nil {
+		ins.markUnavailable("ppid", err)
+	}
+	if ins.User, err = p.Username(); err != nil {
+		ins.markUnavailable("user", err)
+	}
+	if status, err := p.Status(); err == nil && len(status) > 0 {
+		ins.State = status[0]
+	}
+	if mem, err := p.MemoryInfo(); err == nil {
+		ins.Memory = InspectionMemory{RSS: mem.RSS, VMS: mem.VMS, Swap: mem.Swap}
+	} else {
+		ins.markUnavailable("memory", err)
+	}
+	if ins.Memory.PSS, err = readPSS(pid); err != nil {
+		ins.markUnavailable("memory.pss", err)
+	}
+	if ins.Threads, err = p.NumThreads(); err != nil {
+		ins.markUnavailable("threads", err)
+	}
+	if ioc, err := p.IOCounters(); err == nil {
+		ins.IO = &InspectionIO{ReadBytes: ioc.ReadBytes, WriteBytes: ioc.WriteBytes, ReadCount: ioc.ReadCount, WriteCount: ioc.WriteCount}
+	}
+	return ins, nil
+}
+
+// WriteJSON writes the inspection as indented JSON
+func (i *Inspection) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(i)
+}
@@ -0,0 +1,49 @@
+package monitor
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestInspect(t *testing.T) {
+	m := New(config.New())
+
+	ins, err := m.Inspect(int32(os.Getpid()))
+	if err != nil {
+		t.Fatalf("Inspect(self): %v", err)
+	}
+	if ins.PID != int32(os.Getpid()) || ins.Name == "" || ins.Memory.RSS == 0 {
+		t.Errorf("Inspect(self) = %+v; expected our PID, a name and RSS", ins)
+	}
+	for _, field := range []string{"ppid", "user", "threads", "memory"} {
+		if reason, ok := ins.Unavailable[field]; ok {
+			t.Errorf("Inspect(self) couldn't read its own %s: %s", field, reason)
+		}
+	}
+
+	// PIDs are capped well below this on every platform
+	if _, err := m.Inspect(1 << 30); err == nil || !strings.Contains(err.Error(), "no process with PID") {
+		t.Errorf("Inspect(missing) error = %v; expected a no such process error", err)
+	}
+}
+
+// TestInspectionUnavailableJSON checks a field that couldn't be read is
+// reported with the reason rather than as a zero reading
+func TestInspectionUnavailableJSON(t *testing.T) {
+	ins := &Inspection{PID: 1}
+	ins.markUnavailable("user", fs.ErrPermission)
+
+	var b strings.Builder
+	if err := ins.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `"unavailable": {
+    "user": "permission denied"
+  }`) {
+		t.Errorf("WriteJSON = %s; expected the user field's reason", b.String())
+	}
+}
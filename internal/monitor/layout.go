@@ -0,0 +1,34 @@
+package monitor
+
+import "slices"
+
+// ExpandedNames lists the group names of the expanded processes, sorted,
+// for saving as a layout. The --tree view's collapsed set isn't included.
+func (m *Monitor) ExpandedNames() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var names []string
+	for _, info := range m.processes {
+		if info.Expanded && !slices.Contains(names, info.GroupName()) {
+			names = append(names, info.GroupName())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// ExpandNames restores a layout: processes whose group name is in names
+// are expanded and the rest collapsed, and ones that appear later with
+// those names start expanded
+func (m *Monitor) ExpandNames(names []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expandNames = make(map[string]bool, len(names))
+	for _, name := range names {
+		m.expandNames[name] = true
+	}
+	for _, info := range m.processes {
+		info.Expanded = m.expandNames[info.GroupName()]
+	}
+	return nil
+}
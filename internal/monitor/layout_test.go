@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestExpandNames(t *testing.T) {
+	m := New(config.New())
+	handles := []procHandle{
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "java"},
+		&fakeProc{pid: 11, ppid: 1, name: "bash"},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+	if _, err := m.GetFilteredProcesses(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.ToggleExpanded(10)
+	names, err := m.ExpandedNames()
+	if err != nil || !slices.Equal(names, []string{"java"}) {
+		t.Fatalf("ExpandedNames() = %v, %v; want [java]", names, err)
+	}
+
+	if err := m.ExpandNames([]string{"bash", "python"}); err != nil {
+		t.Fatal(err)
+	}
+	if m.processes[10].Expanded || !m.processes[11].Expanded {
+		t.Errorf("restoring [bash python] left java=%v bash=%v expanded", m.processes[10].Expanded, m.processes[11].Expanded)
+	}
+
+	// A process with a layout name that starts later is expanded too
+	handles = append(handles, &fakeProc{pid: 12, ppid: 1, name: "python"})
+	if _, err := m.GetFilteredProcesses(); err != nil {
+		t.Fatal(err)
+	}
+	if !m.processes[12].Expanded {
+		t.Error("python started after the restore wasn't expanded")
+	}
+}
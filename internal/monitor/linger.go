@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"sort"
+	"time"
+)
+
+// lingerExited appends the top-level processes listed on the previous scan
+// that have since exited, marked Exited with their final readings, until
+// linger has passed since they were last seen (their LastUpdate). Exited
+// rows go after the live ones, most recently seen first, so they don't
+// shuffle the sorted list. A reused PID drops its exited row at once.
+// Callers must hold m.mu.
+func (m *Monitor) lingerExited(listed []*ProcessInfo, all map[int32]*ProcessInfo, linger time.Duration, now time.Time) []*ProcessInfo {
+	if linger <= 0 {
+		m.listed, m.exited = nil, nil
+		return listed
+	}
+	if m.exited == nil {
+		m.exited = make(map[int32]*ProcessInfo)
+	}
+
+	for pid, info := range m.listed {
+		if _, alive := all[pid]; !alive && m.exited[pid] == nil {
+			// Copy, since the previous list may still be on screen
+			gone := *info
+			gone.Exited = true
+			m.exited[pid] = &gone
+		}
+	}
+
+	m.listed = make(map[int32]*ProcessInfo, len(listed))
+	for _, info := range listed {
+		m.listed[info.PID] = info
+	}
+
+	start := len(listed)
+	for pid, gone := range m.exited {
+		if _, alive := all[pid]; alive || now.Sub(gone.LastUpdate) >= linger {
+			delete(m.exited, pid)
+			continue
+		}
+		listed = append(listed, gone)
+	}
+	exited := listed[start:]
+	sort.Slice(exited, func(i, j int) bool {
+		if !exited[i].LastUpdate.Equal(exited[j].LastUpdate) {
+			return exited[i].LastUpdate.After(exited[j].LastUpdate)
+		}
+		return exited[i].PID < exited[j].PID
+	})
+	return listed
+}
@@ -0,0 +1,55 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLingerExited(t *testing.T) {
+	m := &Monitor{}
+	start := time.Unix(1700000000, 0)
+	web := &ProcessInfo{PID: 10, Name: "web", CPUPercent: 80, LastUpdate: start}
+	job := &ProcessInfo{PID: 20, Name: "job", CPUPercent: 40, LastUpdate: start}
+	all := map[int32]*ProcessInfo{10: web, 20: job}
+
+	if got := m.lingerExited([]*ProcessInfo{web, job}, all, 5*time.Second, start); len(got) != 2 {
+		t.Fatalf("expected both live processes, got %d rows", len(got))
+	}
+
+	// job exits: it stays at the bottom with its final readings
+	delete(all, 20)
+	got := m.lingerExited([]*ProcessInfo{web}, all, 5*time.Second, start.Add(time.Second))
+	if len(got) != 2 || got[1].PID != 20 || !got[1].Exited || got[1].CPUPercent != 40 {
+		t.Fatalf("expected job kept as exited after web, got %+v", got)
+	}
+	if job.Exited {
+		t.Error("the previous scan's ProcessInfo was modified in place")
+	}
+
+	// Still there while within the linger window, then dropped
+	if got := m.lingerExited([]*ProcessInfo{web}, all, 5*time.Second, start.Add(4*time.Second)); len(got) != 2 {
+		t.Errorf("expected job to linger for 5s, got %d rows at 4s", len(got))
+	}
+	web.LastUpdate = start.Add(5 * time.Second)
+	if got := m.lingerExited([]*ProcessInfo{web}, all, 5*time.Second, start.Add(5*time.Second)); len(got) != 1 {
+		t.Errorf("expected job dropped after 5s, got %d rows", len(got))
+	}
+
+	// A reused PID replaces the exited row
+	delete(all, 10)
+	if got := m.lingerExited(nil, all, 5*time.Second, start.Add(6*time.Second)); len(got) != 1 || !got[0].Exited {
+		t.Fatalf("expected web kept as exited, got %+v", got)
+	}
+	reused := &ProcessInfo{PID: 10, Name: "other", LastUpdate: start.Add(7 * time.Second)}
+	all[10] = reused
+	if got := m.lingerExited([]*ProcessInfo{reused}, all, 5*time.Second, start.Add(7*time.Second)); len(got) != 1 || got[0].Exited {
+		t.Errorf("expected only the live process on a reused PID, got %+v", got)
+	}
+
+	// Turning linger off drops exited rows at once
+	delete(all, 10)
+	m.lingerExited(nil, all, 5*time.Second, start.Add(8*time.Second))
+	if got := m.lingerExited(nil, all, 0, start.Add(8*time.Second)); len(got) != 0 {
+		t.Errorf("expected no rows with linger off, got %+v", got)
+	}
+}
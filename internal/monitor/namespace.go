@@ -0,0 +1,7 @@
+package monitor
+
+import "errors"
+
+// errNoNamespaces is what the namespace readers return where PID namespaces
+// don't exist, as opposed to a namespace that couldn't be read
+var errNoNamespaces = errors.New("PID namespaces are not supported on this platform")
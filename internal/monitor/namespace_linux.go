@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readPIDNamespace returns the inode identifying a process's PID namespace,
+// from the /proc/PID/ns/pid link. Reading it needs the same privileges as
+// ptrace, so other users' processes usually fail unless running as root.
+func readPIDNamespace(pid int32) (uint64, error) {
+	link, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/pid", pid))
+	if err != nil {
+		return 0, err
+	}
+	return parseNamespaceLink(link)
+}
+
+// parseNamespaceLink extracts the inode from a namespace link target such
+// as "pid:[4026531836]"
+func parseNamespaceLink(link string) (uint64, error) {
+	_, rest, ok := strings.Cut(link, ":[")
+	if !ok || !strings.HasSuffix(rest, "]") {
+		return 0, fmt.Errorf("unexpected namespace link %q", link)
+	}
+	return strconv.ParseUint(strings.TrimSuffix(rest, "]"), 10, 64)
+}
+
+// readNamespacePID returns the PID a process has inside its own PID
+// namespace, the last entry of the NSpid status field; it equals pid for
+// processes in the host namespace
+func readNamespacePID(pid int32) (string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return "", err
+	}
+	nspid, err := parseStatusField(data, "NSpid")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(nspid)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty NSpid field")
+	}
+	return fields[len(fields)-1], nil
+}
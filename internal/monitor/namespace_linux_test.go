@@ -0,0 +1,26 @@
+package monitor
+
+import "testing"
+
+func TestParseNamespaceLink(t *testing.T) {
+	tests := []struct {
+		link     string
+		expected uint64
+		wantErr  bool
+	}{
+		{"pid:[4026531836]", 4026531836, false},
+		{"pid:[]", 0, true},
+		{"pid:4026531836", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		result, err := parseNamespaceLink(tt.link)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseNamespaceLink(%q) error = %v; wantErr %v", tt.link, err, tt.wantErr)
+		}
+		if result != tt.expected {
+			t.Errorf("parseNamespaceLink(%q) = %d; expected %d", tt.link, result, tt.expected)
+		}
+	}
+}
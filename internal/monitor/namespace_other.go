@@ -0,0 +1,14 @@
+//go:build !linux
+
+package monitor
+
+// readPIDNamespace is only supported on Linux; processes are treated as
+// sharing one namespace
+func readPIDNamespace(_ int32) (uint64, error) {
+	return 0, errNoNamespaces
+}
+
+// readNamespacePID is only supported on Linux; the detail pane omits it
+func readNamespacePID(_ int32) (string, error) {
+	return "", errNoNamespaces
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// readPressure reads the system's pressure stall information from
+// /proc/pressure (Linux 4.20+, and only with CONFIG_PSI enabled)
+func readPressure() (*Pressure, error) {
+	var pressure Pressure
+	for _, r := range []struct {
+		name string
+		stat *PressureStat
+	}{
+		{"cpu", &pressure.CPU},
+		{"memory", &pressure.Memory},
+		{"io", &pressure.IO},
+	} {
+		data, err := os.ReadFile("/proc/pressure/" + r.name)
+		if err != nil {
+			return nil, err
+		}
+		if *r.stat, err = parsePressure(data); err != nil {
+			return nil, fmt.Errorf("%s pressure: %w", r.name, err)
+		}
+	}
+	return &pressure, nil
+}
+
+// parsePressure extracts the avg10 values of the "some" and "full" lines of
+// a /proc/pressure file. Kernels before 5.13 have no "full" line for cpu,
+// which leaves Full at zero.
+func parsePressure(data []byte) (PressureStat, error) {
+	var stat PressureStat
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) < 2 || !bytes.HasPrefix(fields[1], []byte("avg10=")) {
+			continue
+		}
+		value, err := strconv.ParseFloat(string(bytes.TrimPrefix(fields[1], []byte("avg10="))), 64)
+		if err != nil {
+			return PressureStat{}, fmt.Errorf("invalid avg10 %q: %w", fields[1], err)
+		}
+		switch string(fields[0]) {
+		case "some":
+			stat.Some, found = value, true
+		case "full":
+			stat.Full = value
+		}
+	}
+	if !found {
+		return PressureStat{}, errors.New("no some line found")
+	}
+	return stat, nil
+}
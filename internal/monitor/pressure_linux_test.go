@@ -0,0 +1,34 @@
+package monitor
+
+import "testing"
+
+func TestParsePressure(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected PressureStat
+		wantErr  bool
+	}{
+		{
+			name: "Some and full",
+			data: "some avg10=4.20 avg60=1.10 avg300=0.30 total=123456\n" +
+				"full avg10=0.50 avg60=0.10 avg300=0.00 total=2345\n",
+			expected: PressureStat{Some: 4.2, Full: 0.5},
+		},
+		{"Older kernel cpu", "some avg10=12.00 avg60=8.00 avg300=2.00 total=99\n", PressureStat{Some: 12}, false},
+		{"Missing some", "full avg10=0.50 avg60=0.10 avg300=0.00 total=2345\n", PressureStat{}, true},
+		{"Malformed avg10", "some avg10=abc avg60=1.10 avg300=0.30 total=1\n", PressureStat{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parsePressure([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePressure() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parsePressure() = %+v; expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readPressure is only supported on Linux; the header omits pressure
+func readPressure() (*Pressure, error) {
+	return nil, errors.New("pressure stall information is not supported on this platform")
+}
@@ -1,85 +1,291 @@
 package monitor
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/SteiniDavid/brieftop/internal/config"
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
-	"github.com/shirou/gopsutil/v3/process"
 )
 
+// ProcessInfo is also the JSON snapshot schema; UI-only state is excluded
 type ProcessInfo struct {
-	PID          int32
-	PPID         int32
-	Name         string
-	CPUPercent   float64
-	MemoryBytes  uint64
-	MemoryMB     float64
-	Children     []ChildInfo
-	Expanded     bool
-	LastUpdate   time.Time
-	ParentCPU    float64 // Store original parent CPU for display
-	ParentMemory uint64  // Store original parent memory for display
+	PID              int32       `json:"pid"`
+	PPID             int32       `json:"ppid"`
+	Name             string      `json:"name"`                                  // Current comm name, may change if the process renames itself
+	ExeName          string      `json:"exe_name,omitempty"`                    // Executable basename, stable across renames; empty if unreadable
+	Category         string      `json:"category,omitempty"`                    // Category from the configured rules; empty if none matched
+	CPUPercent       float64     `json:"cpu_percent"`                           // Aggregated across related children
+	MemoryBytes      uint64      `json:"memory_bytes"`                          // Aggregated across related children
+	MemoryMB         float64     `json:"-"`                                     // Derived from MemoryBytes
+	Children         []ChildInfo `json:"children,omitempty"`                    // Related child processes and threads
+	Expanded         bool        `json:"-"`                                     // UI expansion state
+	LastUpdate       time.Time   `json:"-"`                                     // When this sample was taken
+	ParentCPU        float64     `json:"parent_cpu_percent,omitempty"`          // Store original parent CPU for display
+	ParentMemory     uint64      `json:"parent_memory_bytes,omitempty"`         // Store original parent memory for display
+	CPUSeconds       float64     `json:"cpu_seconds,omitempty"`                 // Cumulative user+system CPU time, aggregated like CPUPercent; only read when shown
+	ParentCPUSeconds float64     `json:"parent_cpu_seconds,omitempty"`          // Store original parent CPU time for display
+	AvgCPUPercent    float64     `json:"avg_cpu_percent,omitempty"`             // CPU time over time since start, aggregated like CPUPercent; only read when shown
+	ParentAvgCPU     float64     `json:"parent_avg_cpu_percent,omitempty"`      // Store original parent average for display
+	TTY              string      `json:"tty,omitempty"`                         // Controlling terminal ("pts/3", "?" for none); only read when the TTY column is shown
+	State            string      `json:"state,omitempty"`                       // Scheduler state, e.g. "running", "sleep", "blocked" (D state)
+	BlockedRefreshes int         `json:"blocked_refreshes,omitempty"`           // Consecutive refreshes spent in D state
+	PIDNamespace     uint64      `json:"pid_namespace,omitempty"`               // PID namespace inode; 0 if unreadable or unsupported
+	NamespaceUnread  bool        `json:"namespace_unread,omitempty"`            // PIDNamespace couldn't be read, e.g. another user's process without root
+	ContainerID      string      `json:"container_id,omitempty"`                // Docker/Podman/containerd container ID from the cgroup path; empty for host processes
+	ContainerName    string      `json:"container_name,omitempty"`              // Docker's container name when readable, else the 12-digit short ID
+	MajorFaultRate   float64     `json:"major_faults_per_sec,omitempty"`        // Major page faults per second, aggregated like CPUPercent; only read when shown
+	ParentFaultRate  float64     `json:"parent_major_faults_per_sec,omitempty"` // Store original parent fault rate for display
+	Exited           bool        `json:"exited,omitempty"`                      // Gone from the system; kept listed for --linger with its final readings
+	Depth            int         `json:"depth,omitempty"`                       // Nesting level in the --tree view; 0 otherwise
+	TreeChildren     int         `json:"tree_children,omitempty"`               // Direct children in the --tree view, listed below it unless collapsed
+	DeeperLevels     int         `json:"deeper_levels,omitempty"`               // Levels of descendants --max-depth hides below this --tree row
+	PrevCPUPercent   *float64    `json:"prev_cpu_percent,omitempty"`            // CPUPercent on the previous scan, for --cpu-delta; nil for rows new since then
+	Critical         bool        `json:"critical,omitempty"`                    // Named by --critical: always listed and highlighted
+}
+
+// GroupName returns the name used for grouping and aggregation. The
+// executable basename is preferred since processes can rename their comm
+// mid-life (e.g. via prctl), which would otherwise reshuffle the tree.
+func (p *ProcessInfo) GroupName() string {
+	if p.ExeName != "" {
+		return p.ExeName
+	}
+	return p.Name
 }
 
 type ChildInfo struct {
-	PID         int32
-	Name        string
-	CPUPercent  float64
-	MemoryBytes uint64
-	IsThread    bool
+	PID            int32   `json:"pid"`
+	Name           string  `json:"name"`
+	CPUPercent     float64 `json:"cpu_percent"`
+	MemoryBytes    uint64  `json:"memory_bytes"`
+	CPUSeconds     float64 `json:"cpu_seconds,omitempty"`
+	AvgCPU         float64 `json:"avg_cpu_percent,omitempty"`
+	MajorFaultRate float64 `json:"major_faults_per_sec,omitempty"`
+	IsThread       bool    `json:"is_thread"`
+	Blocked        bool    `json:"blocked,omitempty"` // In uninterruptible sleep (D state)
 }
 
 type SystemMetrics struct {
-	CPUPercent      float64
-	CPUCores        int
-	MemoryTotal     uint64
-	MemoryUsed      uint64
-	MemoryAvailable uint64
-	MemoryCached    uint64
-	MemoryBuffers   uint64
-	MemoryPercent   float64
-	SwapTotal       uint64
-	SwapUsed        uint64
-	SwapPercent     float64
+	CPUPercent      float64   `json:"cpu_percent"`
+	CPUCores        int       `json:"cpu_cores"`
+	MemoryTotal     uint64    `json:"memory_total"`
+	MemoryUsed      uint64    `json:"memory_used"`
+	MemoryAvailable uint64    `json:"memory_available"`
+	MemoryCached    uint64    `json:"memory_cached"`
+	MemoryBuffers   uint64    `json:"memory_buffers"`
+	MemoryPercent   float64   `json:"memory_percent"`
+	SwapTotal       uint64    `json:"swap_total"`
+	SwapUsed        uint64    `json:"swap_used"`
+	SwapPercent     float64   `json:"swap_percent"`
+	Load1           float64   `json:"load1,omitempty"` // Load averages; zero where unsupported
+	Load5           float64   `json:"load5,omitempty"`
+	Load15          float64   `json:"load15,omitempty"`
+	Pressure        *Pressure `json:"pressure,omitempty"` // nil where PSI is unavailable
+}
+
+// PressureStat is one resource's stall time over the last 10 seconds, as a
+// percentage: Some is the share of time at least one task waited on it,
+// Full the share when every non-idle task did
+type PressureStat struct {
+	Some float64 `json:"some"`
+	Full float64 `json:"full"`
 }
 
+// Pressure is the kernel's pressure stall information (PSI), which tells
+// a system that's stalled on a resource from one that's merely busy
+type Pressure struct {
+	CPU    PressureStat `json:"cpu"`
+	Memory PressureStat `json:"memory"`
+	IO     PressureStat `json:"io"`
+}
+
+// Monitor is safe for concurrent use: the UI toggles expansion from its input
+// goroutine while scans run on the update goroutine.
 type Monitor struct {
-	processes    map[int32]*ProcessInfo
-	lastCPUTimes map[int32]float64
-	config       ConfigInterface
+	scanMu          sync.Mutex    // Serializes scans, which share buf
+	source          processSource // Where scans enumerate processes from
+	buf             scanBuffers   // Reused by each scan; guarded by scanMu
+	mu              sync.Mutex    // Guards processes, blockedStreaks, stuck, cpuHistory, systemCPU, timings, tasks, alerts, criticalRunning, criticalMissing, listed, exited, collapsed, expandNames, prevCPU, cpuSampled, sampled and primed
+	processes       map[int32]*ProcessInfo
+	blockedStreaks  map[int32]int  // Consecutive refreshes each PID has been in D state
+	stuck           []*ProcessInfo // Processes blocked for at least stuckRefreshes
+	lastCPUTimes    map[int32]float64
+	cpuHistory      map[int32]*cpuWindow  // Recent CPU readings per PID, for --cpu-window
+	lastFaults      map[int32]faultSample // Previous major fault reading per PID, for fault rates
+	systemCPU       cpuWindow             // Recent system-wide CPU readings
+	config          ConfigInterface
+	timings         ScanTimings            // How long the last GetFilteredProcesses took
+	tasks           TaskCounts             // State breakdown of the last scan
+	alerting        map[int32]bool         // PIDs over an alert threshold on the last scan
+	alerts          []Alert                // Processes that crossed an alert threshold on the last scan
+	criticalRunning map[string]bool        // Critical names running on the last scan; nil before the first
+	criticalMissing []string               // Critical names that stopped running on the last scan
+	listed          map[int32]*ProcessInfo // Top-level processes returned by the last scan, for --linger
+	exited          map[int32]*ProcessInfo // Exited processes still lingering in the list
+	collapsed       map[int32]bool         // Processes whose descendants the --tree view hides
+	expandNames     map[string]bool        // Group names a restored layout expands as they appear
+	prevCPU         previousCPU            // Listed rows' CPU on the last scan, for --cpu-delta
+	cpuSampled      bool                   // GetSystemMetrics has read the system CPU, so --cpu-blocking is done
+	sampled         bool                   // At least one successful enumeration has completed
+	primed          bool                   // At least two enumerations, so CPU deltas are meaningful
 }
 
 type ConfigInterface interface {
 	GetCPUThreshold() float64
 	GetMemoryThreshold() uint64
 	GetRefreshRate() time.Duration
+	GetMemoryMetric() string
+	GetCategoryRules() []config.CategoryRule
+	GetHideSelf() bool
+	GetSortMode() config.SortMode
+	GetShowTTY() bool
+	GetShowCPUTime() bool
+	GetScanWorkers() int
+	GetExclude() []string
+	GetCPUWindow() time.Duration
+	GetShowAvgCPU() bool
+	GetAlertCPU() float64
+	GetAlertMemory() uint64
+	GetShowFaults() bool
+	GetShowSecurityContext() bool
+	GetLinger() time.Duration
+	GetAggregateAllChildren() bool
+	GetSumThreads() bool
+	GetTreeView() bool
+	GetMaxTreeDepth() int
+	GetCritical() []string
+	GetCPUBlocking() time.Duration
+	GetScope() (string, int32)
 }
 
 func New(config ConfigInterface) *Monitor {
 	return &Monitor{
 		processes:    make(map[int32]*ProcessInfo),
 		lastCPUTimes: make(map[int32]float64),
+		cpuHistory:   make(map[int32]*cpuWindow),
+		lastFaults:   make(map[int32]faultSample),
+		collapsed:    make(map[int32]bool),
 		config:       config,
+		source:       systemProcesses,
+	}
+}
+
+// ScanTimings breaks down where a GetFilteredProcesses call spent its time
+type ScanTimings struct {
+	Enumerate time.Duration // Listing processes and reading each one's stats
+	Aggregate time.Duration // Rolling up children, filtering and sorting
+}
+
+// LastScanTimings returns the timings of the most recent scan
+func (m *Monitor) LastScanTimings() ScanTimings {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.timings
+}
+
+// scanBuffers are GetFilteredProcesses's working maps. They are cleared and
+// reused across refreshes rather than reallocated, which keeps GC pressure
+// down on hosts with thousands of processes.
+type scanBuffers struct {
+	all        map[int32]*ProcessInfo
+	children   map[int32][]int32 // Parent PID -> children PIDs
+	aggregated map[int32]bool
+	qualifying map[int32]*ProcessInfo
+	infos      []*ProcessInfo // First-pass results, indexed like the source's processes
+}
+
+func newScanBuffers() scanBuffers {
+	return scanBuffers{
+		all:        make(map[int32]*ProcessInfo),
+		children:   make(map[int32][]int32),
+		aggregated: make(map[int32]bool),
+		qualifying: make(map[int32]*ProcessInfo),
 	}
 }
 
+func (b *scanBuffers) reset() {
+	clear(b.all)
+	clear(b.children)
+	clear(b.aggregated)
+	clear(b.qualifying)
+}
+
+// workerCount bounds the first pass's goroutines to the configured pool
+// size, and to the number of processes on tiny process sets
+func (m *Monitor) workerCount(processes int) int {
+	workers := m.config.GetScanWorkers()
+	if workers > processes {
+		workers = processes
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
 func (m *Monitor) GetFilteredProcesses() ([]*ProcessInfo, error) {
-	processes, err := process.Processes()
+	m.scanMu.Lock()
+	defer m.scanMu.Unlock()
+
+	start := time.Now()
+	processes, err := m.source()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get processes: %w", err)
 	}
 
+	if m.buf.all == nil {
+		m.buf = newScanBuffers()
+	}
+	m.buf.reset()
+	allProcesses := m.buf.all
+	childrenMap := m.buf.children
 	filtered := make([]*ProcessInfo, 0, len(processes)/4)
-	allProcesses := make(map[int32]*ProcessInfo, len(processes))
-	childrenMap := make(map[int32][]int32) // parent PID -> children PIDs
 
-	// First pass: collect all process info and build parent-child mapping
-	for _, p := range processes {
-		info, err := m.getProcessInfo(p)
-		if err != nil {
+	selfPID := int32(os.Getpid())
+	hideSelf := m.config.GetHideSelf()
+
+	// First pass: read every process's info across a bounded worker pool.
+	// Each worker fills its own slots in infos, so no locking is needed and
+	// the maps below are still built in enumeration order.
+	if cap(m.buf.infos) < len(processes) {
+		m.buf.infos = make([]*ProcessInfo, len(processes))
+	}
+	infos := m.buf.infos[:len(processes)]
+	clear(infos)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < m.workerCount(len(processes)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if info, err := m.getProcessInfo(processes[i]); err == nil {
+					infos[i] = info
+				}
+			}
+		}()
+	}
+	for i, p := range processes {
+		if hideSelf && p.PID() == selfPID {
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Then build the parent-child mapping
+	for _, info := range infos {
+		if info == nil {
 			continue
 		}
 		allProcesses[info.PID] = info
@@ -90,25 +296,62 @@ func (m *Monitor) GetFilteredProcesses() ([]*ProcessInfo, error) {
 		}
 	}
 
+	enumerated := time.Now()
+
 	// Clean up stale processes no longer present on the system
+	m.mu.Lock()
 	for pid := range m.processes {
 		if _, alive := allProcesses[pid]; !alive {
 			delete(m.processes, pid)
 		}
 	}
+	for pid := range m.cpuHistory {
+		if _, alive := allProcesses[pid]; !alive {
+			delete(m.cpuHistory, pid)
+		}
+	}
+	for pid := range m.lastFaults {
+		if _, alive := allProcesses[pid]; !alive {
+			delete(m.lastFaults, pid)
+		}
+	}
+	for pid := range m.collapsed {
+		if _, alive := allProcesses[pid]; !alive {
+			delete(m.collapsed, pid)
+		}
+	}
+	m.trackBlocked(allProcesses)
+	m.tasks = countTasks(allProcesses)
+	m.trackAlerts(allProcesses)
+	m.trackCritical(allProcesses)
+	if m.config.GetTreeView() {
+		// The tree lists every process with its own usage, so there is
+		// nothing to aggregate or filter
+		tree := buildTree(allProcesses, childrenMap, m.collapsed, m.config.GetMaxTreeDepth())
+		m.trackPreviousCPU(tree)
+		m.timings = ScanTimings{Enumerate: enumerated.Sub(start), Aggregate: time.Since(enumerated)}
+		if m.sampled {
+			m.primed = true
+		}
+		m.sampled = true
+		m.mu.Unlock()
+		return tree, nil
+	}
+	m.mu.Unlock()
 
 	// Second pass: recursively aggregate resources bottom-up for ALL processes
-	aggregated := make(map[int32]bool)
 	for pid := range allProcesses {
-		m.aggregateResources(pid, allProcesses, childrenMap, aggregated)
+		m.aggregateResources(pid, allProcesses, childrenMap, m.buf.aggregated)
 	}
 
 	// Third pass: filter based on aggregated totals and collect top-level processes
-	qualifyingProcesses := make(map[int32]*ProcessInfo)
+	qualifyingProcesses := m.buf.qualifying
 
 	for _, info := range allProcesses {
-		// Check if aggregated resources meet our thresholds
-		if info.CPUPercent >= m.config.GetCPUThreshold() || info.MemoryBytes >= m.config.GetMemoryThreshold() {
+		// Check if aggregated resources meet our thresholds. D-state
+		// processes sit at 0% CPU, so they are always shown, as are
+		// critical ones.
+		if info.CPUPercent >= m.config.GetCPUThreshold() || info.MemoryBytes >= m.config.GetMemoryThreshold() || info.IsBlocked() || info.Critical {
 			qualifyingProcesses[info.PID] = info
 		}
 	}
@@ -121,13 +364,110 @@ func (m *Monitor) GetFilteredProcesses() ([]*ProcessInfo, error) {
 		}
 	}
 
-	sort.Slice(filtered, func(i, j int) bool {
-		return filtered[i].CPUPercent > filtered[j].CPUPercent
-	})
+	sortProcesses(filtered, m.config.GetSortMode())
+
+	// CPU percentages need two samples, so the first enumeration only
+	// establishes a baseline
+	m.mu.Lock()
+	filtered = m.lingerExited(filtered, allProcesses, m.config.GetLinger(), time.Now())
+	m.trackPreviousCPU(filtered)
+	m.timings = ScanTimings{Enumerate: enumerated.Sub(start), Aggregate: time.Since(enumerated)}
+	if m.sampled {
+		m.primed = true
+	}
+	m.sampled = true
+	m.mu.Unlock()
 
 	return filtered, nil
 }
 
+// sortKey returns the value processes are ordered by (descending) in a mode
+func sortKey(proc *ProcessInfo, mode config.SortMode) float64 {
+	switch mode {
+	case config.SortByMemory:
+		return float64(proc.MemoryBytes)
+	case config.SortByComposite:
+		// Each resource is scaled by its "high" level, so a 1GB idle JVM
+		// ranks alongside a process burning 100% CPU
+		return compositeScore(proc.CPUPercent, float64(proc.MemoryBytes)/(1024*1024))
+	default:
+		return proc.CPUPercent
+	}
+}
+
+// compositeScore weighs CPU and memory against their "high" resource levels
+func compositeScore(cpuPercent, memoryMB float64) float64 {
+	return cpuPercent/highCPUPercent + memoryMB/highMemoryMB
+}
+
+// sortProcesses orders processes by the sort mode's key (descending). PID is
+// the final tiebreaker so equal entries keep a stable order across refreshes.
+func sortProcesses(procs []*ProcessInfo, mode config.SortMode) {
+	sort.Slice(procs, func(i, j int) bool {
+		ki, kj := sortKey(procs[i], mode), sortKey(procs[j], mode)
+		if ki != kj {
+			return ki > kj
+		}
+		return procs[i].PID < procs[j].PID
+	})
+}
+
+// SortChildren returns children ordered by childSort; ChildSortFollow uses
+// the main list's mode. The input slice is left untouched since it is
+// shared with the render goroutine.
+func SortChildren(children []ChildInfo, childSort config.ChildSort, mode config.SortMode) []ChildInfo {
+	sorted := append([]ChildInfo(nil), children...)
+	key := func(c ChildInfo) float64 {
+		switch childSort {
+		case config.ChildSortCPU:
+			return c.CPUPercent
+		case config.ChildSortMemory:
+			return float64(c.MemoryBytes)
+		case config.ChildSortPID:
+			return -float64(c.PID)
+		}
+		switch mode {
+		case config.SortByMemory:
+			return float64(c.MemoryBytes)
+		case config.SortByComposite:
+			return compositeScore(c.CPUPercent, float64(c.MemoryBytes)/(1024*1024))
+		default:
+			return c.CPUPercent
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ki, kj := key(sorted[i]), key(sorted[j])
+		if ki != kj {
+			return ki > kj
+		}
+		return sorted[i].PID < sorted[j].PID
+	})
+	return sorted
+}
+
+// CPUTime returns the cumulative CPU time as a duration
+func (p *ProcessInfo) CPUTime() time.Duration {
+	return time.Duration(p.CPUSeconds * float64(time.Second))
+}
+
+// lifetimeAvgCPU is the CPU percentage averaged over a process's whole life:
+// its CPU time over the time since it started
+func lifetimeAvgCPU(cpuSeconds float64, started, now time.Time) float64 {
+	elapsed := now.Sub(started).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return cpuSeconds / elapsed * 100
+}
+
+// IsPrimed reports whether enough samples have been taken for CPU
+// percentages to be trustworthy
+func (m *Monitor) IsPrimed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.primed
+}
+
 // aggregateResources recursively aggregates CPU and memory usage from children to parents
 // This ensures multi-level hierarchies are properly aggregated bottom-up
 // Only aggregates children that are part of the same application family
@@ -153,41 +493,59 @@ func (m *Monitor) aggregateResources(pid int32, allProcesses map[int32]*ProcessI
 	// Store original parent values before aggregation
 	info.ParentCPU = info.CPUPercent
 	info.ParentMemory = info.MemoryBytes
+	info.ParentCPUSeconds = info.CPUSeconds
+	info.ParentAvgCPU = info.AvgCPUPercent
+	info.ParentFaultRate = info.MajorFaultRate
 
 	// Recursively aggregate children first (bottom-up)
 	totalCPU := info.CPUPercent
 	totalMemory := info.MemoryBytes
+	totalCPUSeconds := info.CPUSeconds
+	totalAvgCPU := info.AvgCPUPercent
+	totalFaultRate := info.MajorFaultRate
 	hasRelatedChildren := false
+	aggregateAll := m.config.GetAggregateAllChildren() && !isSystemParent(info)
+	sumThreads := m.config.GetSumThreads() && !isSystemParent(info)
 
 	for _, childPID := range childPIDs {
 		// Ensure child is aggregated first
 		m.aggregateResources(childPID, allProcesses, childrenMap, aggregated)
 
 		if childInfo, childExists := allProcesses[childPID]; childExists {
+			// Determine if this is a thread or child process
+			isThread := m.isThread(childInfo, info)
+
 			// Check if this child should be aggregated into parent
-			// Only aggregate if child is related (same app family)
-			if !m.isRelatedToParent(childInfo, info) {
+			// Only aggregate if child is related (same app family), unless
+			// every child is summed (--aggregate-all) or it's taken for a
+			// thread with --sum-threads
+			summed := aggregateAll || (sumThreads && isThread && sameNamespace(childInfo, info))
+			if !summed && !m.isRelatedToParent(childInfo, info) {
 				// Child is from a different application - don't aggregate
 				continue
 			}
 
 			hasRelatedChildren = true
 
-			// Determine if this is a thread or child process
-			isThread := m.isThread(childInfo, info)
-
 			child := ChildInfo{
-				PID:         childInfo.PID,
-				Name:        childInfo.Name,
-				CPUPercent:  childInfo.CPUPercent,  // Now contains aggregated values
-				MemoryBytes: childInfo.MemoryBytes, // Now contains aggregated values
-				IsThread:    isThread,
+				PID:            childInfo.PID,
+				Name:           childInfo.Name,
+				CPUPercent:     childInfo.CPUPercent,  // Now contains aggregated values
+				MemoryBytes:    childInfo.MemoryBytes, // Now contains aggregated values
+				CPUSeconds:     childInfo.CPUSeconds,
+				AvgCPU:         childInfo.AvgCPUPercent,
+				MajorFaultRate: childInfo.MajorFaultRate,
+				IsThread:       isThread,
+				Blocked:        childInfo.IsBlocked(),
 			}
 			info.Children = append(info.Children, child)
 
 			// Aggregate resources (using the child's aggregated values)
 			totalCPU += childInfo.CPUPercent
 			totalMemory += childInfo.MemoryBytes
+			totalCPUSeconds += childInfo.CPUSeconds
+			totalAvgCPU += childInfo.AvgCPUPercent
+			totalFaultRate += childInfo.MajorFaultRate
 		}
 	}
 
@@ -196,6 +554,9 @@ func (m *Monitor) aggregateResources(pid int32, allProcesses map[int32]*ProcessI
 		info.CPUPercent = totalCPU
 		info.MemoryBytes = totalMemory
 		info.MemoryMB = float64(totalMemory) / (1024 * 1024)
+		info.CPUSeconds = totalCPUSeconds
+		info.AvgCPUPercent = totalAvgCPU
+		info.MajorFaultRate = totalFaultRate
 	} else {
 		// No related children - just set MemoryMB
 		info.MemoryMB = float64(info.MemoryBytes) / (1024 * 1024)
@@ -204,13 +565,19 @@ func (m *Monitor) aggregateResources(pid int32, allProcesses map[int32]*ProcessI
 	aggregated[pid] = true
 }
 
-func (m *Monitor) getProcessInfo(p *process.Process) (*ProcessInfo, error) {
-	pid := p.Pid
+func (m *Monitor) getProcessInfo(p procHandle) (*ProcessInfo, error) {
+	pid := p.PID()
 
 	name, err := p.Name()
 	if err != nil {
 		return nil, err
 	}
+	if isExcluded(name, m.config.GetExclude()) {
+		return nil, errExcluded
+	}
+	if scope, id := m.config.GetScope(); scope != "" && !inScope(pid, scope, id) {
+		return nil, errOutOfScope
+	}
 
 	ppid, err := p.Ppid()
 	if err != nil {
@@ -221,28 +588,128 @@ func (m *Monitor) getProcessInfo(p *process.Process) (*ProcessInfo, error) {
 	if err != nil {
 		cpuPercent = 0
 	}
+	cpuPercent = m.smoothCPU(pid, time.Now(), cpuPercent)
 
 	memInfo, err := p.MemoryInfo()
 	if err != nil {
 		return nil, err
 	}
 
-	info := &ProcessInfo{
-		PID:         pid,
-		PPID:        ppid,
-		Name:        name,
-		CPUPercent:  cpuPercent,
-		MemoryBytes: memInfo.RSS,
-		LastUpdate:  time.Now(),
-		Expanded:    false,
-		Children:    make([]ChildInfo, 0),
+	m.mu.Lock()
+	previous := m.processes[pid]
+	m.mu.Unlock()
+
+	// The exe and category don't change unless the process execs, which
+	// also changes its name, so reuse last refresh's instead of re-reading
+	known := previous != nil && previous.Name == name
+
+	// Exe is often unreadable for other users' processes; grouping falls
+	// back to the comm name in that case
+	exeName := ""
+	if known {
+		exeName = previous.ExeName
+	} else if exe, err := p.Exe(); err == nil && exe != "" {
+		exeName = filepath.Base(exe)
+	}
+
+	memoryBytes := memInfo.RSS
+	if m.config.GetMemoryMetric() == config.MemoryMetricPSS {
+		// PSS splits shared pages between sharers so family totals don't
+		// overcount; fall back to RSS where smaps isn't readable
+		if pss, err := readPSS(pid); err == nil {
+			memoryBytes = pss
+		}
 	}
 
+	state := ""
+	if status, err := p.Status(); err == nil && len(status) > 0 {
+		state = status[0]
+	}
+
+	var cpuSeconds, avgCPU float64
+	showAvgCPU := m.config.GetShowAvgCPU()
+	if m.config.GetShowCPUTime() || showAvgCPU {
+		if times, err := p.Times(); err == nil {
+			cpuSeconds = times.User + times.System
+		}
+	}
+	if showAvgCPU {
+		if created, err := p.CreateTime(); err == nil {
+			avgCPU = lifetimeAvgCPU(cpuSeconds, time.UnixMilli(created), time.Now())
+		}
+	}
+
+	var faultRate float64
+	if m.config.GetShowFaults() {
+		if faults, err := p.PageFaults(); err == nil {
+			faultRate = m.majorFaultRate(pid, faults.MajorFaults, time.Now())
+		}
+	}
+
+	tty := ""
+	if m.config.GetShowTTY() {
+		if tty, err = readTTY(pid); err != nil {
+			tty = "?"
+		}
+	}
+
+	// Match on the cheap name first and only read the cmdline if needed
+	rules := m.config.GetCategoryRules()
+	var category string
+	if known {
+		category = previous.Category
+	} else if category = matchCategory(name, rules); category == "" && len(rules) > 0 {
+		if cmdline, err := p.Cmdline(); err == nil {
+			category = matchCategory(cmdline, rules)
+		}
+	}
+
+	// A process can't change PID namespace, so reuse the last reading
+	var pidNamespace uint64
+	var namespaceUnread bool
+	var containerID, containerName string
+	if known {
+		pidNamespace, namespaceUnread = previous.PIDNamespace, previous.NamespaceUnread
+		containerID, containerName = previous.ContainerID, previous.ContainerName
+	} else {
+		var err error
+		if pidNamespace, err = readPIDNamespace(pid); err != nil && !errors.Is(err, errNoNamespaces) {
+			namespaceUnread = true
+		}
+		containerID, containerName = readContainer(pid)
+	}
+
+	info := &ProcessInfo{
+		PID:             pid,
+		PPID:            ppid,
+		Name:            name,
+		ExeName:         exeName,
+		Category:        category,
+		CPUPercent:      cpuPercent,
+		MemoryBytes:     memoryBytes,
+		State:           state,
+		TTY:             tty,
+		CPUSeconds:      cpuSeconds,
+		AvgCPUPercent:   avgCPU,
+		MajorFaultRate:  faultRate,
+		PIDNamespace:    pidNamespace,
+		NamespaceUnread: namespaceUnread,
+		ContainerID:     containerID,
+		ContainerName:   containerName,
+		LastUpdate:      time.Now(),
+		Expanded:        false,
+		Children:        make([]ChildInfo, 0),
+	}
+
+	m.mu.Lock()
 	if existing, exists := m.processes[pid]; exists {
 		info.Expanded = existing.Expanded
+	} else {
+		info.Expanded = m.expandNames[info.GroupName()]
 	}
-
 	m.processes[pid] = info
+	m.mu.Unlock()
+
 	return info, nil
 }
 
@@ -254,13 +721,15 @@ func (m *Monitor) isThread(child, parent *ProcessInfo) bool {
 	// 2. Low memory usage relative to parent (threads share memory)
 	// 3. Certain naming patterns
 
-	if child.Name == parent.Name {
+	childName, parentName := child.GroupName(), parent.GroupName()
+
+	if childName == parentName {
 		return true
 	}
 
 	// Check for common thread naming patterns
-	if len(child.Name) > len(parent.Name) &&
-		child.Name[:len(parent.Name)] == parent.Name {
+	if len(childName) > len(parentName) &&
+		childName[:len(parentName)] == parentName {
 		return true
 	}
 
@@ -273,34 +742,48 @@ func (m *Monitor) isThread(child, parent *ProcessInfo) bool {
 	return false
 }
 
+// systemParents never aggregate their children, even with --aggregate-all:
+// summing init's subtree would fold the whole system into one row
+var systemParents = map[string]bool{
+	"systemd": true,
+	"init":    true,
+	"launchd": true, // macOS init system
+}
+
+// isSystemParent reports whether p is an init system
+func isSystemParent(p *ProcessInfo) bool {
+	return systemParents[p.GroupName()]
+}
+
 // isRelatedToParent determines if a child process should be aggregated into its parent
 // Returns false for unrelated applications (e.g., systemd's children from different apps)
 func (m *Monitor) isRelatedToParent(child, parent *ProcessInfo) bool {
-	// System-level parent processes shouldn't aggregate unrelated children
-	systemParents := map[string]bool{
-		"systemd": true,
-		"init":    true,
-		"launchd": true, // macOS init system
+	if isSystemParent(parent) {
+		return false
 	}
 
-	if systemParents[parent.Name] {
+	childName, parentName := child.GroupName(), parent.GroupName()
+
+	// PIDs in different namespaces (containers) belong to unrelated
+	// workloads even when the names match, e.g. two containerized nginx
+	if !sameNamespace(child, parent) {
 		return false
 	}
 
 	// If same name or name prefix, they're related (same application)
-	if child.Name == parent.Name {
+	if childName == parentName {
 		return true
 	}
 
 	// Check if child name starts with parent name (e.g., "chrome" and "chrome_crashpad")
-	if len(child.Name) >= len(parent.Name) &&
-		child.Name[:len(parent.Name)] == parent.Name {
+	if len(childName) >= len(parentName) &&
+		childName[:len(parentName)] == parentName {
 		return true
 	}
 
 	// Check if parent name starts with child name (e.g., "code-" prefix variants)
-	if len(parent.Name) >= len(child.Name) &&
-		parent.Name[:len(child.Name)] == child.Name {
+	if len(parentName) >= len(childName) &&
+		parentName[:len(childName)] == childName {
 		return true
 	}
 
@@ -308,16 +791,48 @@ func (m *Monitor) isRelatedToParent(child, parent *ProcessInfo) bool {
 	return false
 }
 
+// sameNamespace reports whether two processes share a PID namespace. When
+// either namespace couldn't be read it compares their containers instead,
+// which the cgroup shows to any user; where namespaces aren't supported
+// (PIDNamespace 0), everything shares one.
+func sameNamespace(a, b *ProcessInfo) bool {
+	if a.NamespaceUnread || b.NamespaceUnread {
+		return a.ContainerID == b.ContainerID
+	}
+	return a.PIDNamespace == 0 || b.PIDNamespace == 0 || a.PIDNamespace == b.PIDNamespace
+}
+
+// ToggleExpanded expands or collapses a process's children. In the --tree
+// view it shows or hides the process's descendants from the next scan.
 func (m *Monitor) ToggleExpanded(pid int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.config.GetTreeView() {
+		m.collapsed[pid] = !m.collapsed[pid]
+		return
+	}
 	if info, exists := m.processes[pid]; exists {
 		info.Expanded = !info.Expanded
 	}
 }
 
+// Resource level boundaries used for color coding and the composite sort
+const (
+	mediumCPUPercent = 20
+	highCPUPercent   = 50
+	mediumMemoryMB   = 200
+	highMemoryMB     = 500
+)
+
 func (m *Monitor) GetResourceLevel(cpuPercent float64, memoryMB float64) ResourceLevel {
-	if cpuPercent >= 50 || memoryMB >= 500 {
+	return resourceLevel(cpuPercent, memoryMB)
+}
+
+// resourceLevel buckets a reading by the fixed color-coding boundaries
+func resourceLevel(cpuPercent float64, memoryMB float64) ResourceLevel {
+	if cpuPercent >= highCPUPercent || memoryMB >= highMemoryMB {
 		return High
-	} else if cpuPercent >= 20 || memoryMB >= 200 {
+	} else if cpuPercent >= mediumCPUPercent || memoryMB >= mediumMemoryMB {
 		return Medium
 	}
 	return Low
@@ -344,13 +859,43 @@ func (rl ResourceLevel) String() string {
 	}
 }
 
+// Describe explains when a process falls into the level, for the legend
+func (rl ResourceLevel) Describe() string {
+	switch rl {
+	case High:
+		return fmt.Sprintf("CPU ≥%d%% or memory ≥%dMB", highCPUPercent, highMemoryMB)
+	case Medium:
+		return fmt.Sprintf("CPU ≥%d%% or memory ≥%dMB", mediumCPUPercent, mediumMemoryMB)
+	default:
+		return fmt.Sprintf("CPU <%d%% and memory <%dMB", mediumCPUPercent, mediumMemoryMB)
+	}
+}
+
+// systemCPUPercent reads system-wide CPU use; replaced in tests
+var systemCPUPercent = cpu.Percent
+
 func (m *Monitor) GetSystemMetrics() (*SystemMetrics, error) {
 	metrics := &SystemMetrics{}
 
-	// Get CPU metrics
-	cpuPercentages, err := cpu.Percent(0, false)
+	// Each reading covers the time since the previous one. The first would
+	// cover only the moments since gopsutil loaded, too few clock ticks to
+	// mean much, so --cpu-blocking measures it over a set time instead,
+	// unless CPU use can't be read at all.
+	m.mu.Lock()
+	first := !m.cpuSampled
+	m.cpuSampled = true
+	m.mu.Unlock()
+	if blocking := m.config.GetCPUBlocking(); first && blocking > 0 {
+		if _, err := systemCPUPercent(0, false); err == nil {
+			time.Sleep(blocking)
+		}
+	}
+	cpuPercentages, err := systemCPUPercent(0, false)
 	if err == nil && len(cpuPercentages) > 0 {
-		metrics.CPUPercent = cpuPercentages[0]
+		window := m.config.GetCPUWindow()
+		m.mu.Lock()
+		metrics.CPUPercent = m.systemCPU.add(time.Now(), cpuPercentages[0], window)
+		m.mu.Unlock()
 	}
 
 	// Get CPU core count
@@ -367,7 +912,11 @@ func (m *Monitor) GetSystemMetrics() (*SystemMetrics, error) {
 		metrics.MemoryAvailable = vmem.Available
 		metrics.MemoryCached = vmem.Cached
 		metrics.MemoryBuffers = vmem.Buffers
-		metrics.MemoryPercent = vmem.UsedPercent
+		metrics.MemoryPercent = usedPercent(vmem.Used, vmem.Total)
+	}
+
+	if avg, err := load.Avg(); err == nil {
+		metrics.Load1, metrics.Load5, metrics.Load15 = avg.Load1, avg.Load5, avg.Load15
 	}
 
 	// Get swap metrics
@@ -375,7 +924,11 @@ func (m *Monitor) GetSystemMetrics() (*SystemMetrics, error) {
 	if err == nil {
 		metrics.SwapTotal = swap.Total
 		metrics.SwapUsed = swap.Used
-		metrics.SwapPercent = swap.UsedPercent
+		metrics.SwapPercent = usedPercent(swap.Used, swap.Total)
+	}
+
+	if pressure, err := readPressure(); err == nil {
+		metrics.Pressure = pressure
 	}
 
 	return metrics, nil
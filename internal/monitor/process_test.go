@@ -0,0 +1,267 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestIsRelatedToParent(t *testing.T) {
+	m := &Monitor{}
+
+	tests := []struct {
+		name     string
+		child    *ProcessInfo
+		parent   *ProcessInfo
+		expected bool
+	}{
+		{"Same name", &ProcessInfo{Name: "chrome"}, &ProcessInfo{Name: "chrome"}, true},
+		{"Child name prefixed by parent", &ProcessInfo{Name: "chrome_crashpad"}, &ProcessInfo{Name: "chrome"}, true},
+		{"Parent name prefixed by child", &ProcessInfo{Name: "code"}, &ProcessInfo{Name: "code-helper"}, true},
+		{"Unrelated names", &ProcessInfo{Name: "bash"}, &ProcessInfo{Name: "sshd"}, false},
+		{"System parent", &ProcessInfo{Name: "systemd"}, &ProcessInfo{Name: "systemd"}, false},
+		{
+			"Same name in another PID namespace",
+			&ProcessInfo{Name: "nginx", PIDNamespace: 4026532345},
+			&ProcessInfo{Name: "nginx", PIDNamespace: 4026531836},
+			false,
+		},
+		{"Namespace unknown for one side", &ProcessInfo{Name: "nginx", PIDNamespace: 4026532345}, &ProcessInfo{Name: "nginx"}, true},
+		{"Namespace unreadable, same container", &ProcessInfo{Name: "nginx", NamespaceUnread: true}, &ProcessInfo{Name: "nginx", PIDNamespace: 4026531836}, true},
+		{
+			"Namespace unreadable, in another container",
+			&ProcessInfo{Name: "nginx", NamespaceUnread: true, ContainerID: "3f2a"},
+			&ProcessInfo{Name: "nginx", PIDNamespace: 4026531836},
+			false,
+		},
+		{
+			"Renamed child grouped by exe",
+			&ProcessInfo{Name: "worker-3", ExeName: "postgres"},
+			&ProcessInfo{Name: "postgres", ExeName: "postgres"},
+			true,
+		},
+		{
+			"Renamed parent grouped by exe",
+			&ProcessInfo{Name: "node", ExeName: "node"},
+			&ProcessInfo{Name: "my-service", ExeName: "node"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := m.isRelatedToParent(tt.child, tt.parent)
+			if result != tt.expected {
+				t.Errorf("isRelatedToParent(%q, %q) = %v; expected %v",
+					tt.child.GroupName(), tt.parent.GroupName(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGroupName(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *ProcessInfo
+		expected string
+	}{
+		{"Exe name preferred", &ProcessInfo{Name: "renamed", ExeName: "java"}, "java"},
+		{"Falls back to comm", &ProcessInfo{Name: "kworker/0:1"}, "kworker/0:1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.info.GroupName(); result != tt.expected {
+				t.Errorf("GroupName() = %q; expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortProcessesTiebreaksByPID(t *testing.T) {
+	procs := []*ProcessInfo{
+		{PID: 30, CPUPercent: 0},
+		{PID: 10, CPUPercent: 0},
+		{PID: 50, CPUPercent: 12.5},
+		{PID: 20, CPUPercent: 0},
+		{PID: 40, CPUPercent: 12.5},
+	}
+
+	sortProcesses(procs, config.SortByCPU)
+
+	expected := []int32{40, 50, 10, 20, 30}
+	for i, pid := range expected {
+		if procs[i].PID != pid {
+			t.Errorf("Position %d: expected PID %d, got %d", i, pid, procs[i].PID)
+		}
+	}
+}
+
+func TestSortProcessesModes(t *testing.T) {
+	const mb = 1024 * 1024
+	newProcs := func() []*ProcessInfo {
+		return []*ProcessInfo{
+			{PID: 1, CPUPercent: 60, MemoryBytes: 100 * mb}, // CPU burner
+			{PID: 2, CPUPercent: 0, MemoryBytes: 2048 * mb}, // Idle memory hog
+			{PID: 3, CPUPercent: 10, MemoryBytes: 50 * mb},  // Modest
+		}
+	}
+
+	tests := []struct {
+		mode     config.SortMode
+		expected []int32
+	}{
+		{config.SortByCPU, []int32{1, 3, 2}},
+		{config.SortByMemory, []int32{2, 1, 3}},
+		{config.SortByComposite, []int32{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			procs := newProcs()
+			sortProcesses(procs, tt.mode)
+			for i, pid := range tt.expected {
+				if procs[i].PID != pid {
+					t.Errorf("Position %d: expected PID %d, got %d", i, pid, procs[i].PID)
+				}
+			}
+		})
+	}
+}
+
+func TestSortChildren(t *testing.T) {
+	const mb = 1024 * 1024
+	children := []ChildInfo{
+		{PID: 30, CPUPercent: 40, MemoryBytes: 10 * mb},
+		{PID: 10, CPUPercent: 1, MemoryBytes: 900 * mb},
+		{PID: 20, CPUPercent: 5, MemoryBytes: 50 * mb},
+	}
+
+	tests := []struct {
+		name      string
+		childSort config.ChildSort
+		mode      config.SortMode
+		expected  []int32
+	}{
+		{"follow cpu", config.ChildSortFollow, config.SortByCPU, []int32{30, 20, 10}},
+		{"follow mem", config.ChildSortFollow, config.SortByMemory, []int32{10, 20, 30}},
+		{"mem despite cpu main sort", config.ChildSortMemory, config.SortByCPU, []int32{10, 20, 30}},
+		{"cpu despite mem main sort", config.ChildSortCPU, config.SortByMemory, []int32{30, 20, 10}},
+		{"pid", config.ChildSortPID, config.SortByCPU, []int32{10, 20, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := SortChildren(children, tt.childSort, tt.mode)
+			for i, pid := range tt.expected {
+				if sorted[i].PID != pid {
+					t.Errorf("Position %d: expected PID %d, got %d", i, pid, sorted[i].PID)
+				}
+			}
+		})
+	}
+
+	if children[0].PID != 30 {
+		t.Error("SortChildren reordered its input")
+	}
+}
+
+func TestLifetimeAvgCPU(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		cpuSeconds float64
+		started    time.Time
+		expected   float64
+	}{
+		{"Quarter of a core", 900, now.Add(-time.Hour), 25},
+		{"Two cores flat out", 7200, now.Add(-time.Hour), 200},
+		{"Idle", 0, now.Add(-time.Hour), 0},
+		{"Clock skew", 5, now.Add(time.Second), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lifetimeAvgCPU(tt.cpuSeconds, tt.started, now); got != tt.expected {
+				t.Errorf("lifetimeAvgCPU = %v; expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSumThreads(t *testing.T) {
+	cfg := config.New()
+	m := New(cfg)
+	handles := []procHandle{
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "java", cpu: 10, rss: 500 << 20},
+		&fakeProc{pid: 11, ppid: 10, name: "GC Thread#0", cpu: 40, rss: 1 << 20},
+		&fakeProc{pid: 12, ppid: 10, name: "bash", cpu: 2, rss: 200 << 20},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	java := func() *ProcessInfo {
+		procs, err := m.GetFilteredProcesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, proc := range procs {
+			if proc.PID == 10 {
+				return proc
+			}
+		}
+		t.Fatal("java not listed")
+		return nil
+	}
+
+	if parent := java(); parent.CPUPercent != 10 || len(parent.Children) != 0 {
+		t.Errorf("java summed its differently-named thread by default: %+v", parent)
+	}
+
+	// The thread is summed whatever its name; bash, too big to be taken
+	// for a thread, still isn't
+	cfg.SetSumThreads(true)
+	parent := java()
+	if parent.CPUPercent != 50 || len(parent.Children) != 1 || !parent.Children[0].IsThread {
+		t.Fatalf("expected java to sum its GC thread to 50%%, got %.0f%% with %+v", parent.CPUPercent, parent.Children)
+	}
+}
+
+func TestAggregateAllChildren(t *testing.T) {
+	cfg := config.New()
+	m := New(cfg)
+	handles := []procHandle{
+		&fakeProc{pid: 1, name: "init"},
+		&fakeProc{pid: 10, ppid: 1, name: "make", cpu: 1},
+		&fakeProc{pid: 11, ppid: 10, name: "cc1", cpu: 90},
+		&fakeProc{pid: 12, ppid: 10, name: "ld", cpu: 30},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	family := func() *ProcessInfo {
+		procs, err := m.GetFilteredProcesses()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, proc := range procs {
+			if proc.PID == 10 {
+				return proc
+			}
+		}
+		return nil
+	}
+
+	if parent := family(); parent != nil {
+		t.Errorf("make aggregated its differently-named children by default: %+v", parent)
+	}
+
+	cfg.SetAggregateAllChildren(true)
+	parent := family()
+	if parent == nil || parent.CPUPercent != 121 || len(parent.Children) != 2 {
+		t.Fatalf("expected make to sum cc1 and ld to 121%%, got %+v", parent)
+	}
+	if init := m.buf.all[1]; len(init.Children) != 0 {
+		t.Errorf("init aggregated its children with --aggregate-all: %+v", init.Children)
+	}
+}
@@ -0,0 +1,41 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// readStatFields returns the fields of /proc/PID/stat that follow the
+// command name. Field N as numbered in proc(5) is at index N-3, so the state
+// (field 3) is at index 0.
+func readStatFields(pid int32) ([]string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return nil, err
+	}
+	return parseStatFields(data)
+}
+
+// parseStatFields splits /proc/PID/stat content after the parenthesized
+// command name, which may itself contain spaces and parentheses
+func parseStatFields(data []byte) ([]string, error) {
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return nil, fmt.Errorf("malformed stat: no command name")
+	}
+	fields := bytes.Fields(data[end+1:])
+	result := make([]string, len(fields))
+	for i, field := range fields {
+		result[i] = string(field)
+	}
+	return result, nil
+}
+
+// statField returns field n (numbered as in proc(5)) from parsed stat fields
+func statField(fields []string, n int) (string, error) {
+	if n < 3 || n-3 >= len(fields) {
+		return "", fmt.Errorf("stat field %d missing", n)
+	}
+	return fields[n-3], nil
+}
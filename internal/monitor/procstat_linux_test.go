@@ -0,0 +1,52 @@
+package monitor
+
+import "testing"
+
+func TestParseStatFields(t *testing.T) {
+	data := []byte("4242 (tmux: server (1)) S 1 4242 4242 34819 4242 4194560 1\n")
+
+	fields, err := parseStatFields(data)
+	if err != nil {
+		t.Fatalf("parseStatFields() error = %v", err)
+	}
+
+	tests := []struct {
+		field    int
+		expected string
+	}{
+		{3, "S"},
+		{4, "1"},
+		{statTTYNr, "34819"},
+	}
+	for _, tt := range tests {
+		if result, err := statField(fields, tt.field); err != nil || result != tt.expected {
+			t.Errorf("statField(%d) = %q, %v; expected %q", tt.field, result, err, tt.expected)
+		}
+	}
+
+	if _, err := statField(fields, 52); err == nil {
+		t.Error("Expected error for missing field")
+	}
+	if _, err := parseStatFields([]byte("garbage")); err == nil {
+		t.Error("Expected error for stat without a command name")
+	}
+}
+
+func TestTTYName(t *testing.T) {
+	tests := []struct {
+		nr       uint64
+		expected string
+	}{
+		{0, "?"},
+		{34819, "pts/3"},           // major 136, minor 3
+		{1025, "tty1"},             // major 4, minor 1
+		{1088, "ttyS0"},            // major 4, minor 64
+		{34816 + 1<<20, "pts/256"}, // minor 256 spills into the high bits
+	}
+
+	for _, tt := range tests {
+		if result := ttyName(tt.nr); result != tt.expected {
+			t.Errorf("ttyName(%d) = %q; expected %q", tt.nr, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// readPSS returns the proportional set size of a process in bytes, read from
+// /proc/PID/smaps_rollup
+func readPSS(pid int32) (uint64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/smaps_rollup", pid))
+	if err != nil {
+		return 0, err
+	}
+	return parsePSS(data)
+}
+
+// parsePSS extracts the "Pss:" line (in kB) from smaps_rollup content
+func parsePSS(data []byte) (uint64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) < 2 || string(fields[0]) != "Pss:" {
+			continue
+		}
+		kb, err := strconv.ParseUint(string(fields[1]), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid Pss value %q: %w", fields[1], err)
+		}
+		return kb * 1024, nil
+	}
+	return 0, fmt.Errorf("no Pss field found")
+}
@@ -0,0 +1,35 @@
+package monitor
+
+import "testing"
+
+func TestParsePSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected uint64
+		wantErr  bool
+	}{
+		{
+			name: "Typical smaps_rollup",
+			data: "55a4c7d2e000-7ffd2b1fe000 ---p 00000000 00:00 0 [rollup]\n" +
+				"Rss:               12345 kB\n" +
+				"Pss:                2048 kB\n" +
+				"Pss_Anon:           1024 kB\n",
+			expected: 2048 * 1024,
+		},
+		{"Missing Pss", "Rss:               12345 kB\n", 0, true},
+		{"Malformed Pss", "Pss:                abc kB\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parsePSS([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePSS() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parsePSS() = %d; expected %d", result, tt.expected)
+			}
+		})
+	}
+}
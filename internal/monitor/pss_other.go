@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readPSS is only supported on Linux; callers fall back to RSS
+func readPSS(_ int32) (uint64, error) {
+	return 0, errors.New("PSS is not supported on this platform")
+}
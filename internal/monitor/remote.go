@@ -0,0 +1,270 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// remoteRetryDelay is how long Remote waits before reconnecting after the
+// stream drops
+const remoteRetryDelay = 5 * time.Second
+
+// maxSnapshotLine bounds one streamed snapshot; a busy host with every
+// child listed stays well under this
+const maxSnapshotLine = 16 * 1024 * 1024
+
+// ErrRemoteUnsupported is returned for actions that need the process on this
+// machine, such as signals and the detail pane
+var ErrRemoteUnsupported = errors.New("not available for remote hosts")
+
+// Remote feeds the display from brieftop running on another host. It runs
+// `brieftop --json-stream` there over ssh and keeps the latest snapshot;
+// when the stream drops it reports why and reconnects after
+// remoteRetryDelay. Thresholds and the refresh rate are passed to the remote
+// side when connecting, so changes take effect on the next reconnect.
+type Remote struct {
+	target  string
+	config  ConfigInterface
+	command func() *exec.Cmd // Builds the stream command; replaced in tests
+
+	mu       sync.Mutex // Guards latest, err, expanded and cmd
+	latest   *Snapshot
+	err      error // Why the stream is down; nil while connected
+	expanded map[int32]bool
+	cmd      *exec.Cmd
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRemote prepares a Remote for target, anything ssh accepts such as
+// user@host or a Host alias; Start connects
+func NewRemote(target string, config ConfigInterface) *Remote {
+	r := &Remote{
+		target:   target,
+		config:   config,
+		err:      fmt.Errorf("connecting to %s…", target),
+		expanded: make(map[int32]bool),
+		stop:     make(chan struct{}),
+	}
+	r.command = r.sshCommand
+	return r
+}
+
+// sshCommand runs brieftop on the target with this side's filters, the
+// memory threshold in bytes so sub-MB sizes survive. BatchMode
+// fails fast instead of prompting for a password under the TUI, and the
+// keepalives notice a dead link within about ten seconds. The "--" keeps a
+// target starting with "-" from being read as an ssh option.
+func (r *Remote) sshCommand() *exec.Cmd {
+	remote := fmt.Sprintf("brieftop --json-stream --refresh %s --cpu %g --memory %dB --mem-metric %s",
+		r.config.GetRefreshRate(), r.config.GetCPUThreshold(), r.config.GetMemoryThreshold(), r.config.GetMemoryMetric())
+	return exec.Command("ssh", "-T",
+		"-o", "BatchMode=yes",
+		"-o", "ServerAliveInterval=5",
+		"-o", "ServerAliveCountMax=2",
+		"--", r.target, remote)
+}
+
+// Start connects in the background and keeps reconnecting until Close
+func (r *Remote) Start() {
+	go func() {
+		for {
+			err := r.stream()
+			r.mu.Lock()
+			r.err = fmt.Errorf("disconnected from %s: %v; retrying in %v", r.target, err, remoteRetryDelay)
+			r.mu.Unlock()
+
+			select {
+			case <-r.stop:
+				return
+			case <-time.After(remoteRetryDelay):
+			}
+			r.mu.Lock()
+			r.err = fmt.Errorf("reconnecting to %s…", r.target)
+			r.mu.Unlock()
+		}
+	}()
+}
+
+// Close stops the stream and any reconnect
+func (r *Remote) Close() error {
+	var err error
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.cmd != nil && r.cmd.Process != nil {
+			err = killSSH(r.cmd.Process)
+		}
+	})
+	return err
+}
+
+// killSSH stops an ssh process; one that has already exited is fine
+func killSSH(p *os.Process) error {
+	if err := p.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("stopping ssh: %w", err)
+	}
+	return nil
+}
+
+// stream runs one connection, storing each snapshot as it arrives, and
+// returns why it ended
+func (r *Remote) stream() error {
+	cmd := r.command()
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.cmd = cmd
+	r.mu.Unlock()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxSnapshotLine)
+	var decodeErr error
+	for scanner.Scan() {
+		var snapshot Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			decodeErr = fmt.Errorf("unexpected output (is brieftop installed there?): %w", err)
+			if killErr := killSSH(cmd.Process); killErr != nil {
+				decodeErr = fmt.Errorf("%w; %v", decodeErr, killErr)
+			}
+			break
+		}
+		r.mu.Lock()
+		r.latest = &snapshot
+		r.err = nil
+		r.mu.Unlock()
+	}
+	waitErr := cmd.Wait()
+
+	switch {
+	case decodeErr != nil:
+		return decodeErr
+	case scanner.Err() != nil:
+		return scanner.Err()
+	}
+	// ssh explains itself on stderr, e.g. "Connection refused"
+	if lines := strings.Split(strings.TrimSpace(stderr.String()), "\n"); lines[len(lines)-1] != "" {
+		return errors.New(lines[len(lines)-1])
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return errors.New("stream ended")
+}
+
+// GetFilteredProcesses returns the latest remote snapshot's processes with
+// this side's expansion state. While disconnected it returns why, so the
+// display keeps the last data and shows the reason.
+func (r *Remote) GetFilteredProcesses() ([]*ProcessInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// Copies, so toggling expansion never touches rows the display holds
+	processes := make([]*ProcessInfo, len(r.latest.Processes))
+	for i, proc := range r.latest.Processes {
+		info := *proc
+		info.MemoryMB = float64(info.MemoryBytes) / (1024 * 1024)
+		info.Expanded = r.expanded[info.PID]
+		info.LastUpdate = r.latest.Timestamp
+		processes[i] = &info
+	}
+	return processes, nil
+}
+
+// GetSystemMetrics returns the latest remote system metrics
+func (r *Remote) GetSystemMetrics() (*SystemMetrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latest == nil || r.latest.System == nil {
+		return nil, errors.New("no system metrics yet")
+	}
+	return r.latest.System, nil
+}
+
+// IsPrimed reports whether a snapshot has arrived; the remote side primes
+// its own CPU readings before streaming
+func (r *Remote) IsPrimed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.latest != nil
+}
+
+// LastTaskCounts returns the latest remote task counts, if it sent any
+func (r *Remote) LastTaskCounts() TaskCounts {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latest == nil || r.latest.Tasks == nil {
+		return TaskCounts{}
+	}
+	return *r.latest.Tasks
+}
+
+// LastScanTimings is always zero: the scan happens on the remote host
+func (r *Remote) LastScanTimings() ScanTimings { return ScanTimings{} }
+
+// StuckProcesses is always empty; blocked rows still show their [D] marker
+func (r *Remote) StuckProcesses() []*ProcessInfo { return nil }
+
+// LastAlerts is always empty; run --alert-command on the remote host instead
+func (r *Remote) LastAlerts() []Alert { return nil }
+
+// LastMissingCritical is always empty; --critical only watches this machine
+func (r *Remote) LastMissingCritical() []string { return nil }
+
+// GetResourceLevel buckets a reading like Monitor does
+func (r *Remote) GetResourceLevel(cpuPercent float64, memoryMB float64) ResourceLevel {
+	return resourceLevel(cpuPercent, memoryMB)
+}
+
+// ToggleExpanded expands or collapses a process on this side only
+func (r *Remote) ToggleExpanded(pid int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expanded[pid] = !r.expanded[pid]
+}
+
+// GetProcessDetail isn't streamed, so the detail pane says so
+func (r *Remote) GetProcessDetail(pid int32) (*ProcessDetail, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// ExpandedNames is unsupported: layouts are saved from local processes
+func (r *Remote) ExpandedNames() ([]string, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// ExpandNames is unsupported; see ExpandedNames
+func (r *Remote) ExpandNames(names []string) error {
+	return ErrRemoteUnsupported
+}
+
+// Environ isn't streamed either
+func (r *Remote) Environ(pid int32) ([]string, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// SendSignal refuses rather than signaling a local process with the same PID
+func (r *Remote) SendSignal(pid int32, name string, sig syscall.Signal) error {
+	return ErrRemoteUnsupported
+}
@@ -0,0 +1,109 @@
+package monitor
+
+import (
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// TestRemoteStream feeds Remote a canned stream and checks it serves the
+// latest snapshot, keeps expansion on this side and reports the disconnect
+func TestRemoteStream(t *testing.T) {
+	stream := `{"timestamp":"2024-01-01T00:00:00Z","tasks":{"total":300},"processes":[{"pid":7,"name":"old","cpu_percent":1,"memory_bytes":0}]}
+{"timestamp":"2024-01-01T00:00:01Z","system":{"cpu_cores":4},"tasks":{"total":301},"processes":[{"pid":9,"name":"web","cpu_percent":50,"memory_bytes":104857600}]}
+`
+	r := NewRemote("example", config.New())
+	r.command = func() *exec.Cmd {
+		cmd := exec.Command("sh", "-c", "cat; echo 'Connection closed by remote host' >&2")
+		cmd.Stdin = strings.NewReader(stream)
+		return cmd
+	}
+	if _, err := r.GetFilteredProcesses(); err == nil || !strings.Contains(err.Error(), "connecting") {
+		t.Fatalf("expected a connecting error before the first snapshot, got %v", err)
+	}
+
+	err := r.stream()
+	if err == nil || err.Error() != "Connection closed by remote host" {
+		t.Errorf("stream ended with %v; expected ssh's last stderr line", err)
+	}
+
+	processes, err := r.GetFilteredProcesses()
+	if err != nil || len(processes) != 1 || processes[0].Name != "web" || processes[0].MemoryMB != 100 {
+		t.Fatalf("expected the latest snapshot's web process, got %+v, %v", processes, err)
+	}
+	if total := r.LastTaskCounts().Total; total != 301 {
+		t.Errorf("task total = %d; expected 301", total)
+	}
+
+	r.ToggleExpanded(9)
+	if processes, _ := r.GetFilteredProcesses(); !processes[0].Expanded {
+		t.Error("expansion toggled locally wasn't applied")
+	}
+	if err := r.SendSignal(9, "sshd", 15); err != ErrRemoteUnsupported {
+		t.Errorf("SendSignal = %v; expected ErrRemoteUnsupported", err)
+	}
+}
+
+// TestRemoteReportsDisconnect checks the display gets the reason the stream
+// dropped instead of silently stale data
+func TestRemoteReportsDisconnect(t *testing.T) {
+	r := NewRemote("example", config.New())
+	r.command = func() *exec.Cmd {
+		return exec.Command("sh", "-c", "echo 'ssh: connect to host example port 22: Connection refused' >&2; exit 255")
+	}
+	r.Start()
+	defer func() {
+		if err := r.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, err := r.GetFilteredProcesses()
+		if err != nil && strings.Contains(err.Error(), "disconnected from example: ssh: connect to host example port 22: Connection refused") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a disconnect error, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSSHCommandEndsOptions checks the target can't be taken for an ssh
+// option such as -oProxyCommand
+func TestSSHCommandEndsOptions(t *testing.T) {
+	args := NewRemote("-oProxyCommand=touch /tmp/pwned", config.New()).sshCommand().Args
+	i := slices.Index(args, "--")
+	if i < 0 || i+1 >= len(args) || args[i+1] != "-oProxyCommand=touch /tmp/pwned" {
+		t.Errorf("ssh args = %q; expected the target right after \"--\"", args)
+	}
+}
+
+// TestSSHCommandForwardsMemoryThreshold checks a sub-MB --memory reaches
+// the remote intact rather than truncated to 0 MB
+func TestSSHCommandForwardsMemoryThreshold(t *testing.T) {
+	cfg := config.New()
+	cfg.SetMemoryThreshold(512 * 1024)
+	args := NewRemote("example", cfg).sshCommand().Args
+	if remote := args[len(args)-1]; !strings.Contains(remote, "--memory 524288B ") {
+		t.Errorf("remote command = %q; expected --memory 524288B", remote)
+	}
+}
+
+// TestKillSSHExited checks stopping an ssh that has already exited isn't
+// reported as a failure
+func TestKillSSHExited(t *testing.T) {
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("true: %v", err)
+	}
+	if err := killSSH(cmd.Process); err != nil {
+		t.Errorf("killSSH of an exited process = %v, want nil", err)
+	}
+}
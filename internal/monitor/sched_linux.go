@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The /proc/PID/stat fields holding the real-time priority and the
+// scheduling policy
+const (
+	statRTPriority = 40
+	statPolicy     = 41
+)
+
+// schedPolicies names the SCHED_* constants from sched.h; 4 is unused
+var schedPolicies = map[int]string{
+	0: "SCHED_OTHER",
+	1: "SCHED_FIFO",
+	2: "SCHED_RR",
+	3: "SCHED_BATCH",
+	5: "SCHED_IDLE",
+	6: "SCHED_DEADLINE",
+}
+
+// readScheduling describes a process's scheduling policy, read from
+// /proc/PID/stat
+func readScheduling(pid int32) (string, error) {
+	fields, err := readStatFields(pid)
+	if err != nil {
+		return "", err
+	}
+	return parseScheduling(fields)
+}
+
+// parseScheduling describes the policy in parsed stat fields, e.g.
+// "SCHED_FIFO, RT priority 50". The priority is only shown for the
+// real-time policies, since it's always 0 for the rest.
+func parseScheduling(fields []string) (string, error) {
+	field, err := statField(fields, statPolicy)
+	if err != nil {
+		return "", err
+	}
+	policy, err := strconv.Atoi(field)
+	if err != nil {
+		return "", err
+	}
+	name, ok := schedPolicies[policy]
+	if !ok {
+		name = fmt.Sprintf("policy %d", policy)
+	}
+	if policy != 1 && policy != 2 {
+		return name, nil
+	}
+	priority, err := statField(fields, statRTPriority)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s, RT priority %s", name, priority), nil
+}
@@ -0,0 +1,51 @@
+package monitor
+
+import (
+	"os"
+	"testing"
+)
+
+// schedStat builds parsed stat fields up to the policy
+func schedStat(rtPriority, policy string) []string {
+	fields := make([]string, statPolicy-2)
+	for i := range fields {
+		fields[i] = "0"
+	}
+	fields[statRTPriority-3] = rtPriority
+	fields[statPolicy-3] = policy
+	return fields
+}
+
+func TestParseScheduling(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		expected string
+		wantErr  bool
+	}{
+		{"Normal", schedStat("0", "0"), "SCHED_OTHER", false},
+		{"FIFO", schedStat("50", "1"), "SCHED_FIFO, RT priority 50", false},
+		{"Round robin", schedStat("99", "2"), "SCHED_RR, RT priority 99", false},
+		{"Idle", schedStat("0", "5"), "SCHED_IDLE", false},
+		{"Unknown", schedStat("0", "9"), "policy 9", false},
+		{"Truncated", []string{"S", "1"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseScheduling(tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseScheduling() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("parseScheduling() = %q; expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadScheduling(t *testing.T) {
+	if _, err := readScheduling(int32(os.Getpid())); err != nil {
+		t.Fatalf("readScheduling(self) error = %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readScheduling is only supported on Linux; the detail pane says so
+func readScheduling(_ int32) (string, error) {
+	return "", errors.New("scheduling policy is not supported on this platform")
+}
@@ -0,0 +1,23 @@
+package monitor
+
+import (
+	"errors"
+	"os"
+)
+
+// errOutOfScope reports a process skipped because it isn't in the --scope
+// session or process group
+var errOutOfScope = errors.New("process out of scope")
+
+// ShellScopeID returns the session or process group ID (per scope) of the
+// shell brieftop was started from, its parent
+func ShellScopeID(scope string) (int32, error) {
+	return readScopeID(int32(os.Getppid()), scope)
+}
+
+// inScope reports whether a process belongs to the session or process
+// group being watched; unreadable ones don't
+func inScope(pid int32, scope string, id int32) bool {
+	got, err := readScopeID(pid, scope)
+	return err == nil && got == id
+}
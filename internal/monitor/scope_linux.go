@@ -0,0 +1,36 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// Process group and session IDs in /proc/PID/stat
+const (
+	statPGrp    = 5
+	statSession = 6
+)
+
+// readScopeID returns a process's session ID, or its process group ID,
+// depending on scope
+func readScopeID(pid int32, scope string) (int32, error) {
+	fields, err := readStatFields(pid)
+	if err != nil {
+		return 0, err
+	}
+	n := statPGrp
+	if scope == config.ScopeSession {
+		n = statSession
+	}
+	field, err := statField(fields, n)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(field, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stat field %d %q: %w", n, field, err)
+	}
+	return int32(id), nil
+}
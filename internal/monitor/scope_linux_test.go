@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestReadScopeID(t *testing.T) {
+	pid := int32(os.Getpid())
+	if pgrp, err := readScopeID(pid, config.ScopePGroup); err != nil || pgrp != int32(syscall.Getpgrp()) {
+		t.Errorf("readScopeID(pgroup) = %d, %v; expected %d", pgrp, err, syscall.Getpgrp())
+	}
+	if sid, err := readScopeID(pid, config.ScopeSession); err != nil || sid <= 0 {
+		t.Errorf("readScopeID(session) = %d, %v; expected a session ID", sid, err)
+	}
+	if _, err := readScopeID(1<<30, config.ScopeSession); err == nil {
+		t.Error("Expected an error for a PID that doesn't exist")
+	}
+}
+
+func TestScopeFiltersScan(t *testing.T) {
+	cfg := config.New()
+	cfg.SetCPUThreshold(0)
+	cfg.SetScope(config.ScopePGroup, int32(syscall.Getpgrp()))
+	m := New(cfg)
+	self := int32(os.Getpid())
+	handles := []procHandle{
+		&fakeProc{pid: self, name: "brieftop.test"},
+		&fakeProc{pid: 1 << 30, name: "elsewhere"},
+	}
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	procs, err := m.GetFilteredProcesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || procs[0].PID != self {
+		t.Errorf("listed %v; expected only this test's process group", procs)
+	}
+}
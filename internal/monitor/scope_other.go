@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readScopeID is only supported on Linux; --scope is rejected elsewhere
+func readScopeID(_ int32, _ string) (int32, error) {
+	return 0, errors.New("--scope is only supported on Linux")
+}
@@ -0,0 +1,37 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// readSecurityContext returns a process's LSM label: its SELinux context
+// (e.g. "system_u:system_r:sshd_t:s0") or AppArmor profile (e.g.
+// "firefox (enforce)"). The major LSM's label is in attr/current; newer
+// kernels also expose AppArmor's under attr/apparmor when another LSM owns
+// attr/current. Without an LSM the reads fail with EINVAL or come back
+// empty, which is no label rather than an error.
+func readSecurityContext(pid int32) (string, error) {
+	var firstErr error
+	for _, attr := range []string{"attr/current", "attr/apparmor/current"} {
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/%s", pid, attr))
+		if err != nil {
+			if firstErr == nil && !errors.Is(err, syscall.EINVAL) {
+				firstErr = err
+			}
+			continue
+		}
+		if label := parseSecurityContext(data); label != "" {
+			return label, nil
+		}
+	}
+	return "", firstErr
+}
+
+// parseSecurityContext trims the NUL and newline the kernel may append
+func parseSecurityContext(data []byte) string {
+	return strings.TrimRight(string(data), "\x00\n")
+}
@@ -0,0 +1,21 @@
+package monitor
+
+import "testing"
+
+func TestParseSecurityContext(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{"system_u:system_r:sshd_t:s0-s0:c0.c1023\x00", "system_u:system_r:sshd_t:s0-s0:c0.c1023"},
+		{"firefox (enforce)\n", "firefox (enforce)"},
+		{"unconfined\n", "unconfined"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if result := parseSecurityContext([]byte(tt.data)); result != tt.expected {
+			t.Errorf("parseSecurityContext(%q) = %q; expected %q", tt.data, result, tt.expected)
+		}
+	}
+}
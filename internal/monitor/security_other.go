@@ -0,0 +1,9 @@
+//go:build !linux
+
+package monitor
+
+// readSecurityContext is only supported on Linux; other platforms report
+// no label
+func readSecurityContext(_ int32) (string, error) {
+	return "", nil
+}
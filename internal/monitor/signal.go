@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ErrProcessGone is returned by SendSignal when the target exited, or its
+// PID now belongs to a different process, since it was listed
+var ErrProcessGone = errors.New("process no longer exists")
+
+// portableSignals are the signals every supported platform defines;
+// platformSignals adds job control and the user signals where they exist
+var portableSignals = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+}
+
+// ParseSignal converts a signal name such as "HUP", "SIGHUP" or "hup" to
+// a signal
+func ParseSignal(name string) (syscall.Signal, error) {
+	key := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SIG")
+	if sig, ok := portableSignals[key]; ok {
+		return sig, nil
+	}
+	if sig, ok := platformSignals[key]; ok {
+		return sig, nil
+	}
+	return 0, fmt.Errorf("unknown signal %q (expected one of %s)", name, strings.Join(SignalNames(), ", "))
+}
+
+// SignalNames lists the names ParseSignal accepts on this platform, without
+// the SIG prefix
+func SignalNames() []string {
+	names := make([]string, 0, len(portableSignals)+len(platformSignals))
+	for name := range portableSignals {
+		names = append(names, name)
+	}
+	for name := range platformSignals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SendSignal sends sig to the process with the given PID, provided it's
+// still named name. A process listed a while ago may have exited since and
+// its PID been reused, so the name is checked right before sending.
+func (m *Monitor) SendSignal(pid int32, name string, sig syscall.Signal) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return ErrProcessGone
+	}
+	if current, err := p.Name(); err != nil || current != name {
+		return ErrProcessGone
+	}
+	return p.SendSignal(sig)
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package monitor
+
+import "syscall"
+
+// platformSignals is empty where there is no job control or user signals
+var platformSignals = map[string]syscall.Signal{}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func TestSendSignalChecksTarget(t *testing.T) {
+	m := &Monitor{}
+	self := int32(os.Getpid())
+
+	// Signal 0 only checks the process exists, so a wrong check is harmless
+	if err := m.SendSignal(self, "not-brieftop", 0); !errors.Is(err, ErrProcessGone) {
+		t.Errorf("SendSignal with a different name = %v; expected ErrProcessGone", err)
+	}
+	p, err := process.NewProcess(self)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := p.Name()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SendSignal(self, current, 0); err != nil {
+		t.Errorf("SendSignal to itself = %v; expected nil", err)
+	}
+}
+
+func TestParseSignal(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected syscall.Signal
+		wantErr  bool
+	}{
+		{"TERM", syscall.SIGTERM, false},
+		{"SIGHUP", syscall.SIGHUP, false},
+		{"kill", syscall.SIGKILL, false},
+		{" int ", syscall.SIGINT, false},
+		{"RELOAD", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseSignal(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSignal(%q) error = %v; wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if result != tt.expected {
+			t.Errorf("ParseSignal(%q) = %v; expected %v", tt.name, result, tt.expected)
+		}
+	}
+
+	for _, name := range SignalNames() {
+		if _, err := ParseSignal(name); err != nil {
+			t.Errorf("SignalNames() lists %q but ParseSignal rejects it: %v", name, err)
+		}
+	}
+}
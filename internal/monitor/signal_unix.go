@@ -0,0 +1,12 @@
+//go:build unix
+
+package monitor
+
+import "syscall"
+
+var platformSignals = map[string]syscall.Signal{
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"STOP": syscall.SIGSTOP,
+	"CONT": syscall.SIGCONT,
+}
@@ -0,0 +1,145 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Snapshot is a point-in-time capture written by --json and read by --compare
+type Snapshot struct {
+	Timestamp time.Time      `json:"timestamp"`
+	System    *SystemMetrics `json:"system,omitempty"`
+	Tasks     *TaskCounts    `json:"tasks,omitempty"` // Every scanned process by state; absent in older snapshots
+	Processes []*ProcessInfo `json:"processes"`
+}
+
+// TakeSnapshot samples the filtered processes and system metrics once
+func (m *Monitor) TakeSnapshot() (*Snapshot, error) {
+	processes, err := m.GetFilteredProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := m.GetSystemMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := m.LastTaskCounts()
+	return &Snapshot{
+		Timestamp: time.Now(),
+		System:    metrics,
+		Tasks:     &tasks,
+		Processes: processes,
+	}, nil
+}
+
+// WriteJSON writes the snapshot as indented JSON
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// LoadSnapshot reads a snapshot previously written with --json
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// Delta statuses reported by CompareSnapshots
+const (
+	DeltaChanged = "changed"
+	DeltaNew     = "new"
+	DeltaGone    = "gone"
+)
+
+// ProcessDelta describes how one process differs between two snapshots
+type ProcessDelta struct {
+	PID          int32   `json:"pid"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	CPUBefore    float64 `json:"cpu_before"`
+	CPUAfter     float64 `json:"cpu_after"`
+	CPUDelta     float64 `json:"cpu_delta"`
+	MemoryBefore uint64  `json:"memory_before"`
+	MemoryAfter  uint64  `json:"memory_after"`
+	MemoryDelta  int64   `json:"memory_delta"`
+}
+
+// CompareSnapshots matches processes by PID and name (a reused PID with a
+// different name counts as one process gone and another new) and returns the
+// deltas ordered by the size of the CPU change, largest first
+func CompareSnapshots(before, after *Snapshot) []ProcessDelta {
+	type key struct {
+		pid  int32
+		name string
+	}
+
+	beforeByKey := make(map[key]*ProcessInfo, len(before.Processes))
+	for _, proc := range before.Processes {
+		beforeByKey[key{proc.PID, proc.Name}] = proc
+	}
+
+	deltas := make([]ProcessDelta, 0, len(before.Processes)+len(after.Processes))
+	for _, proc := range after.Processes {
+		k := key{proc.PID, proc.Name}
+		delta := ProcessDelta{
+			PID:         proc.PID,
+			Name:        proc.Name,
+			Status:      DeltaNew,
+			CPUAfter:    proc.CPUPercent,
+			MemoryAfter: proc.MemoryBytes,
+		}
+		if prev, exists := beforeByKey[k]; exists {
+			delta.Status = DeltaChanged
+			delta.CPUBefore = prev.CPUPercent
+			delta.MemoryBefore = prev.MemoryBytes
+			delete(beforeByKey, k)
+		}
+		deltas = append(deltas, delta)
+	}
+
+	for _, proc := range beforeByKey {
+		deltas = append(deltas, ProcessDelta{
+			PID:          proc.PID,
+			Name:         proc.Name,
+			Status:       DeltaGone,
+			CPUBefore:    proc.CPUPercent,
+			MemoryBefore: proc.MemoryBytes,
+		})
+	}
+
+	for i := range deltas {
+		deltas[i].CPUDelta = deltas[i].CPUAfter - deltas[i].CPUBefore
+		deltas[i].MemoryDelta = int64(deltas[i].MemoryAfter) - int64(deltas[i].MemoryBefore)
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		ai, aj := abs(deltas[i].CPUDelta), abs(deltas[j].CPUDelta)
+		if ai != aj {
+			return ai > aj
+		}
+		return deltas[i].PID < deltas[j].PID
+	})
+	return deltas
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareSnapshots(t *testing.T) {
+	before := &Snapshot{Processes: []*ProcessInfo{
+		{PID: 1, Name: "steady", CPUPercent: 10, MemoryBytes: 100},
+		{PID: 2, Name: "exited", CPUPercent: 5, MemoryBytes: 50},
+		{PID: 3, Name: "old-name", CPUPercent: 1, MemoryBytes: 10},
+		{PID: 4, Name: "ramping", CPUPercent: 2, MemoryBytes: 300},
+	}}
+	after := &Snapshot{Processes: []*ProcessInfo{
+		{PID: 1, Name: "steady", CPUPercent: 10, MemoryBytes: 100},
+		{PID: 3, Name: "reused-pid", CPUPercent: 3, MemoryBytes: 20},
+		{PID: 4, Name: "ramping", CPUPercent: 42, MemoryBytes: 200},
+	}}
+
+	deltas := CompareSnapshots(before, after)
+
+	expected := []ProcessDelta{
+		{PID: 4, Name: "ramping", Status: DeltaChanged, CPUBefore: 2, CPUAfter: 42, CPUDelta: 40, MemoryBefore: 300, MemoryAfter: 200, MemoryDelta: -100},
+		{PID: 2, Name: "exited", Status: DeltaGone, CPUBefore: 5, CPUDelta: -5, MemoryBefore: 50, MemoryDelta: -50},
+		{PID: 3, Name: "reused-pid", Status: DeltaNew, CPUAfter: 3, CPUDelta: 3, MemoryAfter: 20, MemoryDelta: 20},
+		{PID: 3, Name: "old-name", Status: DeltaGone, CPUBefore: 1, CPUDelta: -1, MemoryBefore: 10, MemoryDelta: -10},
+		{PID: 1, Name: "steady", Status: DeltaChanged, CPUBefore: 10, CPUAfter: 10, MemoryBefore: 100, MemoryAfter: 100},
+	}
+	if len(deltas) != len(expected) {
+		t.Fatalf("Expected %d deltas, got %d: %+v", len(expected), len(deltas), deltas)
+	}
+	for i := range expected {
+		if deltas[i] != expected[i] {
+			t.Errorf("Delta %d = %+v; expected %+v", i, deltas[i], expected[i])
+		}
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snapshot := &Snapshot{
+		System: &SystemMetrics{CPUPercent: 12.5, MemoryTotal: 1024},
+		Processes: []*ProcessInfo{
+			{PID: 7, PPID: 1, Name: "app", CPUPercent: 3.5, MemoryBytes: 2048,
+				Children: []ChildInfo{{PID: 8, Name: "app-worker", IsThread: true}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := snapshot.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if loaded.System.CPUPercent != 12.5 || len(loaded.Processes) != 1 {
+		t.Fatalf("Loaded snapshot mismatch: %+v", loaded)
+	}
+	proc := loaded.Processes[0]
+	if proc.PID != 7 || proc.Name != "app" || len(proc.Children) != 1 || !proc.Children[0].IsThread {
+		t.Errorf("Loaded process mismatch: %+v", proc)
+	}
+}
@@ -0,0 +1,47 @@
+package monitor
+
+import (
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// procHandle is the subset of a gopsutil process a scan reads. Benchmarks
+// and tests substitute synthetic handles.
+type procHandle interface {
+	PID() int32
+	Name() (string, error)
+	Ppid() (int32, error)
+	CPUPercent() (float64, error)
+	MemoryInfo() (*process.MemoryInfoStat, error)
+	Exe() (string, error)
+	Status() ([]string, error)
+	Times() (*cpu.TimesStat, error)
+	CreateTime() (int64, error) // Milliseconds since the epoch
+	Cmdline() (string, error)
+	PageFaults() (*process.PageFaultsStat, error)
+}
+
+// processSource enumerates the processes a scan covers
+type processSource func() ([]procHandle, error)
+
+// systemProcess adapts a gopsutil process to procHandle
+type systemProcess struct {
+	*process.Process
+}
+
+func (p systemProcess) PID() int32 {
+	return p.Pid
+}
+
+// systemProcesses is the default source: every process on the system
+func systemProcesses() ([]procHandle, error) {
+	processes, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	handles := make([]procHandle, len(processes))
+	for i, p := range processes {
+		handles[i] = systemProcess{p}
+	}
+	return handles, nil
+}
@@ -0,0 +1,138 @@
+package monitor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// fakeProc is a synthetic procHandle with fixed stats
+type fakeProc struct {
+	pid, ppid int32
+	name      string
+	cpu       float64
+	rss       uint64
+}
+
+func (p *fakeProc) PID() int32                   { return p.pid }
+func (p *fakeProc) Name() (string, error)        { return p.name, nil }
+func (p *fakeProc) Ppid() (int32, error)         { return p.ppid, nil }
+func (p *fakeProc) CPUPercent() (float64, error) { return p.cpu, nil }
+func (p *fakeProc) Exe() (string, error)         { return "/usr/bin/" + p.name, nil }
+func (p *fakeProc) Status() ([]string, error)    { return []string{process.Sleep}, nil }
+func (p *fakeProc) Times() (*cpu.TimesStat, error) {
+	return &cpu.TimesStat{User: p.cpu}, nil
+}
+func (p *fakeProc) Cmdline() (string, error) { return p.name + " --serve", nil }
+func (p *fakeProc) CreateTime() (int64, error) {
+	return time.Now().Add(-time.Hour).UnixMilli(), nil
+}
+func (p *fakeProc) PageFaults() (*process.PageFaultsStat, error) {
+	return &process.PageFaultsStat{}, nil
+}
+func (p *fakeProc) MemoryInfo() (*process.MemoryInfoStat, error) {
+	return &process.MemoryInfoStat{RSS: p.rss}, nil
+}
+
+// syntheticProcesses builds families of ten under init: a parent and nine
+// same-named children, every fifth family busy enough to be listed
+func syntheticProcesses(n int) []procHandle {
+	handles := []procHandle{&fakeProc{pid: 1, name: "init"}}
+	for pid := int32(2); len(handles) < n; pid++ {
+		family := (pid - 2) / 10
+		proc := &fakeProc{pid: pid, name: fmt.Sprintf("app%d", family), rss: 4 << 20}
+		if (pid-2)%10 == 0 {
+			proc.ppid = 1
+		} else {
+			proc.ppid = family*10 + 2
+		}
+		if family%5 == 0 {
+			proc.cpu = 2
+		}
+		handles = append(handles, proc)
+	}
+	return handles
+}
+
+func TestGetFilteredProcessesFromSource(t *testing.T) {
+	m := New(config.New())
+	handles := syntheticProcesses(31)
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	procs, err := m.GetFilteredProcesses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || procs[0].Name != "app0" || len(procs[0].Children) != 9 {
+		t.Fatalf("expected app0 with 9 children, got %v", procs)
+	}
+	if procs[0].ExeName != "app0" {
+		t.Errorf("ExeName = %q; expected app0", procs[0].ExeName)
+	}
+
+	// A process gone from the source must not survive in the reused buffers
+	handles = handles[:len(handles)-1]
+	if procs, err = m.GetFilteredProcesses(); err != nil {
+		t.Fatal(err)
+	}
+	if len(procs) != 1 || len(procs[0].Children) != 9 {
+		t.Fatalf("expected app0 unchanged after an app2 child exited, got %v", procs)
+	}
+	if _, ok := m.buf.all[31]; ok {
+		t.Error("exited PID 31 is still in the scan buffers")
+	}
+}
+
+func BenchmarkGetFilteredProcesses(b *testing.B) {
+	m := New(config.New())
+	handles := syntheticProcesses(3000)
+	m.source = func() ([]procHandle, error) { return handles, nil }
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := m.GetFilteredProcesses(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// slowProc stands in for a real process, whose stat reads each cost a
+// /proc syscall
+type slowProc struct {
+	*fakeProc
+}
+
+func (p slowProc) MemoryInfo() (*process.MemoryInfoStat, error) {
+	time.Sleep(20 * time.Microsecond)
+	return p.fakeProc.MemoryInfo()
+}
+
+// BenchmarkParallelScan compares a serial first pass with the worker pool
+// when reading each process has real latency
+func BenchmarkParallelScan(b *testing.B) {
+	var handles []procHandle
+	for _, h := range syntheticProcesses(500) {
+		handles = append(handles, slowProc{h.(*fakeProc)})
+	}
+
+	// The latency is sleep, not CPU, so the pool helps even on one core
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cfg := config.New()
+			cfg.SetScanWorkers(workers)
+			m := New(cfg)
+			m.source = func() ([]procHandle, error) { return handles, nil }
+
+			for i := 0; i < b.N; i++ {
+				if _, err := m.GetFilteredProcesses(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
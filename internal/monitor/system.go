@@ -2,6 +2,7 @@ package monitor
 
 import (
 	"fmt"
+	"time"
 )
 
 func FormatBytes(bytes uint64) string {
@@ -17,6 +18,31 @@ func FormatBytes(bytes uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// usedPercent is used as a share of total, or 0 when the total isn't
+// reported. Some containers report a zero memory total, where gopsutil's
+// UsedPercent comes out as NaN.
+func usedPercent(used, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(used) / float64(total) * 100
+}
+
 func FormatCPU(percent float64) string {
 	return fmt.Sprintf("%.1f%%", percent)
 }
+
+// FormatDuration renders a duration compactly with its two largest units,
+// e.g. "45s", "3m12s", "2h14m" or "3d4h"
+func FormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+}
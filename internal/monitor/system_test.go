@@ -1,6 +1,13 @@
 package monitor
 
-import "testing"
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/shirou/gopsutil/v3/cpu"
+)
 
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
@@ -52,3 +59,84 @@ func TestFormatCPU(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{1500 * time.Millisecond, "1s"},
+		{3*time.Minute + 12*time.Second, "3m12s"},
+		{2*time.Hour + 14*time.Minute + 59*time.Second, "2h14m"},
+		{76 * time.Hour, "3d4h"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatDuration(tt.duration); result != tt.expected {
+			t.Errorf("FormatDuration(%v) = %q; expected %q", tt.duration, result, tt.expected)
+		}
+	}
+}
+
+func TestUsedPercentZeroTotal(t *testing.T) {
+	if got := usedPercent(512, 0); got != 0 {
+		t.Errorf("usedPercent with a zero total = %v; expected 0", got)
+	}
+	if got := usedPercent(256, 1024); got != 25 {
+		t.Errorf("usedPercent(256, 1024) = %v; expected 25", got)
+	}
+}
+
+func TestCPUBlockingFirstReading(t *testing.T) {
+	var calls []time.Time
+	systemCPUPercent = func(time.Duration, bool) ([]float64, error) {
+		calls = append(calls, time.Now())
+		return []float64{25}, nil
+	}
+	t.Cleanup(func() { systemCPUPercent = cpu.Percent })
+
+	cfg := config.New()
+	cfg.SetCPUBlocking(30 * time.Millisecond)
+	m := New(cfg)
+
+	// The first reading restarts the measurement, then waits
+	if _, err := m.GetSystemMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[1].Sub(calls[0]) < 30*time.Millisecond {
+		t.Fatalf("first reading took %d calls over %v; expected 2 calls 30ms apart", len(calls), calls[len(calls)-1].Sub(calls[0]))
+	}
+
+	// Later ones don't block
+	calls = nil
+	if _, err := m.GetSystemMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("second reading took %d calls; expected 1", len(calls))
+	}
+}
+
+// TestCPUBlockingUnreadable checks --cpu-blocking doesn't wait for a reading
+// that already failed
+func TestCPUBlockingUnreadable(t *testing.T) {
+	calls := 0
+	systemCPUPercent = func(time.Duration, bool) ([]float64, error) {
+		calls++
+		return nil, errors.New("no /proc/stat")
+	}
+	t.Cleanup(func() { systemCPUPercent = cpu.Percent })
+
+	cfg := config.New()
+	cfg.SetCPUBlocking(time.Hour)
+	m := New(cfg)
+
+	metrics, err := m.GetSystemMetrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || metrics.CPUPercent != 0 {
+		t.Errorf("got %d calls and %v%% CPU; expected 2 calls and no reading", calls, metrics.CPUPercent)
+	}
+}
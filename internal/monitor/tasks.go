@@ -0,0 +1,47 @@
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// TaskCounts is a top-style breakdown of every scanned process by state,
+// not just the listed ones
+type TaskCounts struct {
+	Total    int `json:"total"`
+	Running  int `json:"running"`
+	Sleeping int `json:"sleeping"` // Including idle kernel threads and D state, as top counts them
+	Stopped  int `json:"stopped"`
+	Zombie   int `json:"zombie"`
+}
+
+func (c TaskCounts) String() string {
+	return fmt.Sprintf("Tasks: %d total, %d running, %d sleeping, %d stopped, %d zombie",
+		c.Total, c.Running, c.Sleeping, c.Stopped, c.Zombie)
+}
+
+// countTasks tallies processes by state
+func countTasks(all map[int32]*ProcessInfo) TaskCounts {
+	counts := TaskCounts{Total: len(all)}
+	for _, info := range all {
+		switch info.State {
+		case process.Running:
+			counts.Running++
+		case process.Sleep, process.Idle, process.Blocked:
+			counts.Sleeping++
+		case process.Stop:
+			counts.Stopped++
+		case process.Zombie:
+			counts.Zombie++
+		}
+	}
+	return counts
+}
+
+// LastTaskCounts returns the state breakdown from the most recent scan
+func (m *Monitor) LastTaskCounts() TaskCounts {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tasks
+}
@@ -0,0 +1,27 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+func TestCountTasks(t *testing.T) {
+	all := make(map[int32]*ProcessInfo)
+	states := []string{
+		process.Running, process.Running, process.Sleep, process.Sleep, process.Idle,
+		process.Blocked, process.Stop, process.Zombie, "",
+	}
+	for i, state := range states {
+		all[int32(i+1)] = &ProcessInfo{PID: int32(i + 1), State: state}
+	}
+
+	counts := countTasks(all)
+	expected := TaskCounts{Total: 9, Running: 2, Sleeping: 4, Stopped: 1, Zombie: 1}
+	if counts != expected {
+		t.Errorf("countTasks = %+v; expected %+v", counts, expected)
+	}
+	if s := counts.String(); s != "Tasks: 9 total, 2 running, 4 sleeping, 1 stopped, 1 zombie" {
+		t.Errorf("String() = %q", s)
+	}
+}
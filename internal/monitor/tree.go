@@ -0,0 +1,38 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatTree renders a process and its children/threads as a plain-text tree
+// suitable for pasting into bug reports
+func FormatTree(proc *ProcessInfo) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s (PID %d)  %s  %s\n",
+		proc.Name, proc.PID, FormatCPU(proc.CPUPercent), FormatBytes(proc.MemoryBytes))
+
+	if len(proc.Children) == 0 {
+		return b.String()
+	}
+
+	// The parent's own usage comes first so the entries sum to the total
+	fmt.Fprintf(&b, "├── %s (PID %d, parent)  %s  %s\n",
+		proc.Name, proc.PID, FormatCPU(proc.ParentCPU), FormatBytes(proc.ParentMemory))
+
+	for i, child := range proc.Children {
+		branch := "├──"
+		if i == len(proc.Children)-1 {
+			branch = "└──"
+		}
+		kind := "child"
+		if child.IsThread {
+			kind = "thread"
+		}
+		fmt.Fprintf(&b, "%s %s (PID %d, %s)  %s  %s\n",
+			branch, child.Name, child.PID, kind, FormatCPU(child.CPUPercent), FormatBytes(child.MemoryBytes))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package monitor
+
+import "testing"
+
+func TestFormatTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		proc     *ProcessInfo
+		expected string
+	}{
+		{
+			name:     "Leaf process",
+			proc:     &ProcessInfo{PID: 42, Name: "worker", CPUPercent: 12.5, MemoryBytes: 1024 * 1024},
+			expected: "worker (PID 42)  12.5%  1.0 MB\n",
+		},
+		{
+			name: "Process with children and threads",
+			proc: &ProcessInfo{
+				PID:          100,
+				Name:         "chrome",
+				CPUPercent:   30.0,
+				MemoryBytes:  300 * 1024 * 1024,
+				ParentCPU:    10.0,
+				ParentMemory: 100 * 1024 * 1024,
+				Children: []ChildInfo{
+					{PID: 101, Name: "chrome-renderer", CPUPercent: 15.0, MemoryBytes: 150 * 1024 * 1024},
+					{PID: 102, Name: "chrome-gpu", CPUPercent: 5.0, MemoryBytes: 50 * 1024 * 1024, IsThread: true},
+				},
+			},
+			expected: "chrome (PID 100)  30.0%  300.0 MB\n" +
+				"├── chrome (PID 100, parent)  10.0%  100.0 MB\n" +
+				"├── chrome-renderer (PID 101, child)  15.0%  150.0 MB\n" +
+				"└── chrome-gpu (PID 102, thread)  5.0%  50.0 MB\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatTree(tt.proc)
+			if result != tt.expected {
+				t.Errorf("FormatTree() =\n%s\nexpected\n%s", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// statTTYNr is the controlling terminal's device number in /proc/PID/stat
+const statTTYNr = 7
+
+// readTTY returns the name of a process's controlling terminal, like ps's
+// TTY column: "pts/3", "tty1", or "?" when it has none
+func readTTY(pid int32) (string, error) {
+	fields, err := readStatFields(pid)
+	if err != nil {
+		return "", err
+	}
+	field, err := statField(fields, statTTYNr)
+	if err != nil {
+		return "", err
+	}
+	nr, err := strconv.ParseUint(field, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid tty_nr %q: %w", field, err)
+	}
+	return ttyName(nr), nil
+}
+
+// ttyName decodes a tty_nr device number into a terminal name
+func ttyName(nr uint64) string {
+	if nr == 0 {
+		return "?"
+	}
+	major := (nr >> 8) & 0xfff
+	minor := (nr & 0xff) | ((nr >> 12) & 0xfff00)
+	switch {
+	case major >= 136 && major <= 143: // Unix98 pseudo-terminals
+		return fmt.Sprintf("pts/%d", (major-136)*256+minor)
+	case major == 4 && minor < 64: // Virtual consoles
+		return fmt.Sprintf("tty%d", minor)
+	case major == 4: // Serial ports
+		return fmt.Sprintf("ttyS%d", minor-64)
+	default:
+		return fmt.Sprintf("%d:%d", major, minor)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package monitor
+
+import "errors"
+
+// readTTY is only supported on Linux; the column shows "?" elsewhere
+func readTTY(_ int32) (string, error) {
+	return "", errors.New("TTY lookup is not supported on this platform")
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+// A process only counts as heavier or lighter than its baseline when it
+// moved by more than this, so sampling noise doesn't flip colors
+const (
+	baselineCPUTolerance    = 1.0  // Percentage points
+	baselineMemoryTolerance = 0.05 // Fraction of the baseline memory
+)
+
+// baselineSample is a process's usage when the baseline was captured
+type baselineSample struct {
+	cpu    float64
+	memory uint64
+}
+
+// baseline is a snapshot of the listed processes to compare against.
+// Processes are matched by PID, or by group name if they were restarted.
+type baseline struct {
+	at     time.Time
+	byPID  map[int32]baselineSample
+	byName map[string]baselineSample
+}
+
+func newBaseline(processes []*monitor.ProcessInfo, at time.Time) *baseline {
+	b := &baseline{
+		at:     at,
+		byPID:  make(map[int32]baselineSample, len(processes)),
+		byName: make(map[string]baselineSample, len(processes)),
+	}
+	for _, proc := range processes {
+		sample := baselineSample{cpu: proc.CPUPercent, memory: proc.MemoryBytes}
+		b.byPID[proc.PID] = sample
+		if _, seen := b.byName[proc.GroupName()]; !seen {
+			b.byName[proc.GroupName()] = sample
+		}
+	}
+	return b
+}
+
+// lookup finds proc's baseline usage
+func (b *baseline) lookup(proc *monitor.ProcessInfo) (baselineSample, bool) {
+	if sample, ok := b.byPID[proc.PID]; ok {
+		return sample, true
+	}
+	sample, ok := b.byName[proc.GroupName()]
+	return sample, ok
+}
+
+// compare returns 1 if proc is heavier than its baseline in CPU or memory,
+// -1 if it is lighter without being heavier in either, and 0 if neither
+// moved past the tolerances. Processes missing from the baseline are new,
+// and so heavier.
+func (b *baseline) compare(proc *monitor.ProcessInfo) int {
+	sample, ok := b.lookup(proc)
+	if !ok {
+		return 1
+	}
+	memoryTolerance := float64(sample.memory) * baselineMemoryTolerance
+	cpuDelta := proc.CPUPercent - sample.cpu
+	memoryDelta := float64(proc.MemoryBytes) - float64(sample.memory)
+	switch {
+	case cpuDelta > baselineCPUTolerance || memoryDelta > memoryTolerance:
+		return 1
+	case cpuDelta < -baselineCPUTolerance || memoryDelta < -memoryTolerance:
+		return -1
+	}
+	return 0
+}
+
+// baselineColor colors a row by its comparison to the baseline
+func (cs *ColorScheme) baselineColor(comparison int) tcell.Color {
+	switch comparison {
+	case 1:
+		return cs.HighUsage
+	case -1:
+		return cs.LowUsage
+	}
+	return cs.Text
+}
+
+// ToggleBaseline captures the listed processes as a baseline to color rows
+// against, or clears the baseline if one is set
+func (d *Display) ToggleBaseline() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.baseline != nil {
+		d.baseline = nil
+		d.setStatus("Baseline cleared")
+		return
+	}
+	d.baseline = newBaseline(d.processes, d.lastUpdate)
+	d.setStatus(fmt.Sprintf("Baseline captured (%d processes): red is heavier, green lighter", len(d.processes)))
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+func TestBaselineCompare(t *testing.T) {
+	b := newBaseline([]*monitor.ProcessInfo{
+		{PID: 10, Name: "postgres", CPUPercent: 20, MemoryBytes: 1000},
+		{PID: 11, Name: "nginx", ExeName: "nginx", CPUPercent: 5, MemoryBytes: 1000},
+	}, time.Now())
+
+	tests := []struct {
+		name     string
+		proc     *monitor.ProcessInfo
+		expected int
+	}{
+		{"Unchanged within tolerance", &monitor.ProcessInfo{PID: 10, Name: "postgres", CPUPercent: 20.5, MemoryBytes: 1020}, 0},
+		{"More CPU", &monitor.ProcessInfo{PID: 10, Name: "postgres", CPUPercent: 30, MemoryBytes: 1000}, 1},
+		{"More memory", &monitor.ProcessInfo{PID: 10, Name: "postgres", CPUPercent: 20, MemoryBytes: 2000}, 1},
+		{"Heavier wins over lighter", &monitor.ProcessInfo{PID: 10, Name: "postgres", CPUPercent: 5, MemoryBytes: 2000}, 1},
+		{"Lighter", &monitor.ProcessInfo{PID: 10, Name: "postgres", CPUPercent: 10, MemoryBytes: 1000}, -1},
+		{"Restarted, matched by name", &monitor.ProcessInfo{PID: 99, Name: "nginx: worker", ExeName: "nginx", CPUPercent: 1, MemoryBytes: 500}, -1},
+		{"New since baseline", &monitor.ProcessInfo{PID: 50, Name: "backup", CPUPercent: 0}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := b.compare(tt.proc); result != tt.expected {
+				t.Errorf("compare() = %d; expected %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToggleBaseline(t *testing.T) {
+	d := New(config.New(), nil)
+	d.processes = []*monitor.ProcessInfo{{PID: 1, Name: "init"}}
+
+	d.ToggleBaseline()
+	if d.baseline == nil || len(d.baseline.byPID) != 1 {
+		t.Fatalf("Expected a baseline of the listed process, got %+v", d.baseline)
+	}
+	d.ToggleBaseline()
+	if d.baseline != nil {
+		t.Error("Expected the second toggle to clear the baseline")
+	}
+}
@@ -1,8 +1,14 @@
 package ui
 
 import (
+	"math"
+	"os"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
 	"github.com/SteiniDavid/brieftop/internal/monitor"
 	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/terminfo"
 )
 
 type ColorScheme struct {
@@ -21,6 +27,8 @@ type ColorScheme struct {
 	Success      tcell.Color
 	Warning      tcell.Color
 	Error        tcell.Color
+
+	mono bool // Ignore colors; selection is shown in reverse video
 }
 
 func NewColorScheme() *ColorScheme {
@@ -43,6 +51,76 @@ func NewColorScheme() *ColorScheme {
 	}
 }
 
+// ApplyColorProfile adapts the scheme to a forced color profile. The 256 and
+// 16 color profiles replace each color with its nearest palette entry, so
+// tcell sends palette indexes instead of RGB; mono drops colors altogether.
+func (cs *ColorScheme) ApplyColorProfile(profile config.ColorProfile) {
+	var size int
+	switch profile {
+	case config.ColorProfile256:
+		size = 256
+	case config.ColorProfile16:
+		size = 16
+	case config.ColorProfileMono:
+		cs.mono = true
+		return
+	default:
+		return
+	}
+
+	palette := make([]tcell.Color, size)
+	for i := range palette {
+		palette[i] = tcell.PaletteColor(i)
+	}
+	for _, c := range []*tcell.Color{
+		&cs.Background, &cs.Text, &cs.Header, &cs.LowUsage, &cs.MediumUsage, &cs.HighUsage, &cs.Selected,
+		&cs.Thread, &cs.ChildProcess, &cs.Border, &cs.Accent, &cs.Muted, &cs.Success, &cs.Warning, &cs.Error,
+	} {
+		*c = tcell.FindColor(*c, palette)
+	}
+}
+
+// newScreen creates the terminal screen with a forced color profile's
+// depth. tcell decides on 24-bit color from the terminfo entry's RGB
+// capabilities, so the profile goes into a copy of the entry rather than
+// into COLORTERM or TCELL_TRUECOLOR in the process environment.
+func newScreen(profile config.ColorProfile) (tcell.Screen, error) {
+	// As tcell.NewScreen: Windows consoles first, where terminfo doesn't
+	// apply; elsewhere this fails and the terminal is looked up instead
+	if s, err := tcell.NewConsoleScreen(); err == nil {
+		return s, nil
+	}
+	ti, err := tcell.LookupTerminfo(os.Getenv("TERM"))
+	if err != nil {
+		return tcell.NewScreen()
+	}
+	if forced := forceColorProfile(ti, profile); forced != nil {
+		ti = forced
+	}
+	return tcell.NewTerminfoScreenFromTtyTerminfo(nil, ti)
+}
+
+// forceColorProfile returns a copy of ti that makes tcell emit profile:
+// truecolor gains the standard 24-bit color sequences if ti lacks them,
+// and the palette profiles lose them. It returns nil if ti needs no change.
+func forceColorProfile(ti *terminfo.Terminfo, profile config.ColorProfile) *terminfo.Terminfo {
+	forced := *ti
+	switch profile {
+	case config.ColorProfileTrueColor:
+		if ti.SetFgBgRGB != "" || ti.SetFgRGB != "" || ti.SetBgRGB != "" {
+			return nil
+		}
+		forced.SetFgRGB = "\x1b[38;2;%p1%d;%p2%d;%p3%dm"
+		forced.SetBgRGB = "\x1b[48;2;%p1%d;%p2%d;%p3%dm"
+		forced.SetFgBgRGB = "\x1b[38;2;%p1%d;%p2%d;%p3%d;48;2;%p4%d;%p5%d;%p6%dm"
+	case config.ColorProfile256, config.ColorProfile16:
+		forced.SetFgRGB, forced.SetBgRGB, forced.SetFgBgRGB = "", "", ""
+	default:
+		return nil
+	}
+	return &forced
+}
+
 func (cs *ColorScheme) GetProcessColor(level monitor.ResourceLevel) tcell.Color {
 	switch level {
 	case monitor.Low:
@@ -57,6 +135,9 @@ func (cs *ColorScheme) GetProcessColor(level monitor.ResourceLevel) tcell.Color
 }
 
 func (cs *ColorScheme) GetStyle(color tcell.Color, selected bool) tcell.Style {
+	if cs.mono {
+		return tcell.StyleDefault.Reverse(selected)
+	}
 	style := tcell.StyleDefault.Foreground(color).Background(cs.Background)
 	if selected {
 		style = style.Background(cs.Selected)
@@ -76,43 +157,133 @@ func (cs *ColorScheme) GetProgressBarColor(percent float64) tcell.Color {
 	return cs.LowUsage
 }
 
-// CreateProgressBar creates a visual progress bar string
-func CreateProgressBar(percent float64, width int) string {
+// CreateProgressBar creates a visual progress bar string drawn with glyphs
+// (--bar-chars); the zero BarGlyphs draws the default blocks. With partial
+// glyphs, the cell where the fill ends shows how far into it it reaches.
+func CreateProgressBar(percent float64, width int, glyphs config.BarGlyphs) string {
 	if width < 2 {
 		return ""
 	}
+	if glyphs.Full == 0 {
+		glyphs = config.DefaultBarGlyphs
+	}
 
-	filledWidth := int((percent / 100.0) * float64(width))
-	if filledWidth > width {
-		filledWidth = width
+	if math.IsNaN(percent) || percent < 0 {
+		percent = 0
 	}
+	filled := min(percent/100.0*float64(width), float64(width))
+	filledWidth := int(filled)
 
-	bar := ""
-	for i := 0; i < width; i++ {
+	bar := make([]rune, width)
+	for i := range bar {
+		bar[i] = glyphs.Empty
 		if i < filledWidth {
-			bar += "█"
-		} else {
-			bar += "░"
+			bar[i] = glyphs.Full
 		}
 	}
-	return bar
+	if n := len(glyphs.Partial); n > 0 && filledWidth < width {
+		if step := int((filled - float64(filledWidth)) * float64(n+1)); step > 0 {
+			bar[filledWidth] = glyphs.Partial[step-1]
+		}
+	}
+	return string(bar)
+}
+
+// Status icons for process families and idle processes
+const (
+	collapsedIcon = "▶"
+	expandedIcon  = "▼"
+	idleIcon      = "○"
+)
+
+// statusTiers are the CPU tier icons, highest first. GetStatusIcon and the
+// legend both read from here so they can't drift apart.
+var statusTiers = []struct {
+	icon      string
+	label     string
+	threshold func(config.IconThresholds) float64
+}{
+	{"◉", "High CPU", func(t config.IconThresholds) float64 { return t.High }},
+	{"●", "Medium CPU", func(t config.IconThresholds) float64 { return t.Medium }},
+	{"◎", "Active", func(t config.IconThresholds) float64 { return t.Active }},
 }
 
-// GetStatusIcon returns an appropriate icon for process status
-func GetStatusIcon(cpuPercent float64, isExpanded bool, hasChildren bool) string {
+// GetStatusIcon returns an appropriate icon for process status: the
+// expand arrow for families, otherwise a CPU tier icon using thresholds.
+// Each tier has its own glyph so it reads without color; the row color is
+// applied separately from the resource level.
+func GetStatusIcon(cpuPercent float64, isExpanded bool, hasChildren bool, thresholds config.IconThresholds) string {
 	if hasChildren {
 		if isExpanded {
-			return "▼"
+			return expandedIcon
 		}
-		return "▶"
+		return collapsedIcon
 	}
 
-	if cpuPercent >= 50 {
-		return "◉" // High CPU
-	} else if cpuPercent >= 20 {
-		return "●" // Medium CPU
-	} else if cpuPercent >= 5 {
-		return "◎" // Active
+	for _, tier := range statusTiers {
+		if cpuPercent >= tier.threshold(thresholds) {
+			return tier.icon
+		}
+	}
+	return idleIcon // Low activity
+}
+
+// spinnerFrames are the braille frames of the refresh liveness indicator
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// GetSpinnerFrame returns the spinner frame for the given refresh count
+func GetSpinnerFrame(tick int) string {
+	return spinnerFrames[tick%len(spinnerFrames)]
+}
+
+// Stacked memory bar glyphs for buffers and cache; distinct shades keep the
+// segments readable without color. Used and free memory are drawn with the
+// --bar-chars full and empty glyphs.
+const (
+	memBuffersGlyph = '▓'
+	memCachedGlyph  = '▒'
+)
+
+// memorySegments splits a bar of width cells into used, buffers and cached
+// memory, each in proportion to total RAM; the rest of the bar is free.
+// Boundaries are rounded cumulatively so the segments always add up.
+func memorySegments(m *monitor.SystemMetrics, width int) (used, buffers, cached int) {
+	if m.MemoryTotal == 0 || width < 1 {
+		return 0, 0, 0
+	}
+	cell := func(bytes uint64) int {
+		n := int(float64(bytes)/float64(m.MemoryTotal)*float64(width) + 0.5)
+		if n > width {
+			n = width
+		}
+		return n
+	}
+	usedEnd := cell(m.MemoryUsed)
+	buffersEnd := cell(m.MemoryUsed + m.MemoryBuffers)
+	cachedEnd := cell(m.MemoryUsed + m.MemoryBuffers + m.MemoryCached)
+	return usedEnd, buffersEnd - usedEnd, cachedEnd - buffersEnd
+}
+
+// drawStackedMemoryBar draws the header memory bar split into used memory
+// (colored by pressure), buffers and page cache, so reclaimable cache isn't
+// mistaken for memory in use
+func (d *Display) drawStackedMemoryBar(x, y, maxWidth, width int) {
+	m := d.systemMetrics
+	used, buffers, cached := memorySegments(m, width)
+	cs := d.colorScheme
+	glyphs := d.config.GetBarGlyphs()
+	segments := []struct {
+		cells int
+		glyph rune
+		color tcell.Color
+	}{
+		{used, glyphs.Full, cs.GetProgressBarColor(m.MemoryPercent)},
+		{buffers, memBuffersGlyph, cs.Accent},
+		{cached, memCachedGlyph, cs.Thread},
+		{width - used - buffers - cached, glyphs.Empty, cs.Muted},
+	}
+	for _, seg := range segments {
+		d.drawText(x, y, maxWidth, strings.Repeat(string(seg.glyph), seg.cells), cs.GetStyle(seg.color, false))
+		x += seg.cells
 	}
-	return "○" // Low activity
 }
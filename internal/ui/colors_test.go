@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/terminfo"
+)
+
+func TestGetStatusIconThresholds(t *testing.T) {
+	custom := config.IconThresholds{High: 90, Medium: 60, Active: 30}
+
+	tests := []struct {
+		name       string
+		cpu        float64
+		thresholds config.IconThresholds
+		expected   string
+	}{
+		{"Default high", 55, config.DefaultIconThresholds, "◉"},
+		{"Custom below active", 25, custom, "○"},
+		{"Custom active", 55, custom, "◎"},
+		{"Custom boundary", 60, custom, "●"},
+		{"Custom high", 95, custom, "◉"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := GetStatusIcon(tt.cpu, false, false, tt.thresholds); result != tt.expected {
+				t.Errorf("GetStatusIcon(%.0f) = %q; expected %q", tt.cpu, result, tt.expected)
+			}
+		})
+	}
+
+	if GetStatusIcon(99, false, true, custom) != "▶" {
+		t.Error("Families should show the expand arrow regardless of CPU")
+	}
+}
+
+// TestGetStatusIconTiersAreDistinct guards against the tiers collapsing to
+// the same glyph, which would leave the icon carrying no information
+func TestGetStatusIconTiersAreDistinct(t *testing.T) {
+	tiers := map[string]float64{
+		"high":   75,
+		"medium": 30,
+		"active": 10,
+		"idle":   1,
+	}
+
+	seen := make(map[string]string)
+	for tier, cpu := range tiers {
+		icon := GetStatusIcon(cpu, false, false, config.DefaultIconThresholds)
+		if other, dup := seen[icon]; dup {
+			t.Errorf("Tiers %q and %q both render %q", other, tier, icon)
+		}
+		seen[icon] = tier
+	}
+}
+
+func TestApplyColorProfile(t *testing.T) {
+	cs := NewColorScheme()
+	cs.ApplyColorProfile(config.ColorProfile16)
+	for _, c := range []tcell.Color{cs.Background, cs.Text, cs.HighUsage, cs.Selected, cs.Muted} {
+		if c.IsRGB() || c < tcell.ColorBlack || c > tcell.ColorWhite {
+			t.Errorf("16-color profile left %v outside the basic palette", c)
+		}
+	}
+	if cs.HighUsage == cs.LowUsage {
+		t.Error("Expected high and low usage to stay distinguishable with 16 colors")
+	}
+
+	mono := NewColorScheme()
+	mono.ApplyColorProfile(config.ColorProfileMono)
+	if style := mono.GetStyle(mono.HighUsage, false); style != tcell.StyleDefault {
+		t.Errorf("Mono style = %v; expected the terminal default", style)
+	}
+	if _, _, attrs := mono.GetStyle(mono.Text, true).Decompose(); attrs&tcell.AttrReverse == 0 {
+		t.Error("Expected mono selection to use reverse video")
+	}
+}
+
+func TestForceColorProfile(t *testing.T) {
+	plain := &terminfo.Terminfo{Name: "xterm-256color", Colors: 256}
+	rgb := &terminfo.Terminfo{Name: "xterm-direct", Colors: 256, SetFgRGB: "fg", SetBgRGB: "bg", SetFgBgRGB: "fgbg"}
+
+	if forced := forceColorProfile(plain, config.ColorProfileAuto); forced != nil {
+		t.Errorf("Auto profile changed the terminfo entry: %+v", forced)
+	}
+	if forced := forceColorProfile(plain, config.ColorProfileTrueColor); forced == nil || forced.SetFgRGB == "" || forced.SetFgBgRGB == "" {
+		t.Errorf("truecolor profile = %+v; expected 24-bit color sequences", forced)
+	}
+	if forced := forceColorProfile(rgb, config.ColorProfileTrueColor); forced != nil {
+		t.Errorf("truecolor profile replaced the entry's own sequences: %+v", forced)
+	}
+	forced := forceColorProfile(rgb, config.ColorProfile16)
+	if forced == nil || forced.SetFgRGB != "" || forced.SetBgRGB != "" || forced.SetFgBgRGB != "" {
+		t.Errorf("16 color profile = %+v; expected no 24-bit color sequences", forced)
+	}
+	if rgb.SetFgRGB != "fg" {
+		t.Error("forceColorProfile modified the looked-up entry instead of a copy")
+	}
+}
+
+func TestMemorySegments(t *testing.T) {
+	gb := uint64(1 << 30)
+	tests := []struct {
+		name                  string
+		metrics               monitor.SystemMetrics
+		used, buffers, cached int
+	}{
+		{"Mostly cache", monitor.SystemMetrics{MemoryTotal: 16 * gb, MemoryUsed: 4 * gb, MemoryBuffers: gb, MemoryCached: 8 * gb}, 5, 1, 10},
+		{"No cache", monitor.SystemMetrics{MemoryTotal: 8 * gb, MemoryUsed: 2 * gb}, 5, 0, 0},
+		{"Overfull is capped", monitor.SystemMetrics{MemoryTotal: gb, MemoryUsed: gb, MemoryCached: gb}, 20, 0, 0},
+		{"Unknown total", monitor.SystemMetrics{MemoryUsed: gb}, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used, buffers, cached := memorySegments(&tt.metrics, 20)
+			if used != tt.used || buffers != tt.buffers || cached != tt.cached {
+				t.Errorf("memorySegments = %d, %d, %d; expected %d, %d, %d", used, buffers, cached, tt.used, tt.buffers, tt.cached)
+			}
+		})
+	}
+}
+
+func TestCreateProgressBarGlyphs(t *testing.T) {
+	smooth, _ := config.ParseBarGlyphs("smooth")
+	ascii, _ := config.ParseBarGlyphs("ascii")
+	tests := []struct {
+		name     string
+		percent  float64
+		glyphs   config.BarGlyphs
+		expected string
+	}{
+		{"Default", 50, config.DefaultBarGlyphs, "█████░░░░░"},
+		{"Zero value draws the default", 50, config.BarGlyphs{}, "█████░░░░░"},
+		{"ASCII", 30, ascii, "###-------"},
+		{"Partial cell", 45, smooth, "████▌     "},
+		{"Partial below one step", 40.5, smooth, "████      "},
+		{"Full with partials", 100, smooth, "██████████"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if bar := CreateProgressBar(tt.percent, 10, tt.glyphs); bar != tt.expected {
+				t.Errorf("CreateProgressBar(%v) = %q; expected %q", tt.percent, bar, tt.expected)
+			}
+		})
+	}
+}
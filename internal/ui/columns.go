@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// ToggleColumnMode enters or leaves column arrangement mode, where ←/→
+// pick one of the shown optional columns and Shift+←/→ (or </>) move it.
+// The order is part of the view state, so it's saved on exit.
+func (d *Display) ToggleColumnMode() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.columnMode {
+		d.columnMode = false
+		d.setStatus("Column order: " + strings.Join(d.columns().visible(), ", "))
+		return
+	}
+	visible := d.columns().visible()
+	if len(visible) == 0 {
+		d.setStatus("No optional columns shown; turn some on in settings (O)")
+		return
+	}
+	d.columnMode = true
+	d.columnName = visible[0]
+	d.setStatus("Arrange columns: ←/→ pick, Shift+←/→ or </> move, Enter done")
+}
+
+// ColumnModeOpen reports whether column arrangement mode has keyboard focus
+func (d *Display) ColumnModeOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.columnMode
+}
+
+// SelectColumn moves the column selection delta shown columns over
+func (d *Display) SelectColumn(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	visible := d.columns().visible()
+	if len(visible) == 0 {
+		return
+	}
+	i := slices.Index(visible, d.columnName) + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(visible) {
+		i = len(visible) - 1
+	}
+	d.columnName = visible[i]
+}
+
+// MoveSelectedColumn moves the selected column delta places among the
+// shown columns
+func (d *Display) MoveSelectedColumn(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cols := d.columns()
+	d.config.SetColumnOrder(moveColumn(cols.columnOrder(), cols.visible(), d.columnName, delta))
+}
+
+// moveColumn swaps name with its shown neighbor delta places away in order.
+// Hidden columns keep their places, so turning one back on puts it where
+// it was.
+func moveColumn(order, visible []string, name string, delta int) []string {
+	i := slices.Index(visible, name)
+	j := i + delta
+	if i < 0 || j < 0 || j >= len(visible) {
+		return order
+	}
+	moved := slices.Clone(order)
+	a, b := slices.Index(moved, name), slices.Index(moved, visible[j])
+	moved[a], moved[b] = moved[b], moved[a]
+	return moved
+}
+
+// renderColumnSelection highlights the selected column's heading
+func (d *Display) renderColumnSelection(width int) {
+	cols := d.columns()
+	start, cellWidth := cols.headerSpan(d.columnName)
+	x := borderPadding + len([]rune(columnHeaderPrefix(d.config))) + start - d.hOffset
+	if cellWidth == 0 || x < borderPadding {
+		return
+	}
+	d.drawText(x, d.processTop()-2, width-borderPadding, cols.headerCell(d.columnName), d.colorScheme.GetStyle(d.colorScheme.Accent, true))
+}
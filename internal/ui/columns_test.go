@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestMoveColumn(t *testing.T) {
+	order := config.DefaultColumnOrder
+	visible := []string{config.ColumnMemPercent, config.ColumnFaults, config.ColumnTTY}
+
+	// Moving TTY left skips the hidden columns between it and faults
+	moved := moveColumn(order, visible, config.ColumnTTY, -1)
+	expected := []string{config.ColumnCPUBar, config.ColumnMemPercent, config.ColumnCPUTime, config.ColumnAvgCPU, config.ColumnTTY, config.ColumnFaults}
+	if strings.Join(moved, ",") != strings.Join(expected, ",") {
+		t.Errorf("moveColumn = %v; expected %v", moved, expected)
+	}
+	if order[5] != config.ColumnTTY {
+		t.Error("moveColumn modified the order it was given")
+	}
+
+	// The ends don't wrap
+	if got := moveColumn(order, visible, config.ColumnMemPercent, -1); strings.Join(got, ",") != strings.Join(order, ",") {
+		t.Errorf("moving the first column left changed the order: %v", got)
+	}
+}
+
+func TestColumnOrderCells(t *testing.T) {
+	cols := columns{memPercent: true, memTotal: 1000, tty: true, precision: 1, order: []string{config.ColumnTTY, config.ColumnMemPercent}}
+	cells := cols.cells(cellValues{memory: 500, tty: "tty1"})
+	if cells != " tty1     50.0%" {
+		t.Errorf("cells = %q; expected TTY before MEM%%", cells)
+	}
+	if start, width := cols.headerSpan(config.ColumnMemPercent); start != 8 || width != 7 {
+		t.Errorf("headerSpan(MEM%%) = %d, %d; expected 8, 7", start, width)
+	}
+	if len(cols.header()) != len(cells) {
+		t.Errorf("header %q not aligned with cells %q", cols.header(), cells)
+	}
+}
@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// maxDetailWidth caps the detail pane so long command lines don't span
+// very wide terminals edge to edge
+const maxDetailWidth = 90
+
+// ToggleDetail opens or closes the detail pane for the selected process
+func (d *Display) ToggleDetail() {
+	d.mu.Lock()
+	d.detailOpen = !d.detailOpen
+	d.envOpen = false
+	open := d.detailOpen
+	d.mu.Unlock()
+
+	if open {
+		d.refreshDetail()
+	}
+}
+
+// DetailOpen reports whether the detail pane currently has focus
+func (d *Display) DetailOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.detailOpen
+}
+
+// refreshDetail re-reads the selected process's details while the pane is
+// open. The reads happen outside the lock so render isn't held up by /proc.
+func (d *Display) refreshDetail() {
+	d.mu.RLock()
+	if !d.detailOpen || len(d.processes) == 0 || d.selectedIndex >= len(d.processes) {
+		d.mu.RUnlock()
+		return
+	}
+	pid := d.processes[d.selectedIndex].PID
+	d.mu.RUnlock()
+
+	detail, err := d.monitor.GetProcessDetail(pid)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.detail = nil
+		d.setStatus(fmt.Sprintf("Process %d is gone", pid))
+		return
+	}
+	d.detail = detail
+}
+
+// detailLabelWidth is the width of the label column in the detail pane
+const detailLabelWidth = 9
+
+// detailLines are the label/value rows shown in the detail pane. Values
+// are word-wrapped to width, with continuation lines indented under the value.
+func (d *Display) detailLines(width int) []string {
+	detail := d.detail
+	orUnavailable := func(s, field string) string {
+		if s != "" {
+			return s
+		}
+		if reason := detail.Unavailable[field]; reason != "" {
+			return "unavailable: " + reason
+		}
+		return "unavailable"
+	}
+	// Unreadable for other users' processes, so it's common enough to give
+	// just the reason
+	orReason := func(s, field string) string {
+		if s != "" {
+			return s
+		}
+		if reason := detail.Unavailable[field]; reason != "" {
+			return reason
+		}
+		return "-"
+	}
+
+	rows := []struct{ label, value string }{
+		{"PID", fmt.Sprint(detail.PID)},
+		{"Name", orUnavailable(detail.Name, "Name")},
+		{"Exe", orUnavailable(detail.Exe, "Exe")},
+		{"Command", orUnavailable(detail.Cmdline, "Cmdline")},
+		{"Cwd", orReason(detail.Cwd, "Cwd")},
+		{"CPUs", cpuSummary(detail)},
+		{"Sched", orReason(detail.Scheduling, "Scheduling")},
+		{"CPU time", monitor.FormatDuration(detail.CPUTime)},
+		{"Open", openSummary(detail)},
+	}
+	if !detail.Started.IsZero() {
+		rows = append(rows,
+			struct{ label, value string }{"Started", d.config.FormatTime(detail.Started)},
+			struct{ label, value string }{"Avg CPU", fmt.Sprintf("%.1f%% since start", detail.AvgCPU)},
+		)
+	}
+
+	if detail.MajorFaults > 0 || detail.MinorFaults > 0 {
+		rows = append(rows, struct{ label, value string }{"Faults", faultSummary(detail)})
+	}
+
+	if d.config.GetShowSecurityContext() {
+		rows = append(rows, struct{ label, value string }{"Security", orReason(detail.SecurityContext, "SecurityContext")})
+	}
+
+	if detail.PIDNamespace != 0 {
+		rows = append(rows, struct{ label, value string }{"PID ns", namespaceSummary(detail)})
+	} else if reason := detail.Unavailable["PIDNamespace"]; reason != "" {
+		rows = append(rows, struct{ label, value string }{"PID ns", "unavailable: " + reason})
+	}
+
+	var lines []string
+	indent := strings.Repeat(" ", detailLabelWidth+1)
+	for _, row := range rows {
+		for i, part := range wrapText(row.value, width-detailLabelWidth-1) {
+			if i == 0 {
+				lines = append(lines, fmt.Sprintf("%-*s %s", detailLabelWidth, row.label, part))
+			} else {
+				lines = append(lines, indent+part)
+			}
+		}
+	}
+	return lines
+}
+
+// namespaceSummary describes the process's PID namespace, noting its PID
+// inside the namespace when it's containerized
+func namespaceSummary(detail *monitor.ProcessDetail) string {
+	summary := fmt.Sprintf("pid:[%d]", detail.PIDNamespace)
+	if detail.HostPIDNamespace {
+		return summary + " (same as brieftop)"
+	}
+	if detail.NamespacePID != "" {
+		summary += fmt.Sprintf(" (container, PID %s inside)", detail.NamespacePID)
+	} else if reason := detail.Unavailable["NamespacePID"]; reason != "" {
+		summary += fmt.Sprintf(" (container, PID inside unavailable: %s)", reason)
+	}
+	return summary
+}
+
+// openSummary counts the process's file descriptors and network
+// connections, e.g. "48 files · 3 connections"; both need the process's
+// owner or root
+func openSummary(detail *monitor.ProcessDetail) string {
+	var parts []string
+	if detail.FDs >= 0 {
+		parts = append(parts, fmt.Sprintf("%d files", detail.FDs))
+	}
+	if detail.Connections >= 0 {
+		parts = append(parts, fmt.Sprintf("%d connections", detail.Connections))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// cpuSummary describes where the process may run and where it last ran,
+// e.g. "0-3 · last ran on CPU 2", to match a hot core in the header with
+// the process loading it
+func cpuSummary(detail *monitor.ProcessDetail) string {
+	var parts []string
+	if detail.AllowedCPUs != "" {
+		parts = append(parts, detail.AllowedCPUs)
+	}
+	if detail.LastCPU >= 0 {
+		parts = append(parts, fmt.Sprintf("last ran on CPU %d", detail.LastCPU))
+	}
+	if len(parts) == 0 {
+		if reason := detail.Unavailable["AllowedCPUs"]; reason != "" {
+			return "unavailable: " + reason
+		}
+		return "unavailable"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// faultSummary describes the process's page faults: the recent major fault
+// rate, flagged when it's high enough to be thrashing, and the totals
+func faultSummary(detail *monitor.ProcessDetail) string {
+	summary := fmt.Sprintf("%.0f major/s", detail.MajorFaultRate)
+	if detail.MajorFaultRate >= monitor.ThrashFaultRate {
+		summary += " (thrashing)"
+	}
+	return summary + fmt.Sprintf(" · %d major, %d minor since start", detail.MajorFaults, detail.MinorFaults)
+}
+
+// wrapText word-wraps s to lines of at most width runes. Words longer than
+// width (long paths, JVM classpaths) are split.
+func wrapText(s string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		for len(line)+len(w) > width {
+			n := width - len(line)
+			lines = append(lines, string(append(line, w[:n]...)))
+			line = line[:0]
+			w = w[n:]
+		}
+		line = append(line, w...)
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// renderDetail draws the selected process's details centered over the list
+func (d *Display) renderDetail(width, height int) {
+	if d.detail == nil {
+		return
+	}
+
+	boxWidth := width - 8
+	if boxWidth > maxDetailWidth {
+		boxWidth = maxDetailWidth
+	}
+	title, footer := " Details ", " v environment  Esc close "
+	var lines []string
+	if d.envOpen {
+		title, footer = " Environment ", " ↑↓ scroll  r reveal  v details  Esc close "
+		lines, _ = visibleEnvLines(d.envLines(), d.envScroll, detailRows(height))
+	} else {
+		lines = d.detailLines(boxWidth - 6)
+		if maxLines := detailRows(height); len(lines) > maxLines && maxLines > 0 {
+			lines = append(lines[:maxLines-1], "…")
+		}
+	}
+	boxHeight := len(lines) + 4
+	x := (width - boxWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+boxWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, boxWidth, boxHeight)
+
+	right := x + boxWidth - 2
+	d.drawText(x+2, y, right, title, d.colorScheme.GetStyle(d.colorScheme.Header, false))
+	for i, line := range lines {
+		d.drawText(x+3, y+2+i, right, line, textStyle)
+	}
+	d.drawText(x+2, y+boxHeight-1, right, footer, d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
+
+// detailRows is how many lines the detail pane fits on a screen this tall
+func detailRows(height int) int {
+	return height - 6
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected []string
+	}{
+		{"Fits", "java -jar app.jar", 20, []string{"java -jar app.jar"}},
+		{"Word wrap", "java -Xmx4g -jar app.jar", 12, []string{"java -Xmx4g", "-jar app.jar"}},
+		{"Long word split", "/opt/app/lib/a.jar:/opt/app/lib/b.jar", 16,
+			[]string{"/opt/app/lib/a.j", "ar:/opt/app/lib/", "b.jar"}},
+		{"Long word moves to next line", "cp /very/long/path", 8, []string{"cp", "/very/lo", "ng/path"}},
+		{"Empty", "", 10, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wrapText(tt.text, tt.width)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("wrapText(%q, %d) = %q; expected %q", tt.text, tt.width, result, tt.expected)
+			}
+			for _, line := range result {
+				if len([]rune(line)) > tt.width {
+					t.Errorf("line %q exceeds width %d", line, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestCPUSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   monitor.ProcessDetail
+		expected string
+	}{
+		{"Both", monitor.ProcessDetail{AllowedCPUs: "0-3", LastCPU: 2}, "0-3 · last ran on CPU 2"},
+		{"CPU 0", monitor.ProcessDetail{LastCPU: 0}, "last ran on CPU 0"},
+		{"Affinity only", monitor.ProcessDetail{AllowedCPUs: "0-7", LastCPU: -1}, "0-7"},
+		{"Neither", monitor.ProcessDetail{LastCPU: -1}, "unavailable"},
+		{"Unreadable", monitor.ProcessDetail{LastCPU: -1, Unavailable: map[string]string{"AllowedCPUs": "process exited"}}, "unavailable: process exited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cpuSummary(&tt.detail); got != tt.expected {
+				t.Errorf("cpuSummary = %q; expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOpenSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   monitor.ProcessDetail
+		expected string
+	}{
+		{"Both", monitor.ProcessDetail{FDs: 48, Connections: 3}, "48 files · 3 connections"},
+		{"FDs only", monitor.ProcessDetail{FDs: 0, Connections: -1}, "0 files"},
+		{"Neither", monitor.ProcessDetail{FDs: -1, Connections: -1}, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := openSummary(&tt.detail); got != tt.expected {
+				t.Errorf("openSummary = %q; expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetailLinesShowCwd(t *testing.T) {
+	d := New(config.New(), nil)
+	d.detail = &monitor.ProcessDetail{PID: 42, Name: "make", Cwd: "/home/dev/project", LastCPU: -1}
+	if !slices.Contains(d.detailLines(60), "Cwd       /home/dev/project") {
+		t.Errorf("expected a Cwd row, got %q", d.detailLines(60))
+	}
+
+	// Another user's process: the working directory can't be read
+	d.detail.Cwd = ""
+	if !slices.Contains(d.detailLines(60), "Cwd       -") {
+		t.Errorf("expected an unread Cwd to show -, got %q", d.detailLines(60))
+	}
+	d.detail.Unavailable = map[string]string{"Cwd": "permission denied"}
+	if !slices.Contains(d.detailLines(60), "Cwd       permission denied") {
+		t.Errorf("expected an unreadable Cwd to say why, got %q", d.detailLines(60))
+	}
+}
+
+func TestDetailLinesShowUnavailableReason(t *testing.T) {
+	d := New(config.New(), nil)
+	d.detail = &monitor.ProcessDetail{PID: 1, Name: "systemd", LastCPU: -1,
+		Unavailable: map[string]string{"Exe": "permission denied", "Scheduling": "process exited"}}
+	lines := d.detailLines(60)
+	if !slices.Contains(lines, "Exe       unavailable: permission denied") {
+		t.Errorf("expected the Exe row to give the reason, got %q", lines)
+	}
+	if !slices.Contains(lines, "Sched     process exited") {
+		t.Errorf("expected the Sched row to give the reason, got %q", lines)
+	}
+	if !slices.Contains(lines, "Command   unavailable") {
+		t.Errorf("expected an empty Command without a reason to read unavailable, got %q", lines)
+	}
+}
+
+func TestDetailLinesSecurityReason(t *testing.T) {
+	cfg := config.New()
+	cfg.SetShowSecurityContext(true)
+	d := New(cfg, nil)
+	d.detail = &monitor.ProcessDetail{PID: 1, LastCPU: -1}
+	if !slices.Contains(d.detailLines(60), "Security  -") {
+		t.Errorf("expected an unlabeled process to show -, got %q", d.detailLines(60))
+	}
+	d.detail.Unavailable = map[string]string{"SecurityContext": "permission denied"}
+	if !slices.Contains(d.detailLines(60), "Security  permission denied") {
+		t.Errorf("expected an unreadable label to say why, got %q", d.detailLines(60))
+	}
+}
+
+func TestNamespaceSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   monitor.ProcessDetail
+		expected string
+	}{
+		{"Host", monitor.ProcessDetail{PIDNamespace: 4026531836, HostPIDNamespace: true}, "pid:[4026531836] (same as brieftop)"},
+		{"Container", monitor.ProcessDetail{PIDNamespace: 4026532345, NamespacePID: "1"}, "pid:[4026532345] (container, PID 1 inside)"},
+		{
+			"PID inside unreadable",
+			monitor.ProcessDetail{PIDNamespace: 4026532345, Unavailable: map[string]string{"NamespacePID": "process exited"}},
+			"pid:[4026532345] (container, PID inside unavailable: process exited)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := namespaceSummary(&tt.detail); got != tt.expected {
+				t.Errorf("namespaceSummary = %q; expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactEnv(t *testing.T) {
+	vars := []string{"HOME=/root", "GITHUB_TOKEN=ghp_abc", "db_password=hunter2", "AWS_SECRET_ACCESS_KEY=x=y",
+		"OPENAI_API_KEY=sk-abc", "GOOGLE_APPLICATION_CREDENTIALS=/key.json", "NPM_AUTH=abc", "EMPTY"}
+	got := redactEnv(vars, false)
+	want := []string{"HOME=/root", "GITHUB_TOKEN=" + redactedValue, "db_password=" + redactedValue, "AWS_SECRET_ACCESS_KEY=" + redactedValue,
+		"OPENAI_API_KEY=" + redactedValue, "GOOGLE_APPLICATION_CREDENTIALS=" + redactedValue, "NPM_AUTH=" + redactedValue, "EMPTY"}
+	if !slices.Equal(got, want) {
+		t.Errorf("redactEnv() = %q, want %q", got, want)
+	}
+	if got := redactEnv(vars, true); !slices.Equal(got, vars) {
+		t.Errorf("redactEnv(reveal) = %q, want %q", got, vars)
+	}
+}
+
+func TestEnvLinesPermissionDenied(t *testing.T) {
+	d := New(config.New(), nil)
+	d.envErr = &fs.PathError{Op: "open", Path: "/proc/1/environ", Err: fs.ErrPermission}
+	if lines := d.envLines(); len(lines) != 1 || !strings.HasPrefix(lines[0], "Permission denied") {
+		t.Errorf("envLines() = %q, want a permission message", lines)
+	}
+}
+
+func TestVisibleEnvLinesClampsScroll(t *testing.T) {
+	lines := []string{"A=1", "B=2", "C=3", "D=4"}
+	visible, scroll := visibleEnvLines(lines, 10, 3)
+	if scroll != 1 || !slices.Equal(visible, lines[1:]) {
+		t.Errorf("visibleEnvLines() = %q, %d; want the last 3 lines at 1", visible, scroll)
+	}
+}
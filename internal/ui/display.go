@@ -2,30 +2,99 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/SteiniDavid/brieftop/internal/config"
 	"github.com/SteiniDavid/brieftop/internal/monitor"
 	"github.com/gdamore/tcell/v2"
 )
 
+// Display owns the TUI. Locking discipline:
+//   - Only Run's goroutine draws, clears, syncs or sizes the screen. inputLoop
+//     just polls events (tcell allows PollEvent alongside drawing) and Stop
+//     just posts an interrupt event.
+//   - mu guards the process list, selection, scroll position and view state.
+//     render holds the read lock; updateProcesses and the input handlers take
+//     the write lock. Monitor and Config calls are safe under either.
+//   - running, resized, dirty, bell, viewRows, tableOverflow, renderTime and
+//     updateTime are atomics so the loops can poll them without contending
+//     on mu.
+//   - Run only renders when dirty is set, by a refresh, an input event or a
+//     status message, or once idleRenderInterval has passed.
 type Display struct {
 	screen        tcell.Screen
-	monitor       *monitor.Monitor
+	monitor       DataSource
 	colorScheme   *ColorScheme
 	inputHandler  *InputHandler
 	config        ConfigInterface
 	mu            sync.RWMutex
 	processes     []*monitor.ProcessInfo
+	stuck         []*monitor.ProcessInfo // Persistently in D state, warned about in the header
+	maxCPU        float64                // Busiest listed process's CPU, the relative CPU bar's scale
 	systemMetrics *monitor.SystemMetrics
+	prevMetrics   *monitor.SystemMetrics // The snapshot before systemMetrics, for --header-deltas
 	selectedIndex int
 	scrollOffset  int
+	hOffset       int // Columns the process table is panned right by
 	paused        bool
-	forceRefresh  bool
-	running       bool
-	stopped       atomic.Bool
+	measuring     bool                   // Quiet start: discarding the first, unprimed sample
+	statusMessage string                 // Transient footer message (e.g. export confirmation)
+	statusExpiry  time.Time              // When statusMessage stops being shown
+	refreshTicks  int                    // Completed refreshes, drives the liveness spinner
+	lastUpdate    time.Time              // When the displayed data was collected
+	timings       monitor.ScanTimings    // Scan breakdown for the displayed data, shown with --profile
+	tasks         monitor.TaskCounts     // Every scanned process by state, for the header
+	settingsOpen  bool                   // Settings overlay has keyboard focus
+	settingsIndex int                    // Selected row in the settings overlay
+	helpOpen      bool                   // Key binding help overlay has keyboard focus
+	legendOpen    bool                   // Color and icon legend has keyboard focus
+	taskGraphOpen bool                   // Process count graph has keyboard focus
+	taskHistory   []int                  // Total process count per refresh, oldest first, bounded by taskHistoryLength
+	selectName    string                 // --select: process to select and expand once it appears
+	detailOpen    bool                   // Detail pane for the selected process has keyboard focus
+	detail        *monitor.ProcessDetail // Selected process's details, refreshed while detailOpen
+	envOpen       bool                   // The detail pane shows the environment instead
+	env           []string               // Its environment, read when envOpen was set
+	envErr        error                  // Why env couldn't be read
+	envScroll     int                    // First environment line shown
+	envReveal     bool                   // Show values redactEnv would hide
+	categoryView  bool                   // Show per-category totals instead of processes
+	containerView bool                   // Show per-container totals instead of processes
+	signalOpen    bool                   // Signal prompt has keyboard focus
+	signalIndex   int                    // Selected row in the signal prompt
+	signalPID     int32                  // Process the signal prompt targets
+	signalTarget  string                 // Its name, for the prompt title and footer
+	signalImpact  string                 // What the target holds, e.g. "Frees up to 1.2 GB, 40.0% CPU (38 children)"
+	baseline      *baseline              // Snapshot rows are colored against; nil for normal coloring
+	frozenOrder   map[int32]int          // Row of each PID while the sort is frozen; nil for the live order
+	filterOpen    bool                   // Filter prompt has keyboard focus
+	filterInput   string                 // Pattern being typed in the filter prompt
+	filterRegex   bool                   // The prompt's pattern is a regex rather than a substring
+	filter        *nameFilter            // Applied filter; nil lists everything
+	invertFilter  bool                   // List what filter doesn't match instead
+	columnMode    bool                   // Column arrangement mode has keyboard focus
+	columnName    string                 // Optional column selected in column mode
+	alertHook     *monitor.AlertHook     // Runs --alert-command; nil if none is configured
+	finiOnce      sync.Once
+	finiErr       error  // Why finiScreen couldn't fully restore the terminal
+	windowTitle   string // Last title set with --title; only Run's goroutine uses it
+
+	running       atomic.Bool  // Cleared by Stop; all loops exit once false
+	resized       atomic.Bool  // Set by inputLoop, handled (Sync) by render
+	dirty         atomic.Bool  // Something changed since the last render
+	bell          atomic.Bool  // Ring the bell on the next render, for --critical-bell
+	viewRows      atomic.Int32 // Process rows visible in the last render
+	tableOverflow atomic.Int32 // Columns the widest table row overflowed by in the last render
+	renderTime    atomic.Int64 // How long the last render took, in nanoseconds
+	updateTime    atomic.Int64 // How long the last refresh took, in nanoseconds
+
+	refreshRateChanged chan struct{} // Signals updateLoop to reset its ticker
+	refreshRequests    chan struct{} // Forced refreshes, honored even while paused
 }
 
 // Layout constants for the TUI grid.
@@ -33,111 +102,331 @@ const (
 	headerRows       = 8  // Lines 0-7: border, header, CPU, MEM, SWAP, separator, columns, separator
 	footerRows       = 3  // Bottom border line + controls line + bottom border
 	processStartY    = 8  // First row for process data (after header)
+	collapsedStartY  = 4  // First row for process data while the header is collapsed: border, header, columns, separator
+	onelineStartY    = 5  // First row for process data with the one-line header: border, header, metrics, columns, separator
 	borderPadding    = 2  // Left/right padding inside the border
 	processXOffset   = 3  // Left margin for process lines
 	minNameWidth     = 20 // Minimum width for process name column
 	minChildNameW    = 15 // Minimum width for child/parent name column
+	hScrollStep      = 8  // Columns panned per ←/→ press
 	fixedColumnWidth = 38 // Width of PID + CPU + MEM + CHILD columns (before name)
+	headerBarX       = 8  // Column the CPU, MEM and SWAP bars start at
+	headerBarWidth   = 20 // Cells in each header bar, whatever --bar-chars draws them with
 )
 
 type ConfigInterface interface {
 	GetRefreshRate() time.Duration
 	GetCPUThreshold() float64
 	GetMemoryThreshold() uint64
+	GetQuietStart() bool
+	GetIntervalAlign() bool
+	GetProfile() bool
+	AddExclude(pattern string)
+	SetProfile(enabled bool)
+	GetMemoryMetric() string
+	GetShowThreads() bool
+	FormatTime(t time.Time) string
+	GetSortMode() config.SortMode
+	SetSortMode(mode config.SortMode)
+	GetChildSort() config.ChildSort
+	GetGroupSort() config.GroupSort
+	GetCPUBar() config.CPUBar
+	SetCPUBar(bar config.CPUBar)
+	SetChildSort(sort config.ChildSort)
+	SetGroupSort(sort config.GroupSort)
+	GetShowMemPercent() bool
+	GetCPUDelta() bool
+	GetKeyBindings() map[string][]string
+	GetShowTTY() bool
+	GetShowCPUTime() bool
+	GetShowAvgCPU() bool
+	SetShowAvgCPU(show bool)
+	GetShowFaults() bool
+	GetShowSecurityContext() bool
+	GetLinger() time.Duration
+	SetShowFaults(show bool)
+	GetPrecision() int
+	SetPrecision(precision int)
+	GetSummaryOnly() bool
+	GetColumnOrder() []string
+	SetColumnOrder(order []string)
+	GetStackedMemory() bool
+	SetStackedMemory(stacked bool)
+	GetCollapseHeader() bool
+	GetOnelineHeader() bool
+	SetOnelineHeader(oneline bool)
+	GetWindowTitle() bool
+	SetWindowTitle(enabled bool)
+	GetHeaderDeltas() bool
+	SetHeaderDeltas(enabled bool)
+	GetCritical() []string
+	GetCriticalBell() bool
+	GetScope() (string, int32)
+	GetBarGlyphs() config.BarGlyphs
+	SetBarGlyphs(glyphs config.BarGlyphs)
+	SetCollapseHeader(collapse bool)
+	GetHighlightTop() bool
+	SetHighlightTop(highlight bool)
+	GetHeatColumn() bool
+	SetHeatColumn(show bool)
+	GetAlertCommand() string
+	GetColorProfile() config.ColorProfile
+	SetSummaryOnly(summary bool)
+	GetIconThresholds() config.IconThresholds
+	SetShowCPUTime(show bool)
+	SetShowTTY(show bool)
+	SetShowMemPercent(show bool)
+	SetCPUDelta(delta bool)
+	SetRefreshRate(rate time.Duration)
+	SetCPUThreshold(threshold float64)
+	SetMemoryThreshold(threshold uint64)
+	SetShowThreads(show bool)
+	GetCollapseThreads() bool
+	GetAggregateAllChildren() bool
+	SetAggregateAllChildren(all bool)
+	GetSumThreads() bool
+	SetSumThreads(sum bool)
+	GetTreeView() bool
+	SetTreeView(tree bool)
+	GetMaxTreeDepth() int
+	SetMaxTreeDepth(depth int)
+	SetCollapseThreads(collapse bool)
+	GetRefreshOnKey() bool
+	SetRefreshOnKey(enabled bool)
+	GetLayout() string
 }
 
-func New(config ConfigInterface, mon *monitor.Monitor) *Display {
+// DataSource is what the display reads processes from and acts on them
+// through: a *monitor.Monitor for this machine or a *monitor.Remote
+type DataSource interface {
+	GetFilteredProcesses() ([]*monitor.ProcessInfo, error)
+	GetSystemMetrics() (*monitor.SystemMetrics, error)
+	IsPrimed() bool
+	LastScanTimings() monitor.ScanTimings
+	LastTaskCounts() monitor.TaskCounts
+	StuckProcesses() []*monitor.ProcessInfo
+	LastAlerts() []monitor.Alert
+	LastMissingCritical() []string
+	GetResourceLevel(cpuPercent float64, memoryMB float64) monitor.ResourceLevel
+	ToggleExpanded(pid int32)
+	GetProcessDetail(pid int32) (*monitor.ProcessDetail, error)
+	Environ(pid int32) ([]string, error)
+	ExpandedNames() ([]string, error)
+	ExpandNames(names []string) error
+	SendSignal(pid int32, name string, sig syscall.Signal) error
+}
+
+func New(config ConfigInterface, source DataSource) *Display {
 	d := &Display{
-		monitor:       mon,
+		monitor:       source,
 		colorScheme:   NewColorScheme(),
 		config:        config,
 		selectedIndex: 0,
 		scrollOffset:  0,
 		paused:        false,
-		forceRefresh:  false,
-		running:       true,
+		measuring:     config.GetQuietStart(),
+
+		refreshRateChanged: make(chan struct{}, 1),
+		refreshRequests:    make(chan struct{}, 1),
+	}
+	d.colorScheme.ApplyColorProfile(config.GetColorProfile())
+	if command := config.GetAlertCommand(); command != "" {
+		d.alertHook = monitor.NewAlertHook(command)
 	}
 	d.inputHandler = NewInputHandler(d)
+	d.running.Store(true)
 	return d
 }
 
-func (d *Display) Run() error {
-	var err error
-	d.screen, err = tcell.NewScreen()
-	if err != nil {
-		return fmt.Errorf("failed to create screen: %w", err)
+func (d *Display) Run() (err error) {
+	if d.screen == nil {
+		d.screen, err = newScreen(d.config.GetColorProfile())
+		if err != nil {
+			return fmt.Errorf("failed to create screen: %w", err)
+		}
 	}
 
 	if err = d.screen.Init(); err != nil {
 		return fmt.Errorf("failed to initialize screen: %w", err)
 	}
-	defer d.screen.Fini()
+	defer func() {
+		d.finiScreen()
+		if err == nil {
+			err = d.finiErr
+		}
+	}()
+	defer d.restoreOnPanic()
 
-	d.screen.SetStyle(tcell.StyleDefault.Background(d.colorScheme.Background).Foreground(d.colorScheme.Text))
+	d.screen.SetStyle(d.colorScheme.GetStyle(d.colorScheme.Text, false))
 	d.screen.Clear()
 
 	go d.updateLoop()
 	go d.inputLoop()
 
-	for {
-		d.mu.RLock()
-		running := d.running
-		d.mu.RUnlock()
-		if !running {
-			break
+	// An unchanged frame still costs a full redraw and tcell comparing
+	// every cell, so idle frames are skipped
+	d.dirty.Store(true)
+	var lastRender time.Time
+	for d.running.Load() {
+		if now := time.Now(); d.renderDue(now, lastRender) {
+			if err = d.render(); err != nil {
+				return err
+			}
+			d.ringBell()
+			lastRender = now
 		}
-		d.render()
 		time.Sleep(50 * time.Millisecond)
 	}
 
 	return nil
 }
 
+// idleRenderInterval bounds how long Run goes without rendering, so
+// status messages still expire on screen when nothing else changes
+const idleRenderInterval = time.Second
+
+// renderDue reports whether Run should render now: something changed, or
+// the last render was idleRenderInterval ago
+func (d *Display) renderDue(now, lastRender time.Time) bool {
+	return d.dirty.Swap(false) || now.Sub(lastRender) >= idleRenderInterval
+}
+
+// SelectOnStart selects and expands the first process matching name as soon
+// as it shows up in the list, retrying on every refresh until it does
+func (d *Display) SelectOnStart(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.selectName = name
+}
+
+// findProcess returns the index of the first process whose name or
+// executable equals name (case-insensitively), falling back to the first
+// one containing it; -1 if none match
+func findProcess(processes []*monitor.ProcessInfo, name string) int {
+	for i, proc := range processes {
+		if strings.EqualFold(proc.Name, name) || strings.EqualFold(proc.GroupName(), name) {
+			return i
+		}
+	}
+	lower := strings.ToLower(name)
+	for i, proc := range processes {
+		if strings.Contains(strings.ToLower(proc.Name), lower) || strings.Contains(strings.ToLower(proc.GroupName()), lower) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (d *Display) Stop() {
-	if d.stopped.Swap(true) {
+	if !d.running.CompareAndSwap(true, false) {
 		return // already stopped
 	}
-	d.mu.Lock()
-	d.running = false
-	d.mu.Unlock()
 	// Post an interrupt to unblock PollEvent in inputLoop
 	if d.screen != nil {
 		d.screen.PostEvent(tcell.NewEventInterrupt(nil))
 	}
 }
 
+// finiScreen restores the terminal, leaving any failure in finiErr; safe
+// to call more than once
+func (d *Display) finiScreen() {
+	d.finiOnce.Do(func() {
+		if d.screen != nil {
+			d.screen.Fini()
+		}
+		d.finiErr = d.restoreTitle()
+	})
+}
+
+// restoreOnPanic restores the terminal before letting a panic propagate, so a
+// crash never leaves the shell in raw mode. Deferred at the top of every
+// goroutine that touches the screen.
+func (d *Display) restoreOnPanic() {
+	if r := recover(); r != nil {
+		d.finiScreen()
+		panic(r)
+	}
+}
+
 func (d *Display) updateLoop() {
+	defer d.restoreOnPanic()
+
 	ticker := time.NewTicker(d.config.GetRefreshRate())
 	defer ticker.Stop()
 
+	// With --interval-align the ticker is held until aligned fires at the
+	// next wall-clock boundary and restarts it there, so its ticks stay on
+	// the boundaries after that
+	var aligned <-chan time.Time
+	restart := func(rate time.Duration) {
+		if d.config.GetIntervalAlign() {
+			ticker.Stop()
+			aligned = time.After(AlignedDelay(time.Now(), rate))
+			return
+		}
+		ticker.Reset(rate)
+	}
+	restart(d.config.GetRefreshRate())
+
+	// Take the priming sample right away so quiet start only hides one interval
+	if d.config.GetQuietStart() {
+		d.updateProcesses()
+	}
+
 	for {
-		<-ticker.C
+		force := false
+		select {
+		case <-ticker.C:
+		case <-aligned:
+			aligned = nil
+			ticker.Reset(d.config.GetRefreshRate())
+		case <-d.refreshRequests:
+			force = true
+		case <-d.refreshRateChanged:
+			restart(d.config.GetRefreshRate())
+			continue
+		}
+		if !d.running.Load() {
+			return
+		}
 		d.mu.RLock()
-		running := d.running
 		paused := d.paused
-		force := d.forceRefresh
 		d.mu.RUnlock()
-		if !running {
-			return
-		}
 		if !paused || force {
+			start := time.Now()
 			d.updateProcesses()
-			d.mu.Lock()
-			d.forceRefresh = false
-			d.mu.Unlock()
+			took := time.Since(start)
+			d.updateTime.Store(int64(took))
+
+			// A refresh that outlasted the interval left a tick queued;
+			// running it at once would scan back to back and pin a core,
+			// so drop it and wait a full interval from now instead
+			// (or the next boundary, when aligned)
+			if rate := d.config.GetRefreshRate(); took >= rate {
+				select {
+				case <-ticker.C:
+				default:
+				}
+				restart(rate)
+			}
 		}
 	}
 }
 
+// AlignedDelay is how long from now until the next wall-clock multiple of
+// interval, e.g. the top of the next second for 1s or the next :00/:05/...
+// for 5s, so samples from several hosts line up
+func AlignedDelay(now time.Time, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	return now.Truncate(interval).Add(interval).Sub(now)
+}
+
 func (d *Display) inputLoop() {
-	for {
-		d.mu.RLock()
-		running := d.running
-		d.mu.RUnlock()
-		if !running {
-			return
-		}
+	defer d.restoreOnPanic()
 
+	for d.running.Load() {
 		ev := d.screen.PollEvent()
 		if ev == nil {
 			return
@@ -152,14 +441,20 @@ func (d *Display) inputLoop() {
 		case *tcell.EventInterrupt:
 			return
 		case *tcell.EventResize:
-			d.screen.Sync()
+			d.resized.Store(true)
 		}
+		d.dirty.Store(true)
 	}
 }
 
 func (d *Display) updateProcesses() {
 	processes, err := d.monitor.GetFilteredProcesses()
 	if err != nil {
+		// Keep showing the last data; a remote source explains its
+		// disconnects here on every refresh until it's back
+		d.mu.Lock()
+		d.setStatus("⚠ " + err.Error())
+		d.mu.Unlock()
 		return
 	}
 
@@ -168,28 +463,98 @@ func (d *Display) updateProcesses() {
 		systemMetrics = nil
 	}
 
+	// Quiet start discards samples until CPU percentages are meaningful
+	if d.config.GetQuietStart() && !d.monitor.IsPrimed() {
+		return
+	}
+
 	d.mu.Lock()
+	d.measuring = false
+	d.refreshTicks++
+	d.lastUpdate = time.Now()
+	d.timings = d.monitor.LastScanTimings()
+	d.tasks = d.monitor.LastTaskCounts()
+	d.recordTaskCount(d.tasks.Total)
+	processes = d.filter.apply(processes, d.invertFilter)
 	d.processes = processes
-	d.systemMetrics = systemMetrics
+	if d.frozenOrder != nil {
+		d.processes = applyFrozenOrder(processes, d.frozenOrder)
+		d.frozenOrder = rowOrder(d.processes)
+	}
+	d.maxCPU = 0
+	for _, proc := range processes {
+		if proc.CPUPercent > d.maxCPU {
+			d.maxCPU = proc.CPUPercent
+		}
+	}
+	d.stuck = d.monitor.StuckProcesses()
+	for _, alert := range d.monitor.LastAlerts() {
+		d.setStatus("⚠ " + alert.String())
+		if d.alertHook != nil {
+			d.alertHook.Fire(alert)
+		}
+	}
+	missing := d.monitor.LastMissingCritical()
+	for _, name := range missing {
+		d.setStatus(fmt.Sprintf("⚠ Critical process %s is not running", name))
+	}
+	if len(missing) > 0 && d.config.GetCriticalBell() {
+		d.bell.Store(true)
+	}
+	if d.alertHook != nil {
+		if err := d.alertHook.Err(); err != nil {
+			d.setStatus(fmt.Sprintf("Alert command failed: %v", err))
+		}
+	}
+	// Ballooned or hotplugged RAM changes the total between refreshes;
+	// everything derived from it is recomputed from the new snapshot, but
+	// say so, since the percentages jump
+	if note := memoryTotalChange(d.systemMetrics, systemMetrics); note != "" {
+		d.setStatus(note)
+	}
+	d.prevMetrics, d.systemMetrics = d.systemMetrics, systemMetrics
+	if d.selectName != "" {
+		if i := findProcess(d.processes, d.selectName); i >= 0 {
+			d.selectedIndex = i
+			if !d.processes[i].Expanded {
+				d.monitor.ToggleExpanded(d.processes[i].PID)
+			}
+			d.selectName = ""
+		}
+	}
+	d.clampSelection()
+	d.mu.Unlock()
+
+	d.refreshDetail()
+	d.dirty.Store(true)
+}
+
+// clampSelection keeps selectedIndex and scrollOffset within d.processes.
+// Anything that shrinks the list must call it before releasing d.mu, or
+// input and rendering in between see a selection past the end.
+func (d *Display) clampSelection() {
 	if d.selectedIndex >= len(d.processes) {
 		d.selectedIndex = len(d.processes) - 1
 	}
 	if d.selectedIndex < 0 {
 		d.selectedIndex = 0
 	}
+	// Don't leave the viewport scrolled past the end of a shorter list
+	if last := len(d.processes) - int(d.viewRows.Load()); d.scrollOffset > last {
+		d.scrollOffset = last
+	}
 	d.adjustScrollOffset()
-	d.mu.Unlock()
 }
 
-// adjustScrollOffset ensures the selected item is visible on screen
+// adjustScrollOffset ensures the selected item is visible on screen. It uses
+// the row count from the last render rather than querying the screen, since
+// it runs on the update and input goroutines.
 func (d *Display) adjustScrollOffset() {
-	if d.screen == nil {
+	maxRows := int(d.viewRows.Load())
+	if maxRows <= 0 {
 		return
 	}
 
-	_, height := d.screen.Size()
-	maxRows := height - headerRows - footerRows
-
 	// Ensure scrollOffset keeps selected item visible
 	if d.selectedIndex < d.scrollOffset {
 		// Selected item is above viewport, scroll up
@@ -205,21 +570,67 @@ func (d *Display) adjustScrollOffset() {
 	}
 }
 
-func (d *Display) render() {
+// render draws one frame; its error is why the window title couldn't be set
+func (d *Display) render() error {
+	start := time.Now()
+	defer func() { d.renderTime.Store(int64(time.Since(start))) }()
+
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
+	if d.resized.Swap(false) {
+		d.screen.Sync()
+	}
+
 	d.screen.Clear()
 	width, height := d.screen.Size()
+	d.viewRows.Store(int32(height - d.processTop() - footerRows))
 
 	// Draw main border
 	d.drawBorder(0, 0, width, height)
 
-	d.renderHeader(width)
-	d.renderProcesses(width, height)
+	if d.config.GetSummaryOnly() {
+		d.renderSummary(width, height)
+	} else {
+		d.renderHeader(width)
+		d.renderProcesses(width, height)
+	}
 	d.renderFooter(width, height)
 
+	if d.settingsOpen {
+		d.renderSettings(width, height)
+	}
+	if d.detailOpen {
+		d.renderDetail(width, height)
+	}
+	if d.legendOpen {
+		d.renderLegend(width, height)
+	}
+	if d.taskGraphOpen {
+		d.renderTaskGraph(width, height)
+	}
+	if d.signalOpen {
+		d.renderSignalPrompt(width, height)
+	}
+	if d.helpOpen {
+		d.renderHelp(width, height)
+	}
+
 	d.screen.Show()
+	return d.updateTitle()
+}
+
+// ringBell beeps for --critical-bell if a refresh asked for it. A terminal
+// that can't beep still has the footer warning, which then says why.
+func (d *Display) ringBell() {
+	if !d.bell.Swap(false) {
+		return
+	}
+	if err := d.screen.Beep(); err != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.setStatus(fmt.Sprintf("%s (bell failed: %v)", d.statusMessage, err))
+	}
 }
 
 func (d *Display) renderHeader(width int) {
@@ -231,88 +642,200 @@ func (d *Display) renderHeader(width int) {
 		statusColor = d.colorScheme.Warning
 	}
 
-	headerText := fmt.Sprintf("⚙️  brieftop - Processes >%.1f%% CPU or >%dMB RAM",
-		d.config.GetCPUThreshold(), d.config.GetMemoryThreshold()/(1024*1024))
+	headerText := "⚙️  " + headerTitle(d.config)
+	if d.baseline != nil {
+		headerText += " · vs baseline " + d.config.FormatTime(d.baseline.at)
+	}
+	if d.frozenOrder != nil {
+		headerText += " · sort frozen"
+	}
+	if d.config.GetCPUDelta() {
+		headerText += " · CPU Δ since last refresh"
+	}
+	if scope := scopeText(d.config); scope != "" {
+		headerText += " · " + scope
+	}
 
 	// Main header (Line 1)
 	d.drawText(2, 1, width-4, headerText, d.colorScheme.GetStyle(d.colorScheme.Header, false))
 
 	// Status indicator
-	statusX := width - len(status) - 3
-	d.drawText(statusX, 1, len(status), status, d.colorScheme.GetStyle(statusColor, false))
+	statusX := width - len([]rune(status)) - 3
+	d.drawText(statusX, 1, width-2, status, d.colorScheme.GetStyle(statusColor, false))
+
+	// Refresh spinner advances once per update, grayed out while paused
+	spinnerColor := d.colorScheme.Accent
+	if d.paused {
+		spinnerColor = d.colorScheme.Muted
+	}
+	d.drawText(statusX-2, 1, width-2, GetSpinnerFrame(d.refreshTicks), d.colorScheme.GetStyle(spinnerColor, false))
+
+	// Scrolled down with --collapse-header, the column headings move up
+	// under the title and the metrics lines go to process rows
+	top := d.processTop()
 
 	// System metrics (Lines 2-4) if available
-	if d.systemMetrics != nil {
+	if top == processStartY && d.systemMetrics != nil {
 		// CPU line (Line 2)
-		cpuBar := CreateProgressBar(d.systemMetrics.CPUPercent, 20)
+		cpuBar := CreateProgressBar(d.systemMetrics.CPUPercent, headerBarWidth, d.config.GetBarGlyphs())
 		cpuColor := d.colorScheme.GetProgressBarColor(d.systemMetrics.CPUPercent)
 
 		d.drawText(2, 2, width-2, "CPU:  ", d.colorScheme.GetStyle(d.colorScheme.Text, false))
-		d.drawText(8, 2, width-2, cpuBar, d.colorScheme.GetStyle(cpuColor, false))
-		remainingCPU := fmt.Sprintf(" %.1f%% (%d cores)", d.systemMetrics.CPUPercent, d.systemMetrics.CPUCores)
-		d.drawText(8+len(cpuBar), 2, width-2, remainingCPU, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+		d.drawText(headerBarX, 2, width-2, cpuBar, d.colorScheme.GetStyle(cpuColor, false))
+		remainingCPU := " " + cpuDetails(d.systemMetrics, d.config.GetPrecision())
+		d.drawText(headerBarX+headerBarWidth, 2, width-2, remainingCPU, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+		cpuEnd := headerBarX + headerBarWidth + len([]rune(remainingCPU))
+		cpuEnd += d.drawMetricDelta(cpuEnd, 2, width-2, func(m *monitor.SystemMetrics) float64 { return m.CPUPercent })
+
+		// Task summary, right-aligned on the CPU line where there's room
+		if d.tasks.Total > 0 {
+			tasksText := d.tasks.String()
+			tasksX := width - len([]rune(tasksText)) - 3
+			if tasksX > cpuEnd+2 {
+				d.drawText(tasksX, 2, width-2, tasksText, d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+			}
+		}
 
 		// Memory line (Line 3)
-		memBar := CreateProgressBar(d.systemMetrics.MemoryPercent, 20)
+		memBar := CreateProgressBar(d.systemMetrics.MemoryPercent, headerBarWidth, d.config.GetBarGlyphs())
 		memColor := d.colorScheme.GetProgressBarColor(d.systemMetrics.MemoryPercent)
-		usedGB := monitor.FormatBytes(d.systemMetrics.MemoryUsed)
-		totalGB := monitor.FormatBytes(d.systemMetrics.MemoryTotal)
-		availGB := monitor.FormatBytes(d.systemMetrics.MemoryAvailable)
-
 		d.drawText(2, 3, width-2, "MEM:  ", d.colorScheme.GetStyle(d.colorScheme.Text, false))
-		d.drawText(8, 3, width-2, memBar, d.colorScheme.GetStyle(memColor, false))
-
-		// Build memory details - only show cache/buffers if non-zero
-		memDetails := fmt.Sprintf(" %s/%s (%.1f%%)  │ Available: %s",
-			usedGB, totalGB, d.systemMetrics.MemoryPercent, availGB)
-
-		if d.systemMetrics.MemoryCached > 0 {
-			cacheGB := monitor.FormatBytes(d.systemMetrics.MemoryCached)
-			memDetails += fmt.Sprintf("  Cached: %s", cacheGB)
-		}
-		if d.systemMetrics.MemoryBuffers > 0 {
-			buffersGB := monitor.FormatBytes(d.systemMetrics.MemoryBuffers)
-			memDetails += fmt.Sprintf("  Buffers: %s", buffersGB)
+		if d.config.GetStackedMemory() {
+			d.drawStackedMemoryBar(headerBarX, 3, width-2, headerBarWidth)
+		} else {
+			d.drawText(headerBarX, 3, width-2, memBar, d.colorScheme.GetStyle(memColor, false))
 		}
 
-		d.drawText(8+len(memBar), 3, width-2, memDetails, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+		// The delta goes right after the percentage, before the breakdown
+		memX := headerBarX + headerBarWidth
+		if d.systemMetrics.MemoryTotal == 0 {
+			d.drawText(memX, 3, width-2, " "+memoryDetails(d.systemMetrics, d.config.GetPrecision()), d.colorScheme.GetStyle(d.colorScheme.Text, false))
+		} else {
+			memUsage := " " + memoryUsage(d.systemMetrics, d.config.GetPrecision())
+			d.drawText(memX, 3, width-2, memUsage, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+			memX += len([]rune(memUsage))
+			memX += d.drawMetricDelta(memX, 3, width-2, func(m *monitor.SystemMetrics) float64 { return m.MemoryPercent })
+			d.drawText(memX, 3, width-2, memoryBreakdown(d.systemMetrics), d.colorScheme.GetStyle(d.colorScheme.Text, false))
+		}
 
 		// Swap line (Line 4)
+		swapEnd := 0
 		if d.systemMetrics.SwapTotal > 0 {
-			swapBar := CreateProgressBar(d.systemMetrics.SwapPercent, 20)
+			swapBar := CreateProgressBar(d.systemMetrics.SwapPercent, headerBarWidth, d.config.GetBarGlyphs())
 			swapColor := d.colorScheme.GetProgressBarColor(d.systemMetrics.SwapPercent)
-			swapUsedGB := monitor.FormatBytes(d.systemMetrics.SwapUsed)
-			swapTotalGB := monitor.FormatBytes(d.systemMetrics.SwapTotal)
+			swapText := " " + swapDetails(d.systemMetrics, d.config.GetPrecision())
 
 			d.drawText(2, 4, width-2, "SWAP: ", d.colorScheme.GetStyle(d.colorScheme.Text, false))
-			d.drawText(8, 4, width-2, swapBar, d.colorScheme.GetStyle(swapColor, false))
-			swapDetails := fmt.Sprintf(" %s/%s (%.1f%%)", swapUsedGB, swapTotalGB, d.systemMetrics.SwapPercent)
-			d.drawText(8+len(swapBar), 4, width-2, swapDetails, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+			d.drawText(headerBarX, 4, width-2, swapBar, d.colorScheme.GetStyle(swapColor, false))
+			d.drawText(headerBarX+headerBarWidth, 4, width-2, swapText, d.colorScheme.GetStyle(d.colorScheme.Text, false))
+			swapEnd = headerBarX + headerBarWidth + len([]rune(swapText))
 		} else {
 			swapText := "SWAP: Disabled"
 			d.drawText(2, 4, width-2, swapText, d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+			swapEnd = 2 + len([]rune(swapText))
+		}
+
+		// Pressure stall info, right-aligned on the swap line (Linux)
+		if d.systemMetrics.Pressure != nil {
+			d.drawPressure(swapEnd, 4, width)
 		}
 	}
+	if top == onelineStartY && d.systemMetrics != nil {
+		d.drawOnelineHeader(2, width)
+	}
 
-	// Separator line (Line 5)
-	d.drawHorizontalLine(2, 5, width-4, "─", d.colorScheme.Border)
+	// Separator line (Line 5), replaced by a warning while processes are stuck
+	if top == processStartY {
+		d.drawHorizontalLine(2, 5, width-4, "─", d.colorScheme.Border)
+		if len(d.stuck) > 0 {
+			d.drawText(4, 5, width-4, " "+stuckWarning(d.stuck)+" ", d.colorScheme.GetStyle(d.colorScheme.Error, false))
+		}
+	}
 
 	// Column headers aligned with process data format strings
-	columnHeaders := fmt.Sprintf("  %-7s %8s %12s %5s  %s",
-		"PID", "CPU", "MEMORY", "CHILD", "PROCESS NAME")
-	d.drawText(borderPadding, 6, width-borderPadding*2, columnHeaders, d.colorScheme.GetStyle(d.colorScheme.Accent, false))
+	d.drawText(borderPadding, top-2, width-borderPadding*2, panText(columnHeaderLine(d.config, d.columns()), d.hOffset), d.colorScheme.GetStyle(d.colorScheme.Accent, false))
+	if d.columnMode {
+		d.renderColumnSelection(width)
+	}
 
 	// Header separator (Line 7)
-	d.drawHorizontalLine(2, 7, width-4, "━", d.colorScheme.Border)
+	d.drawHorizontalLine(2, top-1, width-4, "━", d.colorScheme.Border)
+}
+
+// processTop is the first process row: below the full header, or right
+// under the title and column headings while --collapse-header has hidden
+// the system metrics because the list is scrolled down. Callers must hold
+// d.mu.
+func (d *Display) processTop() int {
+	if d.config.GetCollapseHeader() && d.scrollOffset > 0 {
+		return collapsedStartY
+	}
+	if d.onelineHeader() {
+		return onelineStartY
+	}
+	return processStartY
 }
 
 func (d *Display) renderProcesses(width, height int) {
-	maxRows := height - headerRows - footerRows
-	currentY := processStartY
+	top := d.processTop()
+	maxRows := height - top - footerRows
+	currentY := top
+
+	d.tableOverflow.Store(0)
+	if d.measuring {
+		d.drawText(processXOffset, currentY, width-processXOffset*2, "measuring…",
+			d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+		return
+	}
+
+	if hosts, ok := d.monitor.(hostSource); ok {
+		d.renderHosts(width, maxRows, hosts.Hosts())
+		return
+	}
+	if d.categoryView {
+		d.renderCategories(width, maxRows, "CATEGORY",
+			monitor.SummarizeCategories(d.processes, d.config.GetGroupSort(), d.config.GetSortMode()))
+		return
+	}
+	if d.containerView {
+		d.renderCategories(width, maxRows, "CONTAINER",
+			monitor.SummarizeContainers(d.processes, d.config.GetGroupSort(), d.config.GetSortMode()))
+		return
+	}
+
+	// Rows are panned left by hOffset; names get that much more room so
+	// panning reveals what would otherwise be truncated
+	widest := 0
+	defer func() {
+		d.tableOverflow.Store(int32(widest - (width - processXOffset*3)))
+	}()
+	drawRow := func(line string, style tcell.Style) {
+		if n := len([]rune(line)); n > widest {
+			widest = n
+		}
+		d.drawText(processXOffset, currentY, width-processXOffset*2, panText(line, d.hOffset), style)
+		currentY++
+	}
+
+	// An empty list looks broken; say why it's empty and how to widen it
+	if len(d.processes) == 0 && d.monitor.IsPrimed() {
+		message := emptyListMessage(d.config, keyLabel(d.inputHandler.bindings, "settings"))
+		if d.filter != nil {
+			message = fmt.Sprintf("No processes %s — press %s to change the filter", d.filterDescription(), keyLabel(d.inputHandler.bindings, "filter"))
+		}
+		x := max((width-len([]rune(message)))/2, processXOffset)
+		d.drawText(x, top+maxRows/2, width-processXOffset*2, message, d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+		return
+	}
+
+	var heaviest topRows
+	if d.config.GetHighlightTop() {
+		heaviest = findTopRows(d.processes)
+	}
 
 	// Render processes starting from scrollOffset
 	for i := d.scrollOffset; i < len(d.processes); i++ {
-		if currentY >= processStartY+maxRows {
+		if currentY >= top+maxRows {
 			break
 		}
 
@@ -321,51 +844,77 @@ func (d *Display) renderProcesses(width, height int) {
 		childCount := len(proc.Children)
 
 		// Enhanced status icon
-		statusIcon := GetStatusIcon(proc.CPUPercent, proc.Expanded, childCount > 0)
+		statusIcon := GetStatusIcon(proc.CPUPercent, proc.Expanded, childCount+proc.TreeChildren > 0, d.config.GetIconThresholds())
 
-		// Color based on resource usage
+		// Color based on resource usage, or the change since the baseline;
+		// thrashing and D state override it, and exited rows are grayed out
 		level := d.monitor.GetResourceLevel(proc.CPUPercent, proc.MemoryMB)
 		color := d.colorScheme.GetProcessColor(level)
+		if d.baseline != nil {
+			color = d.colorScheme.baselineColor(d.baseline.compare(proc))
+		}
+		if proc.IsThrashing() {
+			color = d.colorScheme.Warning
+		}
+		if proc.IsBlocked() {
+			color = d.colorScheme.Error
+		}
+		if proc.Exited {
+			color = d.colorScheme.Muted
+		}
 		style := d.colorScheme.GetStyle(color, isSelected)
 
+		// Bold rather than a color of its own, so the row keeps its usage
+		// color and the selection background still shows
+		marker := heaviest.marker(proc)
+		if marker != "" {
+			style = style.Bold(true)
+		}
+		if proc.Critical {
+			marker = criticalMarker + marker
+			style = style.Underline(true)
+		}
+
 		// Calculate available space for name
-		availableNameWidth := width - fixedColumnWidth - processXOffset*2
+		availableNameWidth := width + d.hOffset - fixedColumnWidth - processXOffset*2
 		if availableNameWidth < minNameWidth {
 			availableNameWidth = minNameWidth
 		}
 
-		// Main process line — columns: icon PID CPU% MEM CHILD NAME
-		processLine := fmt.Sprintf("%s %-7d %7.1f%% %10.1fMB %5d  %s",
-			statusIcon, proc.PID, proc.CPUPercent, proc.MemoryMB, childCount,
-			truncateString(proc.Name, availableNameWidth))
+		processLine := formatProcessLine(statusIcon, marker, proc, d.columns(), availableNameWidth)
 
-		d.drawText(processXOffset, currentY, width-processXOffset*2, processLine, style)
-		currentY++
+		if d.config.GetHeatColumn() {
+			d.drawHeat(currentY, level)
+		}
+		drawRow(processLine, style)
 
 		if proc.Expanded && childCount > 0 {
 			// First show the parent process itself
-			if currentY < processStartY+maxRows {
+			if currentY < top+maxRows {
 				parentPrefix := "    ├─●" // Parent indicator
 				parentStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
 
-				availableParentNameWidth := width - fixedColumnWidth - processXOffset*2 - 8
+				availableParentNameWidth := width + d.hOffset - fixedColumnWidth - processXOffset*2 - 8
 				if availableParentNameWidth < minChildNameW {
 					availableParentNameWidth = minChildNameW
 				}
 
-				parentLine := fmt.Sprintf("%s %-6d %7.1f%% %10.1fMB       %s (parent)",
-					parentPrefix, proc.PID, proc.ParentCPU, float64(proc.ParentMemory)/(1024*1024),
-					truncateString(proc.Name, availableParentNameWidth-9))
+				parentLine := formatParentLine(parentPrefix, proc, d.columns(), availableParentNameWidth)
 
-				d.drawText(processXOffset, currentY, width-processXOffset*2, parentLine, parentStyle)
-				currentY++
+				drawRow(parentLine, parentStyle)
 			}
 
 			// Then show all children
-			for _, child := range proc.Children {
-				if currentY >= processStartY+maxRows {
+			showThreads := d.config.GetShowThreads()
+			collapseThreads := d.config.GetCollapseThreads()
+			children := monitor.SortChildren(proc.Children, d.config.GetChildSort(), d.config.GetSortMode())
+			for _, child := range children {
+				if currentY >= top+maxRows {
 					break
 				}
+				if child.IsThread && (!showThreads || collapseThreads) {
+					continue
+				}
 
 				// Visual indicators for different types
 				var prefix string
@@ -381,56 +930,582 @@ func (d *Display) renderProcesses(width, height int) {
 					childStyle = d.colorScheme.GetStyle(d.colorScheme.ChildProcess, false)
 					typeLabel = "child"
 				}
+				if child.IsThrashing() {
+					childStyle = d.colorScheme.GetStyle(d.colorScheme.Warning, false)
+					typeLabel += ", thrashing"
+				}
+				if child.Blocked {
+					childStyle = d.colorScheme.GetStyle(d.colorScheme.Error, false)
+					typeLabel += ", D state"
+				}
 
-				availableChildNameWidth := width - fixedColumnWidth - processXOffset*2 - 12
+				availableChildNameWidth := width + d.hOffset - fixedColumnWidth - processXOffset*2 - 12
 				if availableChildNameWidth < minChildNameW {
 					availableChildNameWidth = minChildNameW
 				}
 
-				childLine := fmt.Sprintf("%s %-6d %7.1f%% %10.1fMB       %s (%s)",
-					prefix, child.PID, child.CPUPercent, float64(child.MemoryBytes)/(1024*1024),
-					truncateString(child.Name, availableChildNameWidth-len(typeLabel)-3), typeLabel)
+				childLine := formatChildLine(prefix, child, typeLabel, d.columns(), availableChildNameWidth)
 
-				d.drawText(processXOffset, currentY, width-processXOffset*2, childLine, childStyle)
-				currentY++
+				drawRow(childLine, childStyle)
+			}
+
+			// Collapsed threads still show what they contribute
+			if showThreads && collapseThreads && currentY < top+maxRows {
+				if count, total := threadSummary(proc.Children); count > 0 {
+					summaryLine := formatThreadSummaryLine("    ╠═", count, total, d.columns())
+					drawRow(summaryLine, d.colorScheme.GetStyle(d.colorScheme.Thread, false))
+				}
 			}
 		}
 	}
 }
 
+// headerTitle describes the active thresholds. The memory threshold goes
+// through FormatBytes rather than whole MB so it shows the real filter
+// boundary when it isn't a round number.
+func headerTitle(config ConfigInterface) string {
+	if config.GetTreeView() {
+		return "brieftop - All processes as a tree (own usage, not summed)"
+	}
+	return fmt.Sprintf("brieftop - Processes >%.1f%% CPU or >%s RAM · sort: %s",
+		config.GetCPUThreshold(), monitor.FormatBytes(config.GetMemoryThreshold()), config.GetSortMode())
+}
+
+// scopeText names the session or process group --scope lists, or "" when
+// every process is listed
+func scopeText(cfg ConfigInterface) string {
+	switch scope, id := cfg.GetScope(); scope {
+	case config.ScopeSession:
+		return fmt.Sprintf("session %d only", id)
+	case config.ScopePGroup:
+		return fmt.Sprintf("process group %d only", id)
+	}
+	return ""
+}
+
+// emptyListMessage explains an empty process list, naming the key that
+// opens the settings overlay where the thresholds are adjusted
+func emptyListMessage(config ConfigInterface, settingsKey string) string {
+	message := fmt.Sprintf("No processes above thresholds (CPU>%.1f%%, MEM>%s)",
+		config.GetCPUThreshold(), monitor.FormatBytes(config.GetMemoryThreshold()))
+	if settingsKey != "" {
+		message += " — press " + settingsKey + " to adjust"
+	}
+	return message
+}
+
+func cpuDetails(m *monitor.SystemMetrics, precision int) string {
+	return fmt.Sprintf("%.*f%% (%d cores)", precision, m.CPUPercent, m.CPUCores)
+}
+
+// memoryDetails summarizes memory usage, only showing cache/buffers if non-zero
+func memoryDetails(m *monitor.SystemMetrics, precision int) string {
+	if m.MemoryTotal == 0 {
+		return "N/A (total not reported)"
+	}
+	return memoryUsage(m, precision) + memoryBreakdown(m)
+}
+
+// memoryUsage is the used/total part of memoryDetails, e.g. "5.2 GB/16.0 GB (32.5%)"
+func memoryUsage(m *monitor.SystemMetrics, precision int) string {
+	return fmt.Sprintf("%s/%s (%.*f%%)",
+		monitor.FormatBytes(m.MemoryUsed), monitor.FormatBytes(m.MemoryTotal), precision, m.MemoryPercent)
+}
+
+// memoryBreakdown is the rest of memoryDetails: available memory, then
+// cache and buffers if non-zero
+func memoryBreakdown(m *monitor.SystemMetrics) string {
+	details := fmt.Sprintf("  │ Available: %s", monitor.FormatBytes(m.MemoryAvailable))
+	if m.MemoryCached > 0 {
+		details += fmt.Sprintf("  Cached: %s", monitor.FormatBytes(m.MemoryCached))
+	}
+	if m.MemoryBuffers > 0 {
+		details += fmt.Sprintf("  Buffers: %s", monitor.FormatBytes(m.MemoryBuffers))
+	}
+	return details
+}
+
+// metricDelta formats the change from prev to cur, both percentages, as an
+// arrow and the points moved, e.g. "▲3" — with its direction, 1 or -1. A
+// change that rounds away at precision gives "" and 0.
+func metricDelta(prev, cur float64, precision int) (string, int) {
+	delta := cur - prev
+	if math.Abs(delta) < 0.5*math.Pow10(-precision) {
+		return "", 0
+	}
+	if delta > 0 {
+		return fmt.Sprintf("▲%.*f", precision, delta), 1
+	}
+	return fmt.Sprintf("▼%.*f", precision, -delta), -1
+}
+
+// drawMetricDelta draws, with --header-deltas, how far the percentage
+// picked by percent moved since the previous refresh at x, y: red when
+// rising, green when falling. It returns the columns used, 0 when there's
+// nothing to show. Callers must hold d.mu.
+func (d *Display) drawMetricDelta(x, y, maxX int, percent func(*monitor.SystemMetrics) float64) int {
+	if !d.config.GetHeaderDeltas() || d.prevMetrics == nil || d.systemMetrics == nil {
+		return 0
+	}
+	delta, dir := metricDelta(percent(d.prevMetrics), percent(d.systemMetrics), d.config.GetPrecision())
+	if dir == 0 {
+		return 0
+	}
+	color := d.colorScheme.Error
+	if dir < 0 {
+		color = d.colorScheme.Success
+	}
+	text := " " + delta
+	d.drawText(x, y, maxX, text, d.colorScheme.GetStyle(color, false))
+	return len([]rune(text))
+}
+
+// memoryTotalChange describes a change in system RAM between two snapshots,
+// or returns "" if it didn't change or either total is unknown
+func memoryTotalChange(prev, cur *monitor.SystemMetrics) string {
+	if prev == nil || cur == nil || prev.MemoryTotal == 0 || cur.MemoryTotal == 0 || prev.MemoryTotal == cur.MemoryTotal {
+		return ""
+	}
+	return fmt.Sprintf("RAM total changed %s → %s", monitor.FormatBytes(prev.MemoryTotal), monitor.FormatBytes(cur.MemoryTotal))
+}
+
+func swapDetails(m *monitor.SystemMetrics, precision int) string {
+	return fmt.Sprintf("%s/%s (%.*f%%)",
+		monitor.FormatBytes(m.SwapUsed), monitor.FormatBytes(m.SwapTotal), precision, m.SwapPercent)
+}
+
+// cpuBarWidth is the width of the inline CPU bar column
+const cpuBarWidth = 10
+
+// columns describes the optional columns (CPU bar, MEM%, TIME, AVG%, TTY); the
+// zero value hides them all
+type columns struct {
+	cpuBar     config.CPUBar
+	cpuScale   float64  // CPU % that fills the bar
+	memPercent bool     // Share of system RAM
+	cpuDelta   bool     // Top-level CPU shows the change since the previous refresh
+	memTotal   uint64   // System memory total; 0 if unknown
+	cpuTime    bool     // Cumulative CPU time
+	avgCPU     bool     // CPU averaged since process start
+	faults     bool     // Major page faults per second
+	precision  int      // Decimal places for CPU and memory values
+	tty        bool     // Controlling terminal
+	order      []string // Display order of the columns above; nil for config.DefaultColumnOrder
+	barGlyphs  config.BarGlyphs
+}
+
+// cellValues are one row's values for the optional columns
+type cellValues struct {
+	cpu        float64
+	memory     uint64
+	cpuSeconds float64
+	avgCPU     float64
+	faultRate  float64
+	tty        string // "" for rows without one (children, summaries)
+}
+
+// columns returns the optional column settings for the current snapshot
+func (d *Display) columns() columns {
+	cols := columns{cpuBar: d.config.GetCPUBar(), cpuScale: 100, memPercent: d.config.GetShowMemPercent(), cpuDelta: d.config.GetCPUDelta(), cpuTime: d.config.GetShowCPUTime(), avgCPU: d.config.GetShowAvgCPU(), faults: d.config.GetShowFaults(), tty: d.config.GetShowTTY(), precision: d.config.GetPrecision(), order: d.config.GetColumnOrder(), barGlyphs: d.config.GetBarGlyphs()}
+	if cols.cpuBar == config.CPUBarRelative {
+		cols.cpuScale = d.maxCPU
+	}
+	if d.systemMetrics != nil {
+		cols.memTotal = d.systemMetrics.MemoryTotal
+	}
+	return cols
+}
+
+// columnOrder returns the display order of the optional columns
+func (c columns) columnOrder() []string {
+	if c.order == nil {
+		return config.DefaultColumnOrder
+	}
+	return c.order
+}
+
+// shown reports whether the named optional column is turned on
+func (c columns) shown(name string) bool {
+	switch name {
+	case config.ColumnCPUBar:
+		return c.cpuBar != config.CPUBarOff
+	case config.ColumnMemPercent:
+		return c.memPercent
+	case config.ColumnCPUTime:
+		return c.cpuTime
+	case config.ColumnAvgCPU:
+		return c.avgCPU
+	case config.ColumnFaults:
+		return c.faults
+	case config.ColumnTTY:
+		return c.tty
+	}
+	return false
+}
+
+// visible returns the optional columns that are turned on, in display order
+func (c columns) visible() []string {
+	var names []string
+	for _, name := range c.columnOrder() {
+		if c.shown(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c columns) header() string {
+	var header string
+	for _, name := range c.columnOrder() {
+		header += c.headerCell(name)
+	}
+	return header
+}
+
+// headerCell renders one optional column's heading, or "" if it's off
+func (c columns) headerCell(name string) string {
+	if !c.shown(name) {
+		return ""
+	}
+	switch name {
+	case config.ColumnCPUBar:
+		label := "CPU BAR"
+		if c.cpuBar == config.CPUBarRelative {
+			label = "CPU REL"
+		}
+		return fmt.Sprintf(" %-*s", cpuBarWidth, label)
+	case config.ColumnMemPercent:
+		return fmt.Sprintf(" %*s", c.percentWidth(), "MEM%")
+	case config.ColumnCPUTime:
+		return fmt.Sprintf(" %7s", "TIME")
+	case config.ColumnAvgCPU:
+		return fmt.Sprintf(" %*s", c.percentWidth(), "AVG%")
+	case config.ColumnFaults:
+		return fmt.Sprintf(" %7s", "MAJF/s")
+	case config.ColumnTTY:
+		return fmt.Sprintf(" %-7s", "TTY")
+	}
+	return ""
+}
+
+// headerSpan returns where the named column's heading starts within
+// header() and how wide it is, including its leading space
+func (c columns) headerSpan(name string) (start, width int) {
+	for _, n := range c.columnOrder() {
+		cell := len([]rune(c.headerCell(n)))
+		if n == name {
+			return start, cell
+		}
+		start += cell
+	}
+	return start, 0
+}
+
+// cells renders a row's optional columns in display order
+func (c columns) cells(v cellValues) string {
+	var cells string
+	for _, name := range c.columnOrder() {
+		switch name {
+		case config.ColumnCPUBar:
+			cells += c.barCell(v.cpu)
+		case config.ColumnMemPercent:
+			cells += c.memCell(v.memory)
+		case config.ColumnCPUTime:
+			cells += c.timeCell(v.cpuSeconds)
+		case config.ColumnAvgCPU:
+			cells += c.avgCell(v.avgCPU)
+		case config.ColumnFaults:
+			cells += c.faultCell(v.faultRate)
+		case config.ColumnTTY:
+			cells += c.ttyCell(v.tty)
+		}
+	}
+	return cells
+}
+
+// barCell renders cpu as a bar filled at cpuScale, so in relative mode the
+// busiest process is full and the rest are ranked against it
+func (c columns) barCell(cpu float64) string {
+	if c.cpuBar == config.CPUBarOff {
+		return ""
+	}
+	var percent float64
+	if c.cpuScale > 0 {
+		percent = cpu / c.cpuScale * 100
+	}
+	return " " + CreateProgressBar(percent, cpuBarWidth, c.barGlyphs)
+}
+
+// memCell renders bytes as a share of system RAM
+func (c columns) memCell(bytes uint64) string {
+	if !c.memPercent {
+		return ""
+	}
+	if c.memTotal == 0 {
+		return fmt.Sprintf(" %*s", c.percentWidth(), "-")
+	}
+	return fmt.Sprintf(" %*.*f%%", c.percentWidth()-1, c.precision, float64(bytes)/float64(c.memTotal)*100)
+}
+
+// timeCell renders cumulative CPU seconds
+func (c columns) timeCell(seconds float64) string {
+	if !c.cpuTime {
+		return ""
+	}
+	return fmt.Sprintf(" %7s", monitor.FormatDuration(time.Duration(seconds*float64(time.Second))))
+}
+
+// percentWidth is the width of the MEM% and AVG% columns, which grow with
+// the precision past one decimal
+func (c columns) percentWidth() int {
+	if c.precision > 1 {
+		return 5 + c.precision
+	}
+	return 6
+}
+
+// avgCell renders the CPU percentage averaged since process start
+func (c columns) avgCell(percent float64) string {
+	if !c.avgCPU {
+		return ""
+	}
+	return fmt.Sprintf(" %*.*f%%", c.percentWidth()-1, c.precision, percent)
+}
+
+// faultCell renders a major page fault rate
+func (c columns) faultCell(rate float64) string {
+	if !c.faults {
+		return ""
+	}
+	return fmt.Sprintf(" %7.0f", rate)
+}
+
+// ttyCell renders a terminal name; rows without one (children, summaries)
+// pass "" to keep the columns aligned
+func (c columns) ttyCell(tty string) string {
+	if !c.tty {
+		return ""
+	}
+	return fmt.Sprintf(" %-7s", truncateString(tty, 7))
+}
+
+func columnHeaderLine(config ConfigInterface, cols columns) string {
+	return fmt.Sprintf("%s%s %5s  %s", columnHeaderPrefix(config), cols.header(), "CHILD", "PROCESS NAME")
+}
+
+// columnHeaderPrefix is the fixed headings before the optional columns
+func columnHeaderPrefix(cfg ConfigInterface) string {
+	memoryHeader := "MEMORY"
+	if cfg.GetMemoryMetric() == config.MemoryMetricPSS {
+		memoryHeader = "PSS"
+	}
+	cpuHeader := "CPU"
+	if cfg.GetCPUDelta() {
+		cpuHeader = "ΔCPU"
+	}
+	return fmt.Sprintf("  %-7s %8s %12s", "PID", cpuHeader, memoryHeader)
+}
+
+// formatProcessLine renders a top-level row — columns: icon PID CPU% MEM [MEM%] CHILD NAME
+func formatProcessLine(statusIcon, marker string, proc *monitor.ProcessInfo, cols columns, nameWidth int) string {
+	name := treeIndent(proc.Depth) + marker + proc.Name + deeperLevelsNote(proc.DeeperLevels)
+	if proc.IsBlocked() {
+		name = blockedMarker + name
+	}
+	if proc.Exited {
+		name = exitedMarker + name
+	}
+	return fmt.Sprintf("%s %-7d %s %10.*fMB%s %5d  %s",
+		statusIcon, proc.PID, cols.cpuCell(proc), cols.precision, proc.MemoryMB, cols.cells(cellValues{cpu: proc.CPUPercent, memory: proc.MemoryBytes, cpuSeconds: proc.CPUSeconds, avgCPU: proc.AvgCPUPercent, faultRate: proc.MajorFaultRate, tty: proc.TTY}), len(proc.Children)+proc.TreeChildren,
+		truncateString(name, nameWidth))
+}
+
+// cpuCell is a top-level row's CPU reading, or with cpuDelta its change
+// since the previous refresh; rows that weren't listed then read "new"
+func (c columns) cpuCell(proc *monitor.ProcessInfo) string {
+	if !c.cpuDelta {
+		return fmt.Sprintf("%7.*f%%", c.precision, proc.CPUPercent)
+	}
+	if proc.PrevCPUPercent == nil {
+		return fmt.Sprintf("%8s", "new")
+	}
+	return fmt.Sprintf("%+7.*f%%", c.precision, proc.CPUPercent-*proc.PrevCPUPercent)
+}
+
+// treeIndent nests a --tree row under its parent
+func treeIndent(depth int) string {
+	if depth == 0 {
+		return ""
+	}
+	return strings.Repeat("  ", depth-1) + "└─ "
+}
+
+// deeperLevelsNote follows the name of a tree row whose descendants
+// --max-depth hides, so the cutoff doesn't read as a leaf
+func deeperLevelsNote(levels int) string {
+	switch levels {
+	case 0:
+		return ""
+	case 1:
+		return " … 1 more level"
+	}
+	return fmt.Sprintf(" … %d more levels", levels)
+}
+
+// blockedMarker prefixes the names of processes in uninterruptible sleep
+const blockedMarker = "[D] "
+
+// exitedMarker prefixes the names of exited processes kept by --linger
+const exitedMarker = "[exited] "
+
+// stuckWarning summarizes processes that have stayed in D state
+func stuckWarning(stuck []*monitor.ProcessInfo) string {
+	names := make([]string, 0, len(stuck))
+	for _, proc := range stuck {
+		names = append(names, fmt.Sprintf("%s(%d)", proc.Name, proc.PID))
+	}
+	return fmt.Sprintf("⚠ %d stuck in D state (I/O wait): %s", len(stuck), strings.Join(names, ", "))
+}
+
+// formatParentLine renders the parent's own (unaggregated) usage when expanded
+func formatParentLine(prefix string, proc *monitor.ProcessInfo, cols columns, nameWidth int) string {
+	return fmt.Sprintf("%s %-6d %7.*f%% %10.*fMB%s       %s (parent)",
+		prefix, proc.PID, cols.precision, proc.ParentCPU, cols.precision, float64(proc.ParentMemory)/(1024*1024), cols.cells(cellValues{cpu: proc.ParentCPU, memory: proc.ParentMemory, cpuSeconds: proc.ParentCPUSeconds, avgCPU: proc.ParentAvgCPU, faultRate: proc.ParentFaultRate}),
+		truncateString(proc.Name, nameWidth-9))
+}
+
+// formatChildLine renders a child process or thread row when expanded
+func formatChildLine(prefix string, child monitor.ChildInfo, typeLabel string, cols columns, nameWidth int) string {
+	return fmt.Sprintf("%s %-6d %7.*f%% %10.*fMB%s       %s (%s)",
+		prefix, child.PID, cols.precision, child.CPUPercent, cols.precision, float64(child.MemoryBytes)/(1024*1024), cols.cells(cellValues{cpu: child.CPUPercent, memory: child.MemoryBytes, cpuSeconds: child.CPUSeconds, avgCPU: child.AvgCPU, faultRate: child.MajorFaultRate}),
+		truncateString(child.Name, nameWidth-len(typeLabel)-3), typeLabel)
+}
+
+// threadSummary counts the threads among children and sums their usage
+func threadSummary(children []monitor.ChildInfo) (int, monitor.ChildInfo) {
+	var count int
+	var total monitor.ChildInfo
+	for _, child := range children {
+		if !child.IsThread {
+			continue
+		}
+		count++
+		total.CPUPercent += child.CPUPercent
+		total.MemoryBytes += child.MemoryBytes
+		total.CPUSeconds += child.CPUSeconds
+		total.AvgCPU += child.AvgCPU
+		total.MajorFaultRate += child.MajorFaultRate
+	}
+	return count, total
+}
+
+// formatThreadSummaryLine renders collapsed threads as one "(+N threads)" row
+func formatThreadSummaryLine(prefix string, count int, total monitor.ChildInfo, cols columns) string {
+	return fmt.Sprintf("%s %-6s %7.*f%% %10.*fMB%s       (+%d threads)",
+		prefix, "", cols.precision, total.CPUPercent, cols.precision, float64(total.MemoryBytes)/(1024*1024), cols.cells(cellValues{cpu: total.CPUPercent, memory: total.MemoryBytes, cpuSeconds: total.CPUSeconds, avgCPU: total.AvgCPU, faultRate: total.MajorFaultRate}), count)
+}
+
+// renderCategories shows resource totals per category, or per container,
+// across the listed processes
+func (d *Display) renderCategories(width, maxRows int, title string, summaries []monitor.CategorySummary) {
+	top := d.processTop()
+	currentY := top
+	header := fmt.Sprintf("  %-16s %6s %8s %12s", title, "PROCS", "CPU", "MEMORY")
+	d.drawText(processXOffset, currentY, width-processXOffset*2, header, d.colorScheme.GetStyle(d.colorScheme.Accent, false))
+	currentY++
+
+	for _, summary := range summaries {
+		if currentY >= top+maxRows {
+			break
+		}
+		memoryMB := float64(summary.MemoryBytes) / (1024 * 1024)
+		level := d.monitor.GetResourceLevel(summary.CPUPercent, memoryMB)
+		precision := d.config.GetPrecision()
+		line := fmt.Sprintf("  %-16s %6d %7.*f%% %10.*fMB",
+			truncateString(summary.Category, 16), summary.ProcessCount, precision, summary.CPUPercent, precision, memoryMB)
+		d.drawText(processXOffset, currentY, width-processXOffset*2, line,
+			d.colorScheme.GetStyle(d.colorScheme.GetProcessColor(level), false))
+		currentY++
+	}
+}
+
 func (d *Display) renderFooter(width, height int) {
 	footerY := height - footerRows
 
 	// Footer border
 	d.drawHorizontalLine(2, footerY, width-4, "─", d.colorScheme.Border)
 
-	// Enhanced controls with icons
-	controls := []string{
-		"↑↓ Navigate",
-		"⏎ Expand",
-		"⏸ Pause",
-		"↻ Refresh",
-		"✗ Quit",
+	// Controls come from the same keymap that dispatches input
+	footerText := "🎮 Controls: " + strings.Join(footerControls(d.inputHandler.bindings), " │ ")
+	footerColor := d.colorScheme.Accent
+	if d.filterOpen {
+		footerText = d.filterPrompt()
+		footerColor = d.colorScheme.Text
 	}
-
-	footerText := "🎮 Controls: " + strings.Join(controls, " │ ")
-	d.drawText(3, footerY+1, width-6, footerText, d.colorScheme.GetStyle(d.colorScheme.Accent, false))
+	if d.statusMessage != "" && time.Now().Before(d.statusExpiry) {
+		footerText = d.statusMessage
+		footerColor = d.colorScheme.Warning
+	}
+	d.drawText(3, footerY+1, width-6, footerText, d.colorScheme.GetStyle(footerColor, false))
 
 	// Process count and stats
 	processCount := len(d.processes)
 	statsText := fmt.Sprintf("📊 Showing %d processes", processCount)
-	d.drawText(width-len(statsText)-3, footerY+1, len(statsText), statsText,
-		d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+	if d.filter != nil {
+		statsText += " " + d.filterDescription()
+	}
+	if !d.lastUpdate.IsZero() {
+		statsText += " · updated " + d.config.FormatTime(d.lastUpdate)
+	}
+	if d.config.GetProfile() {
+		statsText = profileText(d.timings, time.Duration(d.renderTime.Load())) + " · " + statsText
+	}
+	statsColor := d.colorScheme.Muted
+	if warning := slowRefreshWarning(d.config.GetRefreshRate(), time.Duration(d.updateTime.Load())); warning != "" {
+		statsText = warning + " · " + statsText
+		statsColor = d.colorScheme.Warning
+	}
+	d.drawText(width-len([]rune(statsText))-3, footerY+1, width-2, statsText,
+		d.colorScheme.GetStyle(statsColor, false))
+}
+
+// slowRefreshWarning says when refreshes take longer than the requested
+// interval, so the effective rate is slower than asked for; updateLoop
+// then waits a full interval after each one rather than scanning back to back
+func slowRefreshWarning(requested, took time.Duration) string {
+	if took < requested {
+		return ""
+	}
+	return fmt.Sprintf("⚠ refresh %v too fast, effective ~%v", requested, (took + requested).Round(time.Millisecond))
+}
+
+// profileText summarizes where the last refresh spent its time. The render
+// time is the previous frame's, since the current one isn't done yet.
+func profileText(scan monitor.ScanTimings, render time.Duration) string {
+	ms := func(d time.Duration) string {
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	}
+	return fmt.Sprintf("⏱ scan %s / aggregate %s / render %s", ms(scan.Enumerate), ms(scan.Aggregate), ms(render))
 }
 
 func (d *Display) drawText(x, y, maxWidth int, text string, style tcell.Style) {
-	runes := []rune(text)
-	for i, r := range runes {
+	i := 0
+	for _, r := range text {
 		if x+i >= maxWidth {
 			break
 		}
 		d.screen.SetContent(x+i, y, r, nil, style)
+		i++
+	}
+}
+
+// panText drops the first offset runes of text, for horizontal scrolling
+func panText(text string, offset int) string {
+	runes := []rune(text)
+	if offset >= len(runes) {
+		return ""
 	}
+	return string(runes[offset:])
 }
 
 func truncateString(s string, maxLen int) string {
@@ -0,0 +1,584 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+// TestDisplayConcurrentAccess drives the input-side methods while the update
+// and render loops run, so `go test -race` can catch unguarded shared state.
+func TestDisplayConcurrentAccess(t *testing.T) {
+	cfg := config.New()
+	cfg.SetRefreshRate(10 * time.Millisecond)
+	cfg.SetCPUThreshold(0)
+
+	d := New(cfg, monitor.New(cfg))
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Run()
+	}()
+
+	for i := 0; i < 50; i++ {
+		d.ForceRefresh()
+		d.TogglePause()
+		d.MoveCursor(1)
+		d.ToggleExpanded()
+		time.Sleep(2 * time.Millisecond)
+	}
+	d.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after Stop()")
+	}
+}
+
+func TestColumnCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		cols     columns
+		bytes    uint64
+		tty      string
+		expected string
+	}{
+		{"Hidden", columns{}, 512, "pts/0", ""},
+		{"Quarter of RAM", columns{memPercent: true, memTotal: 1000, precision: 1}, 250, "", "  25.0%"},
+		{"Whole percent", columns{memPercent: true, memTotal: 1000}, 250, "", "    25%"},
+		{"Three decimals", columns{memPercent: true, memTotal: 3000, precision: 3}, 1000, "", "  33.333%"},
+		{"Unknown total", columns{memPercent: true}, 250, "", "      -"},
+		{"TTY", columns{tty: true}, 0, "pts/3", " pts/3  "},
+		{"No TTY", columns{tty: true}, 0, "?", " ?      "},
+		{"Both", columns{memPercent: true, memTotal: 1000, tty: true, precision: 1}, 500, "tty1", "  50.0% tty1   "},
+		{"CPU time", columns{cpuTime: true}, 0, "", "      0s"},
+		{"Faults", columns{faults: true}, 0, "", "      42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.cols.memCell(tt.bytes) + tt.cols.timeCell(0) + tt.cols.faultCell(42) + tt.cols.ttyCell(tt.tty)
+			if result != tt.expected {
+				t.Errorf("cells = %q; expected %q", result, tt.expected)
+			}
+			if len(tt.cols.header()) != len(tt.expected) {
+				t.Errorf("header %q not aligned with cells %q", tt.cols.header(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBarCellScales(t *testing.T) {
+	tests := []struct {
+		name     string
+		cols     columns
+		cpu      float64
+		expected string
+	}{
+		{"Off", columns{}, 50, ""},
+		{"Absolute half", columns{cpuBar: config.CPUBarAbsolute, cpuScale: 100}, 50, " █████░░░░░"},
+		{"Absolute over 100", columns{cpuBar: config.CPUBarAbsolute, cpuScale: 100}, 380, " ██████████"},
+		{"Relative to 400", columns{cpuBar: config.CPUBarRelative, cpuScale: 400}, 40, " █░░░░░░░░░"},
+		{"Relative busiest", columns{cpuBar: config.CPUBarRelative, cpuScale: 400}, 400, " ██████████"},
+		{"Relative all idle", columns{cpuBar: config.CPUBarRelative}, 0, " ░░░░░░░░░░"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cols.barCell(tt.cpu); got != tt.expected {
+				t.Errorf("barCell(%v) = %q; expected %q", tt.cpu, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCPUCell(t *testing.T) {
+	prev := 20.0
+	tests := []struct {
+		name     string
+		cols     columns
+		proc     *monitor.ProcessInfo
+		expected string
+	}{
+		{"Absolute", columns{precision: 1}, &monitor.ProcessInfo{CPUPercent: 32.3, PrevCPUPercent: &prev}, "   32.3%"},
+		{"Rise", columns{precision: 1, cpuDelta: true}, &monitor.ProcessInfo{CPUPercent: 32.3, PrevCPUPercent: &prev}, "  +12.3%"},
+		{"Drop", columns{precision: 1, cpuDelta: true}, &monitor.ProcessInfo{CPUPercent: 5, PrevCPUPercent: &prev}, "  -15.0%"},
+		{"New row", columns{precision: 1, cpuDelta: true}, &monitor.ProcessInfo{CPUPercent: 5}, "     new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cols.cpuCell(tt.proc); got != tt.expected {
+				t.Errorf("cpuCell = %q; expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeeperLevelsNote(t *testing.T) {
+	tests := []struct {
+		levels   int
+		expected string
+	}{
+		{0, "└─ make"},
+		{1, "└─ make … 1 more level"},
+		{4, "└─ make … 4 more levels"},
+	}
+	for _, tt := range tests {
+		proc := &monitor.ProcessInfo{PID: 10, Name: "make", Depth: 1, DeeperLevels: tt.levels}
+		if line := formatProcessLine("  ", "", proc, columns{}, 40); !strings.HasSuffix(line, tt.expected) {
+			t.Errorf("DeeperLevels %d: line %q; expected suffix %q", tt.levels, line, tt.expected)
+		}
+	}
+}
+
+func TestThreadSummary(t *testing.T) {
+	children := []monitor.ChildInfo{
+		{PID: 2, CPUPercent: 1.5, MemoryBytes: 10 * 1024 * 1024, IsThread: true},
+		{PID: 3, CPUPercent: 4.0, MemoryBytes: 30 * 1024 * 1024},
+		{PID: 4, CPUPercent: 2.5, MemoryBytes: 20 * 1024 * 1024, IsThread: true},
+	}
+
+	count, total := threadSummary(children)
+	if count != 2 {
+		t.Errorf("count = %d; expected 2", count)
+	}
+	if total.CPUPercent != 4.0 || total.MemoryBytes != 30*1024*1024 {
+		t.Errorf("total = %.1f%% %d bytes; expected 4.0%% %d bytes", total.CPUPercent, total.MemoryBytes, 30*1024*1024)
+	}
+
+	line := formatThreadSummaryLine("    ╠═", count, total, columns{})
+	if !strings.HasSuffix(line, "(+2 threads)") {
+		t.Errorf("summary line %q missing thread count", line)
+	}
+}
+
+func TestFindProcess(t *testing.T) {
+	processes := []*monitor.ProcessInfo{
+		{PID: 1, Name: "postgres-worker"},
+		{PID: 2, Name: "Web Content", ExeName: "firefox"},
+		{PID: 3, Name: "postgres"},
+	}
+
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{"postgres", 2}, // Exact match beats an earlier substring match
+		{"FIREFOX", 1},  // Executable name, case-insensitive
+		{"worker", 0},   // Substring fallback
+		{"nginx", -1},   // Not running (yet)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := findProcess(processes, tt.name); result != tt.expected {
+				t.Errorf("findProcess(%q) = %d; expected %d", tt.name, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPanStopsAtWidestRow(t *testing.T) {
+	d := New(config.New(), nil)
+	d.tableOverflow.Store(hScrollStep*2 + 3)
+
+	for _, tt := range []struct {
+		delta    int
+		expected int
+	}{
+		{-1, 0},
+		{1, hScrollStep},
+		{1, hScrollStep * 2},
+		{1, hScrollStep*2 + 3},
+		{1, hScrollStep*2 + 3},
+		{-1, hScrollStep + 3},
+	} {
+		d.Pan(tt.delta)
+		if d.hOffset != tt.expected {
+			t.Fatalf("Pan(%d): hOffset = %d; expected %d", tt.delta, d.hOffset, tt.expected)
+		}
+	}
+
+	if got := panText("├─ 1234", 2); got != " 1234" {
+		t.Errorf("panText = %q; expected %q", got, " 1234")
+	}
+	if got := panText("abc", 5); got != "" {
+		t.Errorf("panText past the end = %q; expected empty", got)
+	}
+}
+
+// TestHeaderTitleShowsExactThreshold checks a threshold that isn't a whole
+// number of MB isn't rounded down in the header
+func TestHeaderTitleShowsExactThreshold(t *testing.T) {
+	cfg := config.New()
+	cfg.SetMemoryThreshold(50*1024*1024 + 512*1024)
+	if title := headerTitle(cfg); !strings.Contains(title, ">50.5 MB RAM") {
+		t.Errorf("headerTitle = %q; expected the 50.5 MB threshold", title)
+	}
+}
+
+func TestEmptyListMessage(t *testing.T) {
+	cfg := config.New()
+	bindings, _ := buildKeymap(map[string][]string{"settings": {"z"}})
+	expected := "No processes above thresholds (CPU>5.0%, MEM>50.0 MB) — press z to adjust"
+	if got := emptyListMessage(cfg, keyLabel(bindings, "settings")); got != expected {
+		t.Errorf("emptyListMessage = %q; expected %q", got, expected)
+	}
+	if got := emptyListMessage(cfg, ""); strings.Contains(got, "press") {
+		t.Errorf("Expected no key hint without a settings key, got %q", got)
+	}
+}
+
+func TestProfileText(t *testing.T) {
+	scan := monitor.ScanTimings{Enumerate: 180 * time.Millisecond, Aggregate: 2500 * time.Microsecond}
+	expected := "⏱ scan 180.0ms / aggregate 2.5ms / render 4.0ms"
+	if got := profileText(scan, 4*time.Millisecond); got != expected {
+		t.Errorf("profileText = %q; expected %q", got, expected)
+	}
+}
+
+func TestSlowRefreshWarning(t *testing.T) {
+	if got := slowRefreshWarning(time.Second, 180*time.Millisecond); got != "" {
+		t.Errorf("scan within the interval warned %q", got)
+	}
+	expected := "⚠ refresh 10ms too fast, effective ~190ms"
+	if got := slowRefreshWarning(10*time.Millisecond, 180*time.Millisecond); got != expected {
+		t.Errorf("slowRefreshWarning = %q; expected %q", got, expected)
+	}
+}
+
+func TestMemoryTotalChange(t *testing.T) {
+	gb := func(n uint64) *monitor.SystemMetrics { return &monitor.SystemMetrics{MemoryTotal: n << 30} }
+
+	tests := []struct {
+		name      string
+		prev, cur *monitor.SystemMetrics
+		expected  string
+	}{
+		{"First snapshot", nil, gb(8), ""},
+		{"Unchanged", gb(8), gb(8), ""},
+		{"Grown", gb(8), gb(16), "RAM total changed 8.0 GB → 16.0 GB"},
+		{"Shrunk", gb(16), gb(8), "RAM total changed 16.0 GB → 8.0 GB"},
+		{"Read failed", gb(8), &monitor.SystemMetrics{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := memoryTotalChange(tt.prev, tt.cur); got != tt.expected {
+				t.Errorf("memoryTotalChange = %q; expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSummaryMetricsSkipsMissingSwap(t *testing.T) {
+	d := New(config.New(), nil)
+	d.systemMetrics = &monitor.SystemMetrics{CPUPercent: 40, MemoryPercent: 50}
+	if got := len(d.summaryMetrics()); got != 2 {
+		t.Errorf("Without swap got %d summary metrics; expected 2", got)
+	}
+
+	d.systemMetrics.SwapTotal = 1024
+	metrics := d.summaryMetrics()
+	if len(metrics) != 3 || metrics[2].label != "SWAP" {
+		t.Errorf("With swap got %+v; expected CPU, MEM and SWAP", metrics)
+	}
+}
+
+func TestFormatHostLine(t *testing.T) {
+	up := monitor.HostStatus{
+		Host:   "web-1",
+		System: &monitor.SystemMetrics{CPUPercent: 42, MemoryPercent: 61.5, Load1: 1.5, Load5: 1, Load15: 0.5},
+		Tasks:  monitor.TaskCounts{Total: 312},
+	}
+	expected := "  web-1                       42.0%    61.5%        -   1.50 1.00 0.50     312"
+	if got := formatHostLine(up, 1); got != expected {
+		t.Errorf("formatHostLine = %q; expected %q", got, expected)
+	}
+
+	down := monitor.HostStatus{Host: "db-1", Err: errors.New("disconnected from db-1: Connection refused")}
+	if got := formatHostLine(down, 1); !strings.HasSuffix(got, "disconnected from db-1: Connection refused") {
+		t.Errorf("formatHostLine for a down host = %q", got)
+	}
+}
+
+// TestZeroMemoryTotal checks a container reporting no memory total gets
+// "N/A" and an empty bar rather than NaN
+func TestZeroMemoryTotal(t *testing.T) {
+	m := &monitor.SystemMetrics{MemoryUsed: 1024}
+	if got := memoryDetails(m, 1); strings.Contains(got, "NaN") || !strings.HasPrefix(got, "N/A") {
+		t.Errorf("memoryDetails = %q; expected N/A", got)
+	}
+	if bar := CreateProgressBar(math.NaN(), 10, config.DefaultBarGlyphs); bar != strings.Repeat("░", 10) {
+		t.Errorf("CreateProgressBar(NaN) = %q; expected an empty bar", bar)
+	}
+	if cell := (columns{memPercent: true}).memCell(1024); strings.Contains(cell, "NaN") {
+		t.Errorf("MEM%% cell = %q", cell)
+	}
+}
+
+func TestCollapseHeaderWhenScrolled(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, nil)
+	d.scrollOffset = 3
+	if top := d.processTop(); top != processStartY {
+		t.Errorf("processTop = %d with a fixed header; expected %d", top, processStartY)
+	}
+
+	cfg.SetCollapseHeader(true)
+	if top := d.processTop(); top != collapsedStartY {
+		t.Errorf("processTop = %d scrolled down; expected the collapsed %d", top, collapsedStartY)
+	}
+	d.scrollOffset = 0
+	if top := d.processTop(); top != processStartY {
+		t.Errorf("processTop = %d back at the top; expected the full header's %d", top, processStartY)
+	}
+}
+
+func TestOnelineHeader(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, nil)
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.screen.Fini()
+
+	d.screen.SetSize(120, 40)
+	if top := d.processTop(); top != processStartY {
+		t.Errorf("processTop = %d on a wide terminal; expected the full header's %d", top, processStartY)
+	}
+	cfg.SetOnelineHeader(true)
+	if top := d.processTop(); top != onelineStartY {
+		t.Errorf("processTop = %d with --oneline-header; expected %d", top, onelineStartY)
+	}
+	cfg.SetOnelineHeader(false)
+	d.screen.SetSize(60, 40)
+	if top := d.processTop(); top != onelineStartY {
+		t.Errorf("processTop = %d in a narrow pane; expected %d", top, onelineStartY)
+	}
+
+	m := &monitor.SystemMetrics{CPUPercent: 34, MemoryPercent: 62.4, SwapTotal: 1, SwapPercent: 5, Load1: 2.1}
+	if got := onelineSummary(m, 0); got != "CPU 34% | MEM 62% | SWAP 5% | Load 2.10" {
+		t.Errorf("onelineSummary = %q", got)
+	}
+	m.SwapTotal, m.Load1 = 0, 0
+	if got := onelineSummary(m, 1); got != "CPU 34.0% | MEM 62.4%" {
+		t.Errorf("onelineSummary without swap or load = %q", got)
+	}
+}
+
+// TestHeaderDetailsColumn checks the readings after the header bars start
+// in the same column whatever characters and fill the bars are drawn with
+func TestHeaderDetailsColumn(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, nil)
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.screen.Fini()
+	d.screen.SetSize(120, 40)
+
+	for _, preset := range config.BarPresets {
+		for _, percent := range []float64{3, 37, 100} {
+			cfg.SetBarGlyphs(preset.Glyphs)
+			d.systemMetrics = &monitor.SystemMetrics{CPUPercent: percent, MemoryPercent: percent, MemoryTotal: 1 << 30, SwapTotal: 1 << 30, SwapPercent: percent}
+			d.screen.Clear()
+			d.renderHeader(120)
+			for y := 2; y <= 4; y++ {
+				r, _, _, _ := d.screen.GetContent(headerBarX+headerBarWidth+1, y)
+				if r < '0' || r > '9' {
+					t.Errorf("%s bars at %v%%: line %d has %q where its reading should start", preset.Name, percent, y, r)
+				}
+			}
+		}
+	}
+}
+
+func TestMetricDelta(t *testing.T) {
+	tests := []struct {
+		prev, cur float64
+		precision int
+		text      string
+		dir       int
+	}{
+		{31, 34, 0, "▲3", 1},
+		{34, 32.5, 1, "▼1.5", -1},
+		{34, 34.2, 0, "", 0},
+		{34, 34.2, 1, "▲0.2", 1},
+		{50, 50, 1, "", 0},
+	}
+	for _, tt := range tests {
+		if text, dir := metricDelta(tt.prev, tt.cur, tt.precision); text != tt.text || dir != tt.dir {
+			t.Errorf("metricDelta(%v, %v, %d) = %q, %d; expected %q, %d", tt.prev, tt.cur, tt.precision, text, dir, tt.text, tt.dir)
+		}
+	}
+}
+
+func TestHeaderDeltas(t *testing.T) {
+	cfg := config.New()
+	cfg.SetPrecision(0)
+	cfg.SetOnelineHeader(true)
+	d := New(cfg, nil)
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.screen.Fini()
+	d.screen.SetSize(120, 40)
+
+	d.prevMetrics = &monitor.SystemMetrics{CPUPercent: 31, MemoryPercent: 64}
+	d.systemMetrics = &monitor.SystemMetrics{CPUPercent: 34, MemoryPercent: 62}
+	line := func() string {
+		d.screen.Clear()
+		d.drawOnelineHeader(2, 120)
+		row := ""
+		for x := 2; x < 60; x++ {
+			r, _, _, _ := d.screen.GetContent(x, 2)
+			row += string(r)
+		}
+		return strings.TrimRight(row, " ")
+	}
+
+	if got := line(); got != "CPU 34% | MEM 62%" {
+		t.Errorf("header without --header-deltas = %q", got)
+	}
+	cfg.SetHeaderDeltas(true)
+	if got := line(); got != "CPU 34% ▲3 | MEM 62% ▼2" {
+		t.Errorf("header with --header-deltas = %q", got)
+	}
+	_, _, style, _ := d.screen.GetContent(len("CPU 34% ")+2, 2)
+	if fg, _, _ := style.Decompose(); fg != d.colorScheme.Error {
+		t.Errorf("rising arrow colored %v; expected %v", fg, d.colorScheme.Error)
+	}
+}
+
+func TestAlignedDelay(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		expected time.Duration
+	}{
+		{"Mid second", base.Add(300 * time.Millisecond), time.Second, 700 * time.Millisecond},
+		{"On a boundary", base, time.Second, time.Second},
+		{"Five seconds", base.Add(7 * time.Second), 5 * time.Second, 3 * time.Second},
+		{"Sub-second", base.Add(120 * time.Millisecond), 250 * time.Millisecond, 130 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AlignedDelay(tt.now, tt.interval); got != tt.expected {
+				t.Errorf("AlignedDelay = %v; expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// BenchmarkRender draws a full frame of a large terminal, as Run does
+// every 50ms
+func BenchmarkRender(b *testing.B) {
+	cfg := config.New()
+	d := New(cfg, monitor.New(cfg))
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		b.Fatal(err)
+	}
+	defer d.screen.Fini()
+	d.screen.SetSize(300, 100)
+
+	d.systemMetrics = &monitor.SystemMetrics{CPUPercent: 40, MemoryPercent: 60, MemoryTotal: 16 << 30, Load1: 1.5}
+	for i := 0; i < 200; i++ {
+		d.processes = append(d.processes, &monitor.ProcessInfo{
+			PID: int32(1000 + i), Name: fmt.Sprintf("process-%d", i), CPUPercent: float64(i % 100), MemoryBytes: uint64(i) << 20, MemoryMB: float64(i),
+		})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := d.render(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRenderDue(t *testing.T) {
+	d := New(config.New(), nil)
+	now := time.Now()
+	last := now.Add(-100 * time.Millisecond)
+
+	if d.renderDue(now, last) {
+		t.Error("render due with nothing changed")
+	}
+	d.dirty.Store(true)
+	if !d.renderDue(now, last) {
+		t.Error("render not due after a change")
+	}
+	if d.renderDue(now, last) {
+		t.Error("renderDue didn't clear the change")
+	}
+	d.setStatus("Saved")
+	if !d.renderDue(now, last) {
+		t.Error("render not due after a status message")
+	}
+	if !d.renderDue(now, now.Add(-idleRenderInterval)) {
+		t.Error("render not due after idleRenderInterval")
+	}
+}
+
+// noBellScreen is a terminal without a bell
+type noBellScreen struct{ tcell.Screen }
+
+func (noBellScreen) Beep() error { return errors.New("no bell capability") }
+
+// TestRingBellFailure checks a bell the terminal can't ring is reported in
+// the footer warning instead of dropped
+func TestRingBellFailure(t *testing.T) {
+	d := New(config.New(), nil)
+	d.screen = noBellScreen{tcell.NewSimulationScreen("UTF-8")}
+
+	d.ringBell()
+	if d.statusMessage != "" {
+		t.Errorf("status %q without a pending bell", d.statusMessage)
+	}
+	d.setStatus("⚠ Critical process nginx is not running")
+	d.bell.Store(true)
+	d.ringBell()
+	if want := "⚠ Critical process nginx is not running (bell failed: no bell capability)"; d.statusMessage != want {
+		t.Errorf("status = %q, want %q", d.statusMessage, want)
+	}
+	if d.bell.Load() {
+		t.Error("ringBell left the bell pending")
+	}
+}
+
+func TestScopeText(t *testing.T) {
+	cfg := config.New()
+	if got := scopeText(cfg); got != "" {
+		t.Errorf("scopeText without --scope = %q", got)
+	}
+	cfg.SetScope(config.ScopeSession, 812)
+	if got := scopeText(cfg); got != "session 812 only" {
+		t.Errorf("scopeText = %q", got)
+	}
+	cfg.SetScope(config.ScopePGroup, 4410)
+	if got := scopeText(cfg); got != "process group 4410 only" {
+		t.Errorf("scopeText = %q", got)
+	}
+}
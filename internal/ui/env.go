@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// secretMarkers flag environment variables whose values are hidden until
+// revealed, matched anywhere in the upper-cased name. They err towards
+// hiding: KEY and AUTH also catch the odd harmless name like SSH_AUTH_SOCK.
+var secretMarkers = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "KEY", "CREDENTIAL", "AUTH"}
+
+// redactedValue replaces a hidden value
+const redactedValue = "•••• (r to reveal)"
+
+// ToggleEnv switches the detail pane between the selected process's details
+// and its environment, reading the environment each time it's opened. Values
+// start hidden again on every open.
+func (d *Display) ToggleEnv() {
+	d.mu.Lock()
+	d.envOpen = !d.envOpen
+	d.envScroll = 0
+	d.envReveal = false
+	if !d.envOpen || d.detail == nil {
+		d.mu.Unlock()
+		return
+	}
+	pid := d.detail.PID
+	d.mu.Unlock()
+
+	env, err := d.monitor.Environ(pid)
+	sort.Strings(env)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.env, d.envErr = env, err
+}
+
+// EnvOpen reports whether the detail pane is showing the environment
+func (d *Display) EnvOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.envOpen
+}
+
+// ToggleEnvReveal shows or hides the values redactEnv masks
+func (d *Display) ToggleEnvReveal() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.envReveal = !d.envReveal
+}
+
+// ScrollEnv moves the environment view by delta lines, stopping once the
+// last line is in view
+func (d *Display) ScrollEnv(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, height := d.screen.Size()
+	_, d.envScroll = visibleEnvLines(d.envLines(), max(d.envScroll+delta, 0), detailRows(height))
+}
+
+// redactEnv masks the values of variables that look like credentials unless
+// reveal is set
+func redactEnv(vars []string, reveal bool) []string {
+	out := make([]string, len(vars))
+	for i, v := range vars {
+		out[i] = v
+		name, _, ok := strings.Cut(v, "=")
+		if reveal || !ok {
+			continue
+		}
+		upper := strings.ToUpper(name)
+		for _, marker := range secretMarkers {
+			if strings.Contains(upper, marker) {
+				out[i] = name + "=" + redactedValue
+				break
+			}
+		}
+	}
+	return out
+}
+
+// envLines are the environment view's lines: one per variable, or why
+// the environment couldn't be read
+func (d *Display) envLines() []string {
+	switch {
+	case errors.Is(d.envErr, fs.ErrPermission):
+		return []string{"Permission denied: only the process's owner or root can read its environment"}
+	case d.envErr != nil:
+		return []string{fmt.Sprintf("Environment unavailable: %v", d.envErr)}
+	case len(d.env) == 0:
+		return []string{"Empty environment"}
+	}
+	return redactEnv(d.env, d.envReveal)
+}
+
+// visibleEnvLines clamps the scroll offset so the last page stays full and
+// returns the lines that fit in rows
+func visibleEnvLines(lines []string, scroll, rows int) ([]string, int) {
+	if rows <= 0 {
+		return nil, 0
+	}
+	scroll = min(scroll, max(len(lines)-rows, 0))
+	return lines[scroll:min(scroll+rows, len(lines))], scroll
+}
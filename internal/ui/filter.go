@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// nameFilter narrows the list to processes whose name or executable
+// matches: a case-insensitive substring, or a regular expression
+type nameFilter struct {
+	pattern string
+	re      *regexp.Regexp // Compiled once when the filter is applied; nil for substring
+}
+
+// newNameFilter compiles pattern; an empty pattern means no filter
+func newNameFilter(pattern string, regex bool) (*nameFilter, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if !regex {
+		return &nameFilter{pattern: pattern}, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &nameFilter{pattern: pattern, re: re}, nil
+}
+
+func (f *nameFilter) matches(proc *monitor.ProcessInfo) bool {
+	if f.re != nil {
+		return f.re.MatchString(proc.Name) || f.re.MatchString(proc.GroupName())
+	}
+	lower := strings.ToLower(f.pattern)
+	return strings.Contains(strings.ToLower(proc.Name), lower) || strings.Contains(strings.ToLower(proc.GroupName()), lower)
+}
+
+// apply returns the matching processes, or with invert the ones that don't
+// match; a nil filter keeps them all
+func (f *nameFilter) apply(processes []*monitor.ProcessInfo, invert bool) []*monitor.ProcessInfo {
+	if f == nil {
+		return processes
+	}
+	kept := make([]*monitor.ProcessInfo, 0, len(processes))
+	for _, proc := range processes {
+		if f.matches(proc) != invert {
+			kept = append(kept, proc)
+		}
+	}
+	return kept
+}
+
+// String shows the filter as typed, with slashes for a regex
+func (f *nameFilter) String() string {
+	if f.re != nil {
+		return "/" + f.pattern + "/"
+	}
+	return fmt.Sprintf("%q", f.pattern)
+}
+
+// OpenFilter opens the filter prompt, starting from the current filter
+func (d *Display) OpenFilter() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.filterOpen = true
+	d.filterInput = ""
+	if d.filter != nil {
+		d.filterInput = d.filter.pattern
+		d.filterRegex = d.filter.re != nil
+	}
+}
+
+// FilterOpen reports whether the filter prompt has keyboard focus
+func (d *Display) FilterOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.filterOpen
+}
+
+// CloseFilter leaves the prompt, keeping the filter already applied
+func (d *Display) CloseFilter() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.filterOpen = false
+}
+
+// EditFilter appends r to the prompt, or deletes the last character when
+// r is 0
+func (d *Display) EditFilter(r rune) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if r != 0 {
+		d.filterInput += string(r)
+		return
+	}
+	if runes := []rune(d.filterInput); len(runes) > 0 {
+		d.filterInput = string(runes[:len(runes)-1])
+	}
+}
+
+// ToggleFilterRegex switches the prompt between substring and regex matching
+func (d *Display) ToggleFilterRegex() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.filterRegex = !d.filterRegex
+}
+
+// ApplyFilter compiles the prompt's pattern and filters the list with it
+// at once; an empty pattern clears the filter. An invalid regex leaves
+// the prompt open with the error in the footer.
+func (d *Display) ApplyFilter() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	filter, err := newNameFilter(d.filterInput, d.filterRegex)
+	if err != nil {
+		d.setStatus(fmt.Sprintf("Invalid regex: %v", err))
+		return
+	}
+	d.filterOpen = false
+	d.filter = filter
+	if filter == nil {
+		d.invertFilter = false
+		d.setStatus("Filter cleared")
+	}
+	d.processes = filter.apply(d.processes, d.invertFilter)
+	d.clampSelection()
+	d.ForceRefresh()
+}
+
+// ToggleInvertFilter flips the applied filter to list everything it
+// doesn't match, and back. The rows it hid come back on the refresh it
+// triggers, since the display only keeps the ones it shows.
+func (d *Display) ToggleInvertFilter() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.filter == nil {
+		d.setStatus("No filter to invert")
+		return
+	}
+	d.invertFilter = !d.invertFilter
+	d.setStatus("Showing processes " + d.filterDescription())
+	d.ForceRefresh()
+}
+
+// filterDescription says which processes the filter lets through, e.g.
+// "not matching /^chrome/"; callers must hold d.mu
+func (d *Display) filterDescription() string {
+	if d.invertFilter {
+		return "not matching " + d.filter.String()
+	}
+	return "matching " + d.filter.String()
+}
+
+// filterPrompt is the footer line while the prompt is open
+func (d *Display) filterPrompt() string {
+	mode, other := "substring", "regex"
+	if d.filterRegex {
+		mode, other = other, mode
+	}
+	return fmt.Sprintf("Filter (%s): %s▏  Tab %s · Enter apply · Esc cancel", mode, d.filterInput, other)
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNameFilter(t *testing.T) {
+	processes := []*monitor.ProcessInfo{
+		{PID: 1, Name: "chrome"},
+		{PID: 2, Name: "firefox"},
+		{PID: 3, Name: "celery-worker-12"},
+		{PID: 4, Name: "Web Content", ExeName: "firefox"},
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		regex    bool
+		expected []int32
+	}{
+		{"Substring ignores case", "CHROME", false, []int32{1}},
+		{"Substring matches executable", "fire", false, []int32{2, 4}},
+		{"Substring keeps metacharacters literal", "^c", false, nil},
+		{"Regex alternation", "^(chrome|firefox)", true, []int32{1, 2, 4}},
+		{"Regex digits", `.*-worker-\d+`, true, []int32{3}},
+		{"Empty keeps everything", "", true, []int32{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newNameFilter(tt.pattern, tt.regex)
+			if err != nil {
+				t.Fatalf("newNameFilter(%q) error = %v", tt.pattern, err)
+			}
+			var got []int32
+			for _, proc := range f.apply(processes, false) {
+				got = append(got, proc.PID)
+			}
+			if !equalPIDs(got, tt.expected) {
+				t.Errorf("filter %q kept %v; expected %v", tt.pattern, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := newNameFilter("(unclosed", true); err == nil {
+		t.Error("Expected an invalid regex to fail to compile")
+	}
+}
+
+func equalPIDs(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFilterPrompt(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, monitor.New(cfg))
+	d.processes = []*monitor.ProcessInfo{{PID: 1, Name: "chrome"}, {PID: 2, Name: "sshd"}}
+	d.selectedIndex = 1
+
+	typeText := func(s string) {
+		for _, r := range s {
+			d.inputHandler.HandleInput(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+	}
+	key := func(k tcell.Key) { d.inputHandler.HandleInput(tcell.NewEventKey(k, 0, tcell.ModNone)) }
+
+	typeText("/")
+	if !d.FilterOpen() {
+		t.Fatal("Expected / to open the filter prompt")
+	}
+	key(tcell.KeyTab)
+	typeText("(chr")
+	key(tcell.KeyEnter)
+	if !d.FilterOpen() || !strings.Contains(d.statusMessage, "Invalid regex") {
+		t.Fatalf("Expected an invalid regex to keep the prompt open with an error, got %q", d.statusMessage)
+	}
+
+	key(tcell.KeyBackspace2)
+	key(tcell.KeyBackspace2)
+	key(tcell.KeyBackspace2)
+	key(tcell.KeyBackspace2)
+	typeText("^ch")
+	key(tcell.KeyEnter)
+	if d.FilterOpen() || len(d.processes) != 1 || d.processes[0].Name != "chrome" || d.selectedIndex != 0 {
+		t.Fatalf("Expected only chrome, selected, after applying ^ch; got %d rows, selected %d", len(d.processes), d.selectedIndex)
+	}
+
+	// Reopening starts from the applied filter; clearing it lists everything again
+	typeText("/")
+	if d.filterInput != "^ch" || !d.filterRegex {
+		t.Errorf("Expected the prompt to reopen with /^ch/, got %q (regex %v)", d.filterInput, d.filterRegex)
+	}
+	for range "^ch" {
+		key(tcell.KeyBackspace2)
+	}
+	key(tcell.KeyEnter)
+	if d.filter != nil {
+		t.Errorf("Expected an empty pattern to clear the filter, got %v", d.filter)
+	}
+}
+
+func TestInvertFilter(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, monitor.New(cfg))
+	press := func(r rune) { d.inputHandler.HandleInput(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)) }
+
+	press('!')
+	if d.invertFilter || !strings.Contains(d.statusMessage, "No filter") {
+		t.Fatalf("Expected inverting without a filter to only explain, got %q", d.statusMessage)
+	}
+
+	d.filter, _ = newNameFilter("chrome", false)
+	press('!')
+	if !d.invertFilter || !strings.Contains(d.statusMessage, `not matching "chrome"`) {
+		t.Fatalf("Expected the filter inverted, got %q", d.statusMessage)
+	}
+	processes := []*monitor.ProcessInfo{{PID: 1, Name: "chrome"}, {PID: 2, Name: "sshd"}}
+	if kept := d.filter.apply(processes, d.invertFilter); len(kept) != 1 || kept[0].Name != "sshd" {
+		t.Errorf("Inverted filter kept %v; expected only sshd", kept)
+	}
+
+	// Clearing the filter drops the inversion with it
+	d.filterInput = ""
+	d.ApplyFilter()
+	if d.invertFilter {
+		t.Error("Expected clearing the filter to reset the inversion")
+	}
+}
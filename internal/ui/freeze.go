@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// ToggleFreezeSort freezes the row order as it is now, so refreshes update
+// each row's values in place instead of re-sorting under the cursor;
+// pressing it again goes back to the live sort order
+func (d *Display) ToggleFreezeSort() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.frozenOrder != nil {
+		d.frozenOrder = nil
+		d.setStatus("Sort unfrozen")
+		return
+	}
+	d.frozenOrder = rowOrder(d.processes)
+	d.setStatus("Sort frozen: rows keep their places until unfrozen")
+}
+
+// rowOrder maps each listed PID to its row
+func rowOrder(processes []*monitor.ProcessInfo) map[int32]int {
+	order := make(map[int32]int, len(processes))
+	for i, proc := range processes {
+		order[proc.PID] = i
+	}
+	return order
+}
+
+// applyFrozenOrder reorders a freshly sorted list to the frozen order.
+// Processes that weren't listed when it was frozen go after the rest in
+// their live order; callers re-take the order afterwards so they keep
+// those places from then on.
+func applyFrozenOrder(processes []*monitor.ProcessInfo, order map[int32]int) []*monitor.ProcessInfo {
+	rank := func(proc *monitor.ProcessInfo) int {
+		if i, ok := order[proc.PID]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		return rank(processes[i]) < rank(processes[j])
+	})
+	return processes
+}
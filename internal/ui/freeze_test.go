@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+func TestApplyFrozenOrder(t *testing.T) {
+	pids := func(processes []*monitor.ProcessInfo) []int32 {
+		var out []int32
+		for _, proc := range processes {
+			out = append(out, proc.PID)
+		}
+		return out
+	}
+
+	d := New(config.New(), nil)
+	d.processes = []*monitor.ProcessInfo{{PID: 1, MemoryBytes: 300}, {PID: 2, MemoryBytes: 200}, {PID: 3, MemoryBytes: 100}}
+	d.ToggleFreezeSort()
+
+	// Live order has 3 ahead of 1, 2 exited and 4 is new
+	live := []*monitor.ProcessInfo{{PID: 4, MemoryBytes: 900}, {PID: 3, MemoryBytes: 500}, {PID: 1, MemoryBytes: 310}}
+	got := pids(applyFrozenOrder(live, d.frozenOrder))
+	expected := []int32{1, 3, 4}
+	for i := range expected {
+		if len(got) != len(expected) || got[i] != expected[i] {
+			t.Fatalf("frozen order = %v; expected %v", got, expected)
+		}
+	}
+	if live[0].MemoryBytes != 310 {
+		t.Errorf("row 1 kept %d bytes; expected the refreshed 310", live[0].MemoryBytes)
+	}
+
+	d.ToggleFreezeSort()
+	if d.frozenOrder != nil {
+		t.Error("second toggle did not unfreeze")
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// heatX is the heat cell's column, in the left margin so turning it on
+// doesn't shift the table
+const heatX = processXOffset - 2
+
+// heatGlyphs fill more of the cell the busier the row, so the levels stay
+// apart in monochrome and with color-blind palettes
+var heatGlyphs = map[monitor.ResourceLevel]rune{
+	monitor.Low:    '░',
+	monitor.Medium: '▒',
+	monitor.High:   '█',
+}
+
+// drawHeat draws a top-level row's heat cell with --heat: its resource
+// level as a block in the level's usage color. It ignores the baseline and
+// warning colors the row itself may take, so the edge always reads as load.
+func (d *Display) drawHeat(y int, level monitor.ResourceLevel) {
+	style := d.colorScheme.GetStyle(d.colorScheme.GetProcessColor(level), false)
+	d.screen.SetContent(heatX, y, heatGlyphs[level], nil, style)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHeatCellFollowsResourceLevel(t *testing.T) {
+	cfg := config.New()
+	cfg.SetHeatColumn(true)
+	d := New(cfg, monitor.New(cfg))
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.screen.Fini()
+	d.screen.SetSize(100, 40)
+
+	d.processes = []*monitor.ProcessInfo{
+		{PID: 1, Name: "busy", CPUPercent: 95},
+		{PID: 2, Name: "idle", MemoryMB: 1},
+	}
+	// The selection highlight doesn't reach the heat cell
+	d.selectedIndex = 1
+	d.renderProcesses(100, 40)
+
+	top := d.processTop()
+	for i, expected := range []monitor.ResourceLevel{monitor.High, monitor.Low} {
+		r, _, style, _ := d.screen.GetContent(heatX, top+i)
+		if r != heatGlyphs[expected] {
+			t.Errorf("row %d heat = %q; expected %q", i, r, heatGlyphs[expected])
+		}
+		if fg, _, _ := style.Decompose(); fg != d.colorScheme.GetProcessColor(expected) {
+			t.Errorf("row %d heat color = %v; expected the %s color", i, fg, expected)
+		}
+	}
+
+	cfg.SetHeatColumn(false)
+	d.screen.Clear()
+	d.renderProcesses(100, 40)
+	if r, _, _, _ := d.screen.GetContent(heatX, top); r != ' ' {
+		t.Errorf("heat cell drawn with the column off: %q", r)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+// ToggleHelp opens or closes the key binding help overlay
+func (d *Display) ToggleHelp() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.helpOpen = !d.helpOpen
+}
+
+// HelpOpen reports whether the help overlay currently has focus
+func (d *Display) HelpOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.helpOpen
+}
+
+// renderHelp draws every active key binding centered over the process list
+func (d *Display) renderHelp(width, height int) {
+	lines := helpLines(d.inputHandler.bindings)
+	boxWidth := 0
+	for _, line := range lines {
+		if n := len([]rune(line)); n > boxWidth {
+			boxWidth = n
+		}
+	}
+	boxWidth += 6
+	boxHeight := len(lines) + 4
+	x := (width - boxWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+boxWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, boxWidth, boxHeight)
+
+	right := x + boxWidth - 2
+	d.drawText(x+2, y, right, " Keys ", d.colorScheme.GetStyle(d.colorScheme.Header, false))
+	for i, line := range lines {
+		d.drawText(x+3, y+2+i, right, line, textStyle)
+	}
+	d.drawText(x+2, y+boxHeight-1, right, " Esc close ", d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
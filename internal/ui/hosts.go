@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// hostSource is a data source spanning several hosts, such as
+// *monitor.Fleet; the display lists one row per host instead of processes
+type hostSource interface {
+	Hosts() []monitor.HostStatus
+}
+
+// hostHeader heads the per-host table
+func hostHeader() string {
+	return fmt.Sprintf("  %-24s %8s %8s %8s %16s %7s", "HOST", "CPU", "MEM", "SWAP", "LOAD", "TASKS")
+}
+
+// formatHostLine renders one host's row, or why it has no data
+func formatHostLine(host monitor.HostStatus, precision int) string {
+	name := truncateString(host.Host, 24)
+	if host.Err != nil {
+		return fmt.Sprintf("  %-24s %s", name, host.Err)
+	}
+	m := host.System
+	if m == nil {
+		return fmt.Sprintf("  %-24s %s", name, "measuring…")
+	}
+	swap := "-"
+	if m.SwapTotal > 0 {
+		swap = fmt.Sprintf("%.*f%%", precision, m.SwapPercent)
+	}
+	return fmt.Sprintf("  %-24s %7.*f%% %7.*f%% %8s %16s %7d",
+		name, precision, m.CPUPercent, precision, m.MemoryPercent, swap,
+		fmt.Sprintf("%.2f %.2f %.2f", m.Load1, m.Load5, m.Load15), host.Tasks.Total)
+}
+
+// renderHosts lists each host's system metrics, colored by its busier of
+// CPU and memory; hosts that are down show why in the error color
+func (d *Display) renderHosts(width, maxRows int, hosts []monitor.HostStatus) {
+	top := d.processTop()
+	currentY := top
+	right := width - processXOffset*2
+	d.drawText(processXOffset, currentY, right, hostHeader(), d.colorScheme.GetStyle(d.colorScheme.Accent, false))
+	currentY++
+
+	precision := d.config.GetPrecision()
+	for _, host := range hosts {
+		if currentY >= top+maxRows {
+			break
+		}
+		color := d.colorScheme.Muted
+		switch {
+		case host.Err != nil:
+			color = d.colorScheme.Error
+		case host.System != nil:
+			color = d.colorScheme.GetProgressBarColor(max(host.System.CPUPercent, host.System.MemoryPercent))
+		}
+		d.drawText(processXOffset, currentY, right, formatHostLine(host, precision), d.colorScheme.GetStyle(color, false))
+		currentY++
+	}
+}
@@ -1,42 +1,239 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
 	"github.com/gdamore/tcell/v2"
 )
 
+// statusDuration is how long transient footer messages stay visible
+const statusDuration = 3 * time.Second
+
+// keyRefreshInterval rate-limits refresh-on-key so holding an arrow key
+// doesn't rescan every process on each repeat
+const keyRefreshInterval = 250 * time.Millisecond
+
+// refreshingActions are the bindings that fetch fresh data when
+// refresh-on-key is enabled, so the row just selected isn't stale
+var refreshingActions = map[string]bool{
+	"up":     true,
+	"down":   true,
+	"first":  true,
+	"last":   true,
+	"expand": true,
+}
+
+// InputHandler dispatches key events through the keymap; overlays that have
+// focus get their keys first
 type InputHandler struct {
-	display *Display
+	display        *Display
+	bindings       []keyBinding
+	lastKeyRefresh time.Time // Only touched from the input goroutine
 }
 
+// NewInputHandler builds the keymap from the defaults plus any overrides in
+// the display's config; invalid overrides are reported by CheckKeyBindings
 func NewInputHandler(display *Display) *InputHandler {
+	bindings, _ := buildKeymap(display.config.GetKeyBindings())
 	return &InputHandler{
-		display: display,
+		display:  display,
+		bindings: bindings,
 	}
 }
 
 func (ih *InputHandler) HandleInput(ev *tcell.EventKey) bool {
+	if ih.display.HelpOpen() {
+		return ih.handleOverlayInput(ev, "help", ih.display.ToggleHelp)
+	}
+	if ih.display.LegendOpen() {
+		return ih.handleOverlayInput(ev, "legend", ih.display.ToggleLegend)
+	}
+	if ih.display.TaskGraphOpen() {
+		return ih.handleOverlayInput(ev, "task-graph", ih.display.ToggleTaskGraph)
+	}
+	if ih.display.SettingsOpen() {
+		return ih.handleSettingsInput(ev)
+	}
+	if ih.display.SignalPromptOpen() {
+		return ih.handleSignalInput(ev)
+	}
+	if ih.display.DetailOpen() {
+		return ih.handleDetailInput(ev)
+	}
+	if ih.display.ColumnModeOpen() {
+		return ih.handleColumnInput(ev)
+	}
+	if ih.display.FilterOpen() {
+		return ih.handleFilterInput(ev)
+	}
+
+	if b, ok := lookup(ih.bindings, ev); ok {
+		if !b.run(ih.display) {
+			return false
+		}
+		if refreshingActions[b.action] {
+			ih.refreshOnKey()
+		}
+	}
+	return true
+}
+
+// refreshOnKey requests a refresh after navigation when enabled, at most
+// once per keyRefreshInterval and never while paused
+func (ih *InputHandler) refreshOnKey() {
+	if !ih.display.config.GetRefreshOnKey() || ih.display.Paused() {
+		return
+	}
+	if now := time.Now(); now.Sub(ih.lastKeyRefresh) >= keyRefreshInterval {
+		ih.lastKeyRefresh = now
+		ih.display.ForceRefresh()
+	}
+}
+
+// handleOverlayInput gives a read-only overlay focus: its own key (action),
+// Esc or q close it, and Ctrl+C still quits
+func (ih *InputHandler) handleOverlayInput(ev *tcell.EventKey, action string, toggle func()) bool {
+	if ev.Key() == tcell.KeyCtrlC {
+		return false
+	}
+	if b, ok := lookup(ih.bindings, ev); ok && (b.action == action || b.action == "quit") {
+		toggle()
+	}
+	return true
+}
+
+// handleDetailInput routes keys while the detail pane has focus: v switches
+// to the environment, which scrolls and reveals its hidden values with r
+func (ih *InputHandler) handleDetailInput(ev *tcell.EventKey) bool {
+	if ev.Key() == tcell.KeyRune && (ev.Rune() == 'v' || ev.Rune() == 'V') {
+		ih.display.ToggleEnv()
+		return true
+	}
+	if ih.display.EnvOpen() {
+		switch ev.Key() {
+		case tcell.KeyUp:
+			ih.display.ScrollEnv(-1)
+			return true
+		case tcell.KeyDown:
+			ih.display.ScrollEnv(1)
+			return true
+		case tcell.KeyPgUp:
+			ih.display.ScrollEnv(-10)
+			return true
+		case tcell.KeyPgDn:
+			ih.display.ScrollEnv(10)
+			return true
+		case tcell.KeyRune:
+			if ev.Rune() == 'r' || ev.Rune() == 'R' {
+				ih.display.ToggleEnvReveal()
+				return true
+			}
+		}
+	}
+	return ih.handleOverlayInput(ev, "details", ih.display.ToggleDetail)
+}
+
+// handleSettingsInput routes keys while the settings overlay has focus
+func (ih *InputHandler) handleSettingsInput(ev *tcell.EventKey) bool {
 	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyCtrlC:
+	case tcell.KeyCtrlC:
 		return false
+	case tcell.KeyEscape:
+		ih.display.ToggleSettings()
 	case tcell.KeyRune:
 		switch ev.Rune() {
+		case 'o', 'O':
+			ih.display.ToggleSettings()
 		case 'q', 'Q':
 			return false
-		case ' ':
-			ih.display.TogglePause()
-		case 'r', 'R':
-			ih.display.ForceRefresh()
 		}
 	case tcell.KeyUp:
-		ih.display.MoveCursor(-1)
+		ih.display.MoveSettingsCursor(-1)
+	case tcell.KeyDown:
+		ih.display.MoveSettingsCursor(1)
+	case tcell.KeyLeft:
+		ih.display.AdjustSetting(-1)
+	case tcell.KeyRight, tcell.KeyEnter:
+		ih.display.AdjustSetting(1)
+	}
+	return true
+}
+
+// handleSignalInput routes keys while the signal prompt has focus; only
+// Enter sends, so a stray key can't signal anything
+func (ih *InputHandler) handleSignalInput(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return false
+	case tcell.KeyEscape:
+		ih.display.CloseSignalPrompt()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'k', 'K', 'q', 'Q':
+			ih.display.CloseSignalPrompt()
+		}
+	case tcell.KeyUp:
+		ih.display.MoveSignalCursor(-1)
 	case tcell.KeyDown:
-		ih.display.MoveCursor(1)
+		ih.display.MoveSignalCursor(1)
+	case tcell.KeyEnter:
+		ih.display.SendSelectedSignal()
+	}
+	return true
+}
+
+// handleFilterInput edits the filter prompt: printable keys type, Tab
+// switches between substring and regex, Enter applies and Esc cancels
+func (ih *InputHandler) handleFilterInput(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return false
+	case tcell.KeyEscape:
+		ih.display.CloseFilter()
 	case tcell.KeyEnter:
-		ih.display.ToggleExpanded()
-	case tcell.KeyHome:
-		ih.display.SetCursor(0)
-	case tcell.KeyEnd:
-		ih.display.SetCursor(-1)
+		ih.display.ApplyFilter()
+	case tcell.KeyTab:
+		ih.display.ToggleFilterRegex()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		ih.display.EditFilter(0)
+	case tcell.KeyRune:
+		ih.display.EditFilter(ev.Rune())
+	}
+	return true
+}
+
+// handleColumnInput gives column arrangement mode focus: ←/→ pick a
+// column, Shift+←/→ or </> move it, and Enter, Esc or its own key leave
+func (ih *InputHandler) handleColumnInput(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return false
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ih.display.ToggleColumnMode()
+	case tcell.KeyLeft, tcell.KeyRight:
+		delta := 1
+		if ev.Key() == tcell.KeyLeft {
+			delta = -1
+		}
+		if ev.Modifiers()&tcell.ModShift != 0 {
+			ih.display.MoveSelectedColumn(delta)
+		} else {
+			ih.display.SelectColumn(delta)
+		}
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case '<', ',':
+			ih.display.MoveSelectedColumn(-1)
+		case '>', '.':
+			ih.display.MoveSelectedColumn(1)
+		default:
+			if b, ok := lookup(ih.bindings, ev); ok && (b.action == "columns" || b.action == "quit") {
+				ih.display.ToggleColumnMode()
+			}
+		}
 	}
 	return true
 }
@@ -47,10 +244,103 @@ func (d *Display) TogglePause() {
 	d.paused = !d.paused
 }
 
-func (d *Display) ForceRefresh() {
+// Paused reports whether automatic updates are paused
+func (d *Display) Paused() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.paused
+}
+
+// ToggleCategoryView switches between the process list and per-category totals
+func (d *Display) ToggleCategoryView() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.categoryView = !d.categoryView
+	d.containerView = false
+}
+
+// ToggleContainerView switches between the process list and per-container
+// totals
+func (d *Display) ToggleContainerView() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.forceRefresh = true
+	d.containerView = !d.containerView
+	d.categoryView = false
+}
+
+// CycleSortMode switches to the next sort mode and re-sorts right away
+func (d *Display) CycleSortMode() {
+	d.config.SetSortMode(d.config.GetSortMode().Next())
+	d.ForceRefresh()
+}
+
+// ToggleCollapseThreads switches between listing threads and a summary row
+func (d *Display) ToggleCollapseThreads() {
+	d.config.SetCollapseThreads(!d.config.GetCollapseThreads())
+}
+
+// ToggleAggregateAll switches between summing only related (same-named)
+// children into their parent and summing every child, then rescans so the
+// totals change at once
+func (d *Display) ToggleAggregateAll() {
+	all := !d.config.GetAggregateAllChildren()
+	d.config.SetAggregateAllChildren(all)
+	d.mu.Lock()
+	if all {
+		d.setStatus("Summing every child into its parent")
+	} else {
+		d.setStatus("Summing same-named children only")
+	}
+	d.mu.Unlock()
+	d.ForceRefresh()
+}
+
+// ToggleTreeView switches between the filtered, aggregated list and every
+// process as an indented hierarchy, rescanning so the switch is immediate
+func (d *Display) ToggleTreeView() {
+	tree := !d.config.GetTreeView()
+	d.config.SetTreeView(tree)
+	d.mu.Lock()
+	d.frozenOrder = nil
+	d.selectedIndex, d.scrollOffset = 0, 0
+	if tree {
+		d.setStatus("Tree view: every process, Enter collapses a branch")
+	} else {
+		d.setStatus("Filtered view")
+	}
+	d.mu.Unlock()
+	d.ForceRefresh()
+}
+
+// ToggleMemPercent shows or hides the share-of-system-RAM column
+func (d *Display) ToggleMemPercent() {
+	d.config.SetShowMemPercent(!d.config.GetShowMemPercent())
+}
+
+// ToggleCPUDelta switches the CPU column between each row's reading and its
+// change since the previous refresh
+func (d *Display) ToggleCPUDelta() {
+	d.config.SetCPUDelta(!d.config.GetCPUDelta())
+}
+
+// CycleCPUBar switches the inline CPU bar between off, absolute and
+// relative scaling
+func (d *Display) CycleCPUBar() {
+	d.config.SetCPUBar(d.config.GetCPUBar().Next())
+}
+
+// ToggleProfile shows or hides scan and render timings in the footer
+func (d *Display) ToggleProfile() {
+	d.config.SetProfile(!d.config.GetProfile())
+}
+
+// ForceRefresh asks updateLoop for an immediate refresh, even while paused.
+// Requests made while one is already pending are coalesced.
+func (d *Display) ForceRefresh() {
+	select {
+	case d.refreshRequests <- struct{}{}:
+	default:
+	}
 }
 
 func (d *Display) MoveCursor(delta int) {
@@ -70,6 +360,22 @@ func (d *Display) MoveCursor(delta int) {
 	d.adjustScrollOffset()
 }
 
+// Pan scrolls the process table horizontally by delta steps, stopping once
+// the widest row from the last render is fully in view
+func (d *Display) Pan(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	offset := d.hOffset + delta*hScrollStep
+	if overflow := int(d.tableOverflow.Load()); offset > overflow {
+		offset = overflow
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	d.hOffset = offset
+}
+
 func (d *Display) SetCursor(pos int) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -95,4 +401,57 @@ func (d *Display) ToggleExpanded() {
 	}
 	selectedProcess := d.processes[d.selectedIndex]
 	d.monitor.ToggleExpanded(selectedProcess.PID)
+	if d.config.GetTreeView() {
+		// The tree only drops or restores descendants on a scan
+		d.ForceRefresh()
+	}
+}
+
+// ExportTree writes the selected process's tree to a timestamped text file
+// in the working directory
+func (d *Display) ExportTree() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.processes) == 0 || d.selectedIndex >= len(d.processes) {
+		return
+	}
+	selectedProcess := d.processes[d.selectedIndex]
+
+	filename := fmt.Sprintf("brieftop-tree-%d-%s.txt", selectedProcess.PID, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(filename, []byte(monitor.FormatTree(selectedProcess)), 0o644); err != nil {
+		d.setStatus(fmt.Sprintf("Export failed: %v", err))
+		return
+	}
+	d.setStatus("Saved tree to " + filename)
+}
+
+// ExcludeSelected hides every process named like the selected one for the
+// rest of the session. The rows go at once rather than on the next refresh.
+func (d *Display) ExcludeSelected() {
+	d.mu.Lock()
+	if len(d.processes) == 0 || d.selectedIndex >= len(d.processes) {
+		d.mu.Unlock()
+		return
+	}
+	name := d.processes[d.selectedIndex].Name
+	d.config.AddExclude(monitor.ExcludePattern(name))
+	d.setStatus(fmt.Sprintf("Hiding processes named %q", name))
+	kept := make([]*monitor.ProcessInfo, 0, len(d.processes))
+	for _, proc := range d.processes {
+		if proc.Name != name {
+			kept = append(kept, proc)
+		}
+	}
+	d.processes = kept
+	d.clampSelection()
+	d.mu.Unlock()
+
+	d.ForceRefresh()
+}
+
+// setStatus shows a transient footer message; callers must hold d.mu
+func (d *Display) setStatus(msg string) {
+	d.statusMessage = msg
+	d.statusExpiry = time.Now().Add(statusDuration)
+	d.dirty.Store(true)
 }
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRefreshOnKeyIsRateLimited(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, nil)
+	down := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+
+	pending := func() bool {
+		select {
+		case <-d.refreshRequests:
+			return true
+		default:
+			return false
+		}
+	}
+
+	d.inputHandler.HandleInput(down)
+	if pending() {
+		t.Fatal("navigation refreshed with refresh-on-key disabled")
+	}
+
+	cfg.SetRefreshOnKey(true)
+	d.inputHandler.HandleInput(down)
+	if !pending() {
+		t.Fatal("navigation did not refresh with refresh-on-key enabled")
+	}
+
+	d.inputHandler.HandleInput(down)
+	if pending() {
+		t.Error("second keypress within keyRefreshInterval refreshed again")
+	}
+
+	d.inputHandler.lastKeyRefresh = d.inputHandler.lastKeyRefresh.Add(-keyRefreshInterval)
+	d.TogglePause()
+	d.inputHandler.HandleInput(down)
+	if pending() {
+		t.Error("navigation refreshed while paused")
+	}
+}
+
+func TestExcludeSelectedClampsSelection(t *testing.T) {
+	cfg := config.New()
+	d := New(cfg, monitor.New(cfg))
+	d.viewRows.Store(2)
+	d.processes = []*monitor.ProcessInfo{
+		{PID: 1, Name: "web"},
+		{PID: 2, Name: "db"},
+		{PID: 3, Name: "worker"},
+		{PID: 4, Name: "worker"},
+	}
+	d.SetCursor(3)
+	if d.scrollOffset != 2 {
+		t.Fatalf("scrollOffset = %d; expected 2 with the last row selected", d.scrollOffset)
+	}
+
+	// Both workers go at once, taking the selected row with them
+	d.ExcludeSelected()
+	if len(d.processes) != 2 || d.selectedIndex != 1 || d.scrollOffset != 0 {
+		t.Fatalf("after excluding workers: %d rows, selected %d, scrolled %d; expected 2, 1, 0", len(d.processes), d.selectedIndex, d.scrollOffset)
+	}
+	d.ToggleExpanded()
+	d.MoveCursor(1)
+	if d.selectedIndex != 0 {
+		t.Errorf("MoveCursor wrapped to %d; expected 0", d.selectedIndex)
+	}
+
+	// Emptying the list leaves a zero selection that every action ignores
+	d.ExcludeSelected()
+	d.ExcludeSelected()
+	if len(d.processes) != 0 || d.selectedIndex != 0 || d.scrollOffset != 0 {
+		t.Fatalf("after excluding everything: %d rows, selected %d, scrolled %d", len(d.processes), d.selectedIndex, d.scrollOffset)
+	}
+	d.ToggleExpanded()
+	d.MoveCursor(1)
+	d.ExportTree()
+}
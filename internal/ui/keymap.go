@@ -0,0 +1,298 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// keySpec is one parsed key: either a special key or a rune
+type keySpec struct {
+	key  tcell.Key
+	r    rune
+	name string // As written in the keymap, e.g. "q" or "Ctrl+C"
+}
+
+// namedKeys maps keymap key names (lower-cased) to tcell keys
+var namedKeys = map[string]tcell.Key{
+	"esc":       tcell.KeyEscape,
+	"escape":    tcell.KeyEscape,
+	"enter":     tcell.KeyEnter,
+	"tab":       tcell.KeyTab,
+	"backspace": tcell.KeyBackspace2,
+	"delete":    tcell.KeyDelete,
+	"up":        tcell.KeyUp,
+	"down":      tcell.KeyDown,
+	"left":      tcell.KeyLeft,
+	"right":     tcell.KeyRight,
+	"home":      tcell.KeyHome,
+	"end":       tcell.KeyEnd,
+	"pgup":      tcell.KeyPgUp,
+	"pgdn":      tcell.KeyPgDn,
+}
+
+// keyLabels are the compact glyphs shown in the footer for some keys
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	"enter": "⏎",
+}
+
+// parseKey parses a key name such as "q", "Space", "Esc", "Up" or "Ctrl+C"
+func parseKey(name string) (keySpec, error) {
+	lower := strings.ToLower(name)
+	if key, ok := namedKeys[lower]; ok {
+		return keySpec{key: key, name: name}, nil
+	}
+	if lower == "space" {
+		return keySpec{key: tcell.KeyRune, r: ' ', name: name}, nil
+	}
+	if letter, ok := strings.CutPrefix(lower, "ctrl+"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return keySpec{key: tcell.KeyCtrlA + tcell.Key(letter[0]-'a'), name: name}, nil
+	}
+	if r, size := utf8.DecodeRuneInString(name); size == len(name) && r != utf8.RuneError {
+		return keySpec{key: tcell.KeyRune, r: r, name: name}, nil
+	}
+	return keySpec{}, fmt.Errorf("unknown key %q", name)
+}
+
+func (k keySpec) matches(ev *tcell.EventKey) bool {
+	if k.key == tcell.KeyRune {
+		return ev.Key() == tcell.KeyRune && ev.Rune() == k.r
+	}
+	return ev.Key() == k.key
+}
+
+// label is the key as shown in the footer and help overlay
+func (k keySpec) label() string {
+	if label, ok := keyLabels[strings.ToLower(k.name)]; ok {
+		return label
+	}
+	return k.name
+}
+
+// keyBinding ties an action to its keys and description. run returns false
+// to quit. footer groups bindings into one footer entry; empty hides it.
+type keyBinding struct {
+	action string
+	keys   []keySpec
+	desc   string
+	footer string
+	run    func(d *Display) bool
+}
+
+// defaultKeymap is the single source for key dispatch, the footer and the
+// help overlay
+var defaultKeymap = []struct {
+	action string
+	keys   []string
+	desc   string
+	footer string
+	run    func(d *Display) bool
+}{
+	{"up", []string{"Up"}, "Move selection up", "Navigate", func(d *Display) bool { d.MoveCursor(-1); return true }},
+	{"down", []string{"Down"}, "Move selection down", "Navigate", func(d *Display) bool { d.MoveCursor(1); return true }},
+	{"first", []string{"Home"}, "Jump to first process", "", func(d *Display) bool { d.SetCursor(0); return true }},
+	{"last", []string{"End"}, "Jump to last process", "", func(d *Display) bool { d.SetCursor(-1); return true }},
+	{"pan-left", []string{"Left"}, "Scroll the process table left", "", func(d *Display) bool { d.Pan(-1); return true }},
+	{"pan-right", []string{"Right"}, "Scroll the process table right to see truncated columns", "", func(d *Display) bool { d.Pan(1); return true }},
+	{"expand", []string{"Enter"}, "Expand/collapse process details", "Expand", func(d *Display) bool { d.ToggleExpanded(); return true }},
+	{"details", []string{"d", "D"}, "Show details for the selected process", "", func(d *Display) bool { d.ToggleDetail(); return true }},
+	{"pause", []string{"Space"}, "Pause/unpause updates", "Pause", func(d *Display) bool { d.TogglePause(); return true }},
+	{"refresh", []string{"r", "R"}, "Force refresh", "Refresh", func(d *Display) bool { d.ForceRefresh(); return true }},
+	{"export", []string{"e", "E"}, "Export selected process tree to a text file", "", func(d *Display) bool { d.ExportTree(); return true }},
+	{"save-layout", []string{"y", "Y"}, "Save which processes are expanded as the layout (--layout, or default)", "", func(d *Display) bool { d.SaveLayout(); return true }},
+	{"restore-layout", []string{"j", "J"}, "Restore the layout's expanded processes", "", func(d *Display) bool { d.RestoreLayout(); return true }},
+	{"export-svg", []string{"i", "I"}, "Save the screen as an SVG image", "", func(d *Display) bool { d.ExportSVG(); return true }},
+	{"signal", []string{"k", "K"}, "Send a signal (TERM, KILL, HUP, STOP, ...) to the selected process", "", func(d *Display) bool { d.OpenSignalPrompt(); return true }},
+	{"filter", []string{"/"}, "Filter by name or executable; Tab in the prompt switches to regex", "", func(d *Display) bool { d.OpenFilter(); return true }},
+	{"invert-filter", []string{"!"}, "Invert the filter to list everything it doesn't match", "", func(d *Display) bool { d.ToggleInvertFilter(); return true }},
+	{"exclude", []string{"x", "X"}, "Hide processes named like the selected one", "", func(d *Display) bool { d.ExcludeSelected(); return true }},
+	{"settings", []string{"o", "O"}, "Open settings overlay", "", func(d *Display) bool { d.ToggleSettings(); return true }},
+	{"categories", []string{"c", "C"}, "Toggle per-category resource totals", "", func(d *Display) bool { d.ToggleCategoryView(); return true }},
+	{"containers", []string{"z", "Z"}, "Toggle per-container resource totals (Docker, Podman, containerd)", "", func(d *Display) bool { d.ToggleContainerView(); return true }},
+	{"sort", []string{"s", "S"}, "Cycle sort order (cpu, mem, composite)", "", func(d *Display) bool { d.CycleSortMode(); return true }},
+	{"columns", []string{"g", "G"}, "Arrange columns: ←/→ pick one, Shift+←/→ or </> move it", "", func(d *Display) bool { d.ToggleColumnMode(); return true }},
+	{"freeze-sort", []string{"f", "F"}, "Freeze the row order while values keep updating; again to unfreeze", "", func(d *Display) bool { d.ToggleFreezeSort(); return true }},
+	{"collapse-threads", []string{"t", "T"}, "Merge threads into one summary row", "", func(d *Display) bool { d.ToggleCollapseThreads(); return true }},
+	{"tree", []string{"h", "H"}, "Toggle the full process tree (every process, no thresholds)", "", func(d *Display) bool { d.ToggleTreeView(); return true }},
+	{"aggregate-all", []string{"w", "W"}, "Sum every child into its parent, not only same-named ones", "", func(d *Display) bool { d.ToggleAggregateAll(); return true }},
+	{"cpu-bar", []string{"b", "B"}, "Cycle the CPU bar (off, absolute, relative to the busiest)", "", func(d *Display) bool { d.CycleCPUBar(); return true }},
+	{"mem-percent", []string{"m", "M"}, "Toggle the MEM% column", "", func(d *Display) bool { d.ToggleMemPercent(); return true }},
+	{"cpu-delta", []string{"u", "U"}, "Toggle CPU as the change since the previous refresh", "", func(d *Display) bool { d.ToggleCPUDelta(); return true }},
+	{"profile", []string{"p", "P"}, "Show scan and render timings in the footer", "", func(d *Display) bool { d.ToggleProfile(); return true }},
+	{"baseline", []string{"a", "A"}, "Capture a baseline and color rows heavier/lighter than it; again to clear", "", func(d *Display) bool { d.ToggleBaseline(); return true }},
+	{"summary", []string{"v", "V"}, "Toggle the summary-only dashboard view", "", func(d *Display) bool { d.ToggleSummaryOnly(); return true }},
+	{"task-graph", []string{"n", "N"}, "Graph the total process count over time (spot fork loops)", "", func(d *Display) bool { d.ToggleTaskGraph(); return true }},
+	{"legend", []string{"l", "L"}, "Show the color and icon legend", "", func(d *Display) bool { d.ToggleLegend(); return true }},
+	{"help", []string{"?"}, "Show this help", "Help", func(d *Display) bool { d.ToggleHelp(); return true }},
+	{"quit", []string{"q", "Q", "Esc", "Ctrl+C"}, "Quit application", "Quit", func(d *Display) bool { return false }},
+}
+
+// keyID identifies a key regardless of how its name was spelled
+type keyID struct {
+	key tcell.Key
+	r   rune
+}
+
+func (k keySpec) id() keyID {
+	return keyID{key: k.key, r: k.r}
+}
+
+// newKeymap parses the default keymap
+func newKeymap() []keyBinding {
+	bindings, _ := buildKeymap(nil)
+	return bindings
+}
+
+// buildKeymap applies overrides (action → key names) to the default keymap.
+// An override replaces the action's default keys and takes its keys away
+// from any other default binding. Unknown actions, unparsable keys and keys
+// claimed by two overrides are skipped and reported.
+func buildKeymap(overrides map[string][]string) ([]keyBinding, []error) {
+	var errs []error
+
+	known := make(map[string]bool, len(defaultKeymap))
+	for _, entry := range defaultKeymap {
+		known[entry.action] = true
+	}
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		if !known[action] {
+			errs = append(errs, fmt.Errorf("unknown key binding action %q", action))
+		}
+	}
+
+	// Overrides claim their keys first, in keymap order
+	claimed := make(map[keyID]string)
+	custom := make(map[string][]keySpec)
+	for _, entry := range defaultKeymap {
+		names, ok := overrides[entry.action]
+		if !ok {
+			continue
+		}
+		var specs []keySpec
+		for _, name := range names {
+			spec, err := parseKey(name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("binding %q: %w", entry.action, err))
+				continue
+			}
+			if other, taken := claimed[spec.id()]; taken {
+				errs = append(errs, fmt.Errorf("key %q bound to both %q and %q; keeping %q", name, other, entry.action, other))
+				continue
+			}
+			claimed[spec.id()] = entry.action
+			specs = append(specs, spec)
+		}
+		custom[entry.action] = specs
+	}
+
+	bindings := make([]keyBinding, 0, len(defaultKeymap))
+	for _, entry := range defaultKeymap {
+		b := keyBinding{action: entry.action, desc: entry.desc, footer: entry.footer, run: entry.run}
+		if specs, ok := custom[entry.action]; ok {
+			b.keys = specs
+		} else {
+			for _, name := range entry.keys {
+				spec, err := parseKey(name)
+				if err != nil {
+					panic(fmt.Sprintf("default keymap: %v", err))
+				}
+				if _, taken := claimed[spec.id()]; taken {
+					continue
+				}
+				b.keys = append(b.keys, spec)
+			}
+		}
+		if len(b.keys) == 0 {
+			errs = append(errs, fmt.Errorf("action %q has no keys left", entry.action))
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings, errs
+}
+
+// CheckKeyBindings reports problems with key overrides so they can be
+// shown at startup; the keymap itself skips the bad entries
+func CheckKeyBindings(overrides map[string][]string) []error {
+	_, errs := buildKeymap(overrides)
+	return errs
+}
+
+// lookup returns the binding for ev, if any
+func lookup(bindings []keyBinding, ev *tcell.EventKey) (keyBinding, bool) {
+	for _, b := range bindings {
+		for _, k := range b.keys {
+			if k.matches(ev) {
+				return b, true
+			}
+		}
+	}
+	return keyBinding{}, false
+}
+
+// footerControls builds the footer entries, joining the first key of each
+// binding that shares a footer label, e.g. "↑↓ Navigate"
+func footerControls(bindings []keyBinding) []string {
+	var controls []string
+	seen := make(map[string]bool)
+	for _, b := range bindings {
+		if b.footer == "" || seen[b.footer] {
+			continue
+		}
+		seen[b.footer] = true
+
+		var keys strings.Builder
+		for _, other := range bindings {
+			if other.footer == b.footer && len(other.keys) > 0 {
+				keys.WriteString(other.keys[0].label())
+			}
+		}
+		if keys.Len() == 0 {
+			continue
+		}
+		controls = append(controls, keys.String()+" "+b.footer)
+	}
+	return controls
+}
+
+// keyLabel is the first key bound to action, or "" if it has none
+func keyLabel(bindings []keyBinding, action string) string {
+	for _, b := range bindings {
+		if b.action == action && len(b.keys) > 0 {
+			return b.keys[0].label()
+		}
+	}
+	return ""
+}
+
+// helpLines lists every binding with all of its keys, for the help overlay
+func helpLines(bindings []keyBinding) []string {
+	lines := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if len(b.keys) == 0 {
+			continue
+		}
+		names := make([]string, len(b.keys))
+		for i, k := range b.keys {
+			names[i] = k.label()
+		}
+		lines = append(lines, fmt.Sprintf("%-16s %s [%s]", strings.Join(names, "/"), b.desc, b.action))
+	}
+	return lines
+}
+
+// ControlsHelp describes the default key bindings, one per line, for --help
+func ControlsHelp() []string {
+	return helpLines(newKeymap())
+}
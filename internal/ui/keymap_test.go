@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		ev      *tcell.EventKey
+		wantErr bool
+	}{
+		{name: "q", ev: tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone)},
+		{name: "?", ev: tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)},
+		{name: "Space", ev: tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)},
+		{name: "esc", ev: tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone)},
+		{name: "Up", ev: tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)},
+		{name: "Ctrl+C", ev: tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone)},
+		{name: "Ctrl+", wantErr: true},
+		{name: "qq", wantErr: true},
+		{name: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseKey(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseKey(%q) succeeded, want error", tt.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseKey(%q): %v", tt.name, err)
+			}
+			if !spec.matches(tt.ev) {
+				t.Errorf("parseKey(%q) does not match its event", tt.name)
+			}
+		})
+	}
+}
+
+func TestKeymapHasNoDuplicateKeys(t *testing.T) {
+	seen := make(map[keyID]string)
+	for _, b := range newKeymap() {
+		for _, k := range b.keys {
+			if other, ok := seen[k.id()]; ok {
+				t.Errorf("key bound to both %q and %q", other, b.action)
+			}
+			seen[k.id()] = b.action
+		}
+	}
+}
+
+func TestBuildKeymapOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		key       *tcell.EventKey
+		action    string
+		wantErrs  int
+	}{
+		{
+			name:      "remap to free key",
+			overrides: map[string][]string{"sort": {"x"}},
+			key:       tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone),
+			action:    "sort",
+		},
+		{
+			name:      "override steals default key",
+			overrides: map[string][]string{"sort": {"q"}},
+			key:       tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone),
+			action:    "sort",
+		},
+		{
+			name:      "unknown action ignored",
+			overrides: map[string][]string{"explode": {"x"}},
+			key:       tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModNone),
+			action:    "sort",
+			wantErrs:  1,
+		},
+		{
+			name:      "conflicting overrides keep first",
+			overrides: map[string][]string{"refresh": {"x"}, "sort": {"x", "y"}},
+			key:       tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone),
+			action:    "refresh",
+			wantErrs:  1,
+		},
+		{
+			name:      "action left without keys",
+			overrides: map[string][]string{"help": {"bogus"}},
+			key:       tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone),
+			action:    "quit",
+			wantErrs:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings, errs := buildKeymap(tt.overrides)
+			if len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors %v, want %d", len(errs), errs, tt.wantErrs)
+			}
+			b, ok := lookup(bindings, tt.key)
+			if !ok || b.action != tt.action {
+				t.Errorf("key dispatches to %q (found %v), want %q", b.action, ok, tt.action)
+			}
+		})
+	}
+}
+
+func TestFooterControlsFollowKeymap(t *testing.T) {
+	got := strings.Join(footerControls(newKeymap()), " │ ")
+	want := "↑↓ Navigate │ ⏎ Expand │ Space Pause │ r Refresh │ ? Help │ q Quit"
+	if got != want {
+		t.Errorf("footerControls = %q, want %q", got, want)
+	}
+
+	for _, line := range helpLines(newKeymap()) {
+		if strings.HasPrefix(line, "q/Q/Esc/Ctrl+C") && strings.HasSuffix(line, "[quit]") {
+			return
+		}
+	}
+	t.Error("help lines missing every quit key")
+}
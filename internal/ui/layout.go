@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// layoutsPath locates the saved layouts; replaced in tests
+var layoutsPath = config.LayoutsPath
+
+// SaveLayout saves which processes are expanded, by name, as the current
+// layout (--layout, or "default")
+func (d *Display) SaveLayout() {
+	name := d.config.GetLayout()
+	names, err := d.monitor.ExpandedNames()
+	if err == nil {
+		err = writeLayout(name, names)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.setStatus(fmt.Sprintf("Couldn't save layout %q: %v", name, err))
+		return
+	}
+	d.setStatus(fmt.Sprintf("Saved layout %q (%d expanded)", name, len(names)))
+}
+
+// RestoreLayout expands the current layout's processes and collapses the
+// rest; processes with those names that start later are expanded too
+func (d *Display) RestoreLayout() {
+	name := d.config.GetLayout()
+	names, err := readLayout(name)
+	if err == nil {
+		err = d.monitor.ExpandNames(names)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.setStatus(fmt.Sprintf("Couldn't restore layout %q: %v", name, err))
+		return
+	}
+	d.setStatus(fmt.Sprintf("Restored layout %q (%d expanded)", name, len(names)))
+	d.ForceRefresh()
+}
+
+// readLayout returns the names a saved layout expands
+func readLayout(name string) ([]string, error) {
+	path, err := layoutsPath()
+	if err != nil {
+		return nil, err
+	}
+	layouts, err := config.ReadLayouts(path)
+	if err != nil {
+		return nil, err
+	}
+	names, ok := layouts[name]
+	if !ok {
+		return nil, fmt.Errorf("not saved yet")
+	}
+	return names, nil
+}
+
+// writeLayout saves names as the named layout, keeping the others
+func writeLayout(name string, names []string) error {
+	path, err := layoutsPath()
+	if err != nil {
+		return err
+	}
+	layouts, err := config.ReadLayouts(path)
+	if err != nil {
+		return err
+	}
+	layouts[name] = names
+	return layouts.WriteFile(path)
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+// ToggleLegend opens or closes the color and icon legend
+func (d *Display) ToggleLegend() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.legendOpen = !d.legendOpen
+}
+
+// LegendOpen reports whether the legend currently has focus
+func (d *Display) LegendOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.legendOpen
+}
+
+// legendEntry is one legend row: a marker drawn in color, then its meaning
+type legendEntry struct {
+	marker string
+	color  tcell.Color
+	text   string
+}
+
+// legendEntries describes the row colors and icons. Colors come from the
+// active scheme and icons from statusTiers, so the legend always matches
+// what the process list draws.
+func (d *Display) legendEntries() []legendEntry {
+	cs := d.colorScheme
+	var entries []legendEntry
+	if d.baseline != nil {
+		entries = append(entries,
+			legendEntry{"■", cs.baselineColor(1), "Heavier than the baseline, or new since it"},
+			legendEntry{"■", cs.baselineColor(-1), "Lighter than the baseline"},
+			legendEntry{"■", cs.baselineColor(0), "About the same as the baseline"},
+		)
+	} else {
+		for _, level := range []monitor.ResourceLevel{monitor.High, monitor.Medium, monitor.Low} {
+			entries = append(entries, legendEntry{"■", cs.GetProcessColor(level), level.String() + ": " + level.Describe()})
+		}
+	}
+	entries = append(entries,
+		legendEntry{"■", cs.ChildProcess, "Child process"},
+		legendEntry{"■", cs.Thread, "Thread"},
+		legendEntry{"■", cs.Warning, fmt.Sprintf("Thrashing (≥%d major faults/s)", monitor.ThrashFaultRate)},
+		legendEntry{"[D]", cs.Error, "Uninterruptible sleep (blocked on I/O)"},
+	)
+	if d.config.GetHeatColumn() {
+		for _, level := range []monitor.ResourceLevel{monitor.High, monitor.Medium, monitor.Low} {
+			entries = append(entries, legendEntry{string(heatGlyphs[level]), cs.GetProcessColor(level), "Heat: " + level.String()})
+		}
+	}
+	if d.config.GetHighlightTop() {
+		entries = append(entries,
+			legendEntry{topCPUMarker, cs.Text, "Most CPU of the listed processes (bold)"},
+			legendEntry{topMemoryMarker, cs.Text, "Most memory of the listed processes (bold)"},
+		)
+	}
+	if len(d.config.GetCritical()) > 0 {
+		entries = append(entries, legendEntry{criticalMarker, cs.Text, "Critical process, always listed (underlined)"})
+	}
+	if linger := d.config.GetLinger(); linger > 0 {
+		entries = append(entries, legendEntry{"[exited]", cs.Muted, fmt.Sprintf("Exited, kept for %v", linger)})
+	}
+
+	thresholds := d.config.GetIconThresholds()
+	for _, tier := range statusTiers {
+		entries = append(entries, legendEntry{tier.icon, cs.Text, fmt.Sprintf("%s (≥%.0f%%)", tier.label, tier.threshold(thresholds))})
+	}
+	return append(entries,
+		legendEntry{idleIcon, cs.Text, "Idle"},
+		legendEntry{collapsedIcon, cs.Text, "Collapsed family"},
+		legendEntry{expandedIcon, cs.Text, "Expanded family"},
+	)
+}
+
+// renderLegend draws the legend centered over the process list
+func (d *Display) renderLegend(width, height int) {
+	entries := d.legendEntries()
+	markerWidth, boxWidth := 0, 0
+	for _, e := range entries {
+		if n := len([]rune(e.marker)); n > markerWidth {
+			markerWidth = n
+		}
+	}
+	for _, e := range entries {
+		if n := markerWidth + 1 + len([]rune(e.text)); n > boxWidth {
+			boxWidth = n
+		}
+	}
+	boxWidth += 6
+	boxHeight := len(entries) + 4
+	x := (width - boxWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+boxWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, boxWidth, boxHeight)
+
+	right := x + boxWidth - 2
+	d.drawText(x+2, y, right, " Legend ", d.colorScheme.GetStyle(d.colorScheme.Header, false))
+	for i, e := range entries {
+		d.drawText(x+3, y+2+i, right, e.marker, d.colorScheme.GetStyle(e.color, false))
+		d.drawText(x+4+markerWidth, y+2+i, right, e.text, textStyle)
+	}
+	d.drawText(x+2, y+boxHeight-1, right, " Esc close ", d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
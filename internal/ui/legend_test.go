@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+// TestLegendMatchesStatusIcons checks every icon GetStatusIcon can draw is
+// explained, and that the legend follows changed thresholds
+func TestLegendMatchesStatusIcons(t *testing.T) {
+	cfg := config.New()
+	cfg.SetIconThresholds(config.IconThresholds{High: 90, Medium: 40, Active: 2})
+	d := New(cfg, nil)
+
+	legend := make(map[string]string)
+	for _, e := range d.legendEntries() {
+		legend[e.marker] = e.text
+	}
+
+	thresholds := cfg.GetIconThresholds()
+	for _, cpu := range []float64{95, 50, 5, 0} {
+		icon := GetStatusIcon(cpu, false, false, thresholds)
+		if _, ok := legend[icon]; !ok {
+			t.Errorf("Icon %q for %.0f%% CPU is missing from the legend", icon, cpu)
+		}
+	}
+	for _, expanded := range []bool{false, true} {
+		if icon := GetStatusIcon(0, expanded, true, thresholds); legend[icon] == "" {
+			t.Errorf("Icon %q (expanded=%v) is missing from the legend", icon, expanded)
+		}
+	}
+	if text := legend[GetStatusIcon(95, false, false, thresholds)]; !strings.Contains(text, "90%") {
+		t.Errorf("High CPU entry %q doesn't reflect the configured threshold", text)
+	}
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// onelineHeaderWidth is the terminal width below which the header switches
+// to one line even without --oneline-header; the bars and their details
+// don't fit in less
+const onelineHeaderWidth = 80
+
+// onelineHeader reports whether the header summarizes the system metrics
+// on one line: with --oneline-header, or in a narrow pane such as a tmux split
+func (d *Display) onelineHeader() bool {
+	if d.config.GetOnelineHeader() {
+		return true
+	}
+	if d.screen == nil {
+		return false
+	}
+	width, _ := d.screen.Size()
+	return width > 0 && width < onelineHeaderWidth
+}
+
+// onelineSummary formats the system metrics densely, without bars, e.g.
+// "CPU 34% | MEM 62% | SWAP 5% | Load 2.10"
+func onelineSummary(m *monitor.SystemMetrics, precision int) string {
+	return strings.Join(onelineParts(m, precision), " | ")
+}
+
+// onelineParts are onelineSummary's readings, CPU and memory first
+func onelineParts(m *monitor.SystemMetrics, precision int) []string {
+	parts := []string{
+		fmt.Sprintf("CPU %.*f%%", precision, m.CPUPercent),
+		fmt.Sprintf("MEM %.*f%%", precision, m.MemoryPercent),
+	}
+	if m.SwapTotal > 0 {
+		parts = append(parts, fmt.Sprintf("SWAP %.*f%%", precision, m.SwapPercent))
+	}
+	if m.Load1 > 0 {
+		parts = append(parts, fmt.Sprintf("Load %.2f", m.Load1))
+	}
+	return parts
+}
+
+// drawOnelineHeader draws the one-line summary on line y, colored like the
+// busier of CPU and memory would be as a bar, with --header-deltas' arrows
+// after the CPU and memory readings. The stuck process warning, which has
+// no separator line to go on, follows it.
+func (d *Display) drawOnelineHeader(y, width int) {
+	m := d.systemMetrics
+	style := d.colorScheme.GetStyle(d.colorScheme.GetProgressBarColor(max(m.CPUPercent, m.MemoryPercent)), false)
+	deltas := []func(*monitor.SystemMetrics) float64{
+		func(m *monitor.SystemMetrics) float64 { return m.CPUPercent },
+		func(m *monitor.SystemMetrics) float64 { return m.MemoryPercent },
+	}
+	x := 2
+	for i, part := range onelineParts(m, d.config.GetPrecision()) {
+		if i > 0 {
+			part = " | " + part
+		}
+		d.drawText(x, y, width-2, part, style)
+		x += len([]rune(part))
+		if i < len(deltas) {
+			x += d.drawMetricDelta(x, y, width-2, deltas[i])
+		}
+	}
+	if len(d.stuck) > 0 {
+		d.drawText(x, y, width-2, " | "+stuckWarning(d.stuck), d.colorScheme.GetStyle(d.colorScheme.Error, false))
+	}
+}
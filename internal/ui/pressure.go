@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Pressure stall percentages (avg10) where the header starts warning, and
+// where it turns red: a fifth of the time with tasks waiting, or any real
+// share with every task waiting, is a stalled system rather than a busy one
+const (
+	pressureWarnSome = 5.0
+	pressureHighSome = 20.0
+	pressureHighFull = 5.0
+)
+
+// pressureSegment is one part of the PSI summary and the color it's drawn in
+type pressureSegment struct {
+	text  string
+	color tcell.Color
+}
+
+// pressureSegments renders PSI as e.g. "PSI cpu 1.2% mem 4.2% full 0.5%
+// io 0.3%", each resource colored by how stalled it is. Full is only shown
+// when tasks have actually been fully stalled.
+func (cs *ColorScheme) pressureSegments(p *monitor.Pressure, precision int) []pressureSegment {
+	segments := []pressureSegment{{"PSI", cs.Text}}
+	for _, r := range []struct {
+		name string
+		stat monitor.PressureStat
+	}{
+		{"cpu", p.CPU},
+		{"mem", p.Memory},
+		{"io", p.IO},
+	} {
+		text := fmt.Sprintf(" %s %.*f%%", r.name, precision, r.stat.Some)
+		if r.stat.Full > 0 {
+			text += fmt.Sprintf(" full %.*f%%", precision, r.stat.Full)
+		}
+		segments = append(segments, pressureSegment{text, cs.pressureColor(r.stat)})
+	}
+	return segments
+}
+
+// pressureColor is red for a stalled resource, yellow for one under some
+// pressure and muted otherwise
+func (cs *ColorScheme) pressureColor(stat monitor.PressureStat) tcell.Color {
+	switch {
+	case stat.Some >= pressureHighSome || stat.Full >= pressureHighFull:
+		return cs.Error
+	case stat.Some >= pressureWarnSome:
+		return cs.Warning
+	default:
+		return cs.Muted
+	}
+}
+
+// drawPressure draws the PSI summary right-aligned on row y if it fits
+// right of left
+func (d *Display) drawPressure(left, y, width int) {
+	segments := d.colorScheme.pressureSegments(d.systemMetrics.Pressure, d.config.GetPrecision())
+	length := 0
+	for _, segment := range segments {
+		length += len([]rune(segment.text))
+	}
+	x := width - length - 3
+	if x <= left+2 {
+		return
+	}
+	for _, segment := range segments {
+		d.drawText(x, y, width-2, segment.text, d.colorScheme.GetStyle(segment.color, false))
+		x += len([]rune(segment.text))
+	}
+}
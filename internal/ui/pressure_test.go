@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+func TestPressureSegments(t *testing.T) {
+	cs := NewColorScheme()
+	pressure := &monitor.Pressure{
+		CPU:    monitor.PressureStat{Some: 1.2},
+		Memory: monitor.PressureStat{Some: 8.4, Full: 0.5},
+		IO:     monitor.PressureStat{Some: 25, Full: 6},
+	}
+
+	expected := []pressureSegment{
+		{"PSI", cs.Text},
+		{" cpu 1.2%", cs.Muted},
+		{" mem 8.4% full 0.5%", cs.Warning},
+		{" io 25.0% full 6.0%", cs.Error},
+	}
+	segments := cs.pressureSegments(pressure, 1)
+	if len(segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d", len(expected), len(segments))
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("Segment %d = %+v; expected %+v", i, segments[i], expected[i])
+		}
+	}
+}
+
+func TestPressureColorFullStall(t *testing.T) {
+	cs := NewColorScheme()
+	if color := cs.pressureColor(monitor.PressureStat{Some: 6, Full: 5}); color != cs.Error {
+		t.Errorf("Expected a full stall of 5%% to be red, got %v", color)
+	}
+}
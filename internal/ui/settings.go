@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// Step sizes and bounds for the settings overlay
+const (
+	cpuThresholdStep = 1.0
+	memThresholdStep = 10 * 1024 * 1024 // 10MB
+	refreshRateStep  = 100 * time.Millisecond
+	minRefreshRate   = 100 * time.Millisecond
+	maxRefreshRate   = 10 * time.Second
+	settingsWidth    = 44
+)
+
+// settingsItem is one adjustable row in the settings overlay.
+// adjust is called with the display lock held and dir of -1 or +1.
+type settingsItem struct {
+	label  string
+	value  func(d *Display) string
+	adjust func(d *Display, dir int)
+}
+
+var settingsItems = []settingsItem{
+	{
+		label: "CPU threshold",
+		value: func(d *Display) string { return fmt.Sprintf("%.1f%%", d.config.GetCPUThreshold()) },
+		adjust: func(d *Display, dir int) {
+			threshold := d.config.GetCPUThreshold() + float64(dir)*cpuThresholdStep
+			if threshold < 0 {
+				threshold = 0
+			}
+			d.config.SetCPUThreshold(threshold)
+		},
+	},
+	{
+		label: "Memory threshold",
+		value: func(d *Display) string { return monitor.FormatBytes(d.config.GetMemoryThreshold()) },
+		adjust: func(d *Display, dir int) {
+			threshold := d.config.GetMemoryThreshold()
+			if dir < 0 {
+				if threshold < memThresholdStep {
+					threshold = 0
+				} else {
+					threshold -= memThresholdStep
+				}
+			} else {
+				threshold += memThresholdStep
+			}
+			d.config.SetMemoryThreshold(threshold)
+		},
+	},
+	{
+		label: "Refresh rate",
+		value: func(d *Display) string { return d.config.GetRefreshRate().String() },
+		adjust: func(d *Display, dir int) {
+			rate := d.config.GetRefreshRate() + time.Duration(dir)*refreshRateStep
+			if rate < minRefreshRate {
+				rate = minRefreshRate
+			} else if rate > maxRefreshRate {
+				rate = maxRefreshRate
+			}
+			d.config.SetRefreshRate(rate)
+			d.notifyRefreshRateChanged()
+		},
+	},
+	{
+		label: "Refresh on keys",
+		value: func(d *Display) string { return onOff(d.config.GetRefreshOnKey()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetRefreshOnKey(!d.config.GetRefreshOnKey())
+		},
+	},
+	{
+		label: "Child sort",
+		value: func(d *Display) string { return d.config.GetChildSort().String() },
+		adjust: func(d *Display, dir int) {
+			sort := d.config.GetChildSort().Next()
+			if dir < 0 {
+				sort = d.config.GetChildSort().Prev()
+			}
+			d.config.SetChildSort(sort)
+		},
+	},
+	{
+		label: "Category sort",
+		value: func(d *Display) string { return d.config.GetGroupSort().String() },
+		adjust: func(d *Display, dir int) {
+			sort := d.config.GetGroupSort().Next()
+			if dir < 0 {
+				sort = d.config.GetGroupSort().Prev()
+			}
+			d.config.SetGroupSort(sort)
+		},
+	},
+	{
+		label: "CPU bar",
+		value: func(d *Display) string { return d.config.GetCPUBar().String() },
+		adjust: func(d *Display, dir int) {
+			bar := d.config.GetCPUBar().Next()
+			if dir < 0 {
+				bar = d.config.GetCPUBar().Prev()
+			}
+			d.config.SetCPUBar(bar)
+		},
+	},
+	{
+		label: "Precision",
+		value: func(d *Display) string { return fmt.Sprintf("%d decimals", d.config.GetPrecision()) },
+		adjust: func(d *Display, dir int) {
+			d.config.SetPrecision(d.config.GetPrecision() + dir)
+		},
+	},
+	{
+		label: "Show threads",
+		value: func(d *Display) string { return onOff(d.config.GetShowThreads()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowThreads(!d.config.GetShowThreads())
+		},
+	},
+	{
+		label: "Collapse threads",
+		value: func(d *Display) string { return onOff(d.config.GetCollapseThreads()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetCollapseThreads(!d.config.GetCollapseThreads())
+		},
+	},
+	{
+		label: "Sum threads into parent",
+		value: func(d *Display) string { return onOff(d.config.GetSumThreads()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetSumThreads(!d.config.GetSumThreads())
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "Tree depth",
+		value: func(d *Display) string {
+			if depth := d.config.GetMaxTreeDepth(); depth > 0 {
+				return fmt.Sprintf("%d levels", depth)
+			}
+			return "unlimited"
+		},
+		adjust: func(d *Display, dir int) {
+			d.config.SetMaxTreeDepth(d.config.GetMaxTreeDepth() + dir)
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "Memory % column",
+		value: func(d *Display) string { return onOff(d.config.GetShowMemPercent()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowMemPercent(!d.config.GetShowMemPercent())
+		},
+	},
+	{
+		label: "Avg CPU column",
+		value: func(d *Display) string { return onOff(d.config.GetShowAvgCPU()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowAvgCPU(!d.config.GetShowAvgCPU())
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "Stacked memory bar",
+		value: func(d *Display) string { return onOff(d.config.GetStackedMemory()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetStackedMemory(!d.config.GetStackedMemory())
+		},
+	},
+	{
+		label: "Collapse header on scroll",
+		value: func(d *Display) string { return onOff(d.config.GetCollapseHeader()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetCollapseHeader(!d.config.GetCollapseHeader())
+		},
+	},
+	{
+		label: "One-line header",
+		value: func(d *Display) string { return onOff(d.config.GetOnelineHeader()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetOnelineHeader(!d.config.GetOnelineHeader())
+		},
+	},
+	{
+		label: "Bar characters",
+		value: func(d *Display) string {
+			if i := config.BarPreset(d.config.GetBarGlyphs()); i >= 0 {
+				return config.BarPresets[i].Name
+			}
+			return "custom"
+		},
+		adjust: func(d *Display, dir int) {
+			n := len(config.BarPresets)
+			i := config.BarPreset(d.config.GetBarGlyphs())
+			if i < 0 && dir < 0 {
+				i = n // Custom characters step to the first or last preset
+			}
+			d.config.SetBarGlyphs(config.BarPresets[(i+dir+n)%n].Glyphs)
+		},
+	},
+	{
+		label: "Header deltas",
+		value: func(d *Display) string { return onOff(d.config.GetHeaderDeltas()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetHeaderDeltas(!d.config.GetHeaderDeltas())
+		},
+	},
+	{
+		label: "Window title",
+		value: func(d *Display) string { return onOff(d.config.GetWindowTitle()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetWindowTitle(!d.config.GetWindowTitle())
+		},
+	},
+	{
+		label: "Highlight top CPU/memory",
+		value: func(d *Display) string { return onOff(d.config.GetHighlightTop()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetHighlightTop(!d.config.GetHighlightTop())
+		},
+	},
+	{
+		label: "Heat column",
+		value: func(d *Display) string { return onOff(d.config.GetHeatColumn()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetHeatColumn(!d.config.GetHeatColumn())
+		},
+	},
+	{
+		label: "Faults column",
+		value: func(d *Display) string { return onOff(d.config.GetShowFaults()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowFaults(!d.config.GetShowFaults())
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "CPU time column",
+		value: func(d *Display) string { return onOff(d.config.GetShowCPUTime()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowCPUTime(!d.config.GetShowCPUTime())
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "TTY column",
+		value: func(d *Display) string { return onOff(d.config.GetShowTTY()) },
+		adjust: func(d *Display, _ int) {
+			d.config.SetShowTTY(!d.config.GetShowTTY())
+			d.ForceRefresh()
+		},
+	},
+	{
+		label: "Pause updates",
+		value: func(d *Display) string { return onOff(d.paused) },
+		adjust: func(d *Display, _ int) {
+			d.paused = !d.paused
+		},
+	},
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// ToggleSettings opens or closes the settings overlay
+func (d *Display) ToggleSettings() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.settingsOpen = !d.settingsOpen
+}
+
+// SettingsOpen reports whether the settings overlay currently has focus
+func (d *Display) SettingsOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.settingsOpen
+}
+
+// MoveSettingsCursor moves the overlay selection, wrapping around
+func (d *Display) MoveSettingsCursor(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.settingsIndex = (d.settingsIndex + delta + len(settingsItems)) % len(settingsItems)
+}
+
+// AdjustSetting changes the selected overlay setting; dir is -1 or +1
+func (d *Display) AdjustSetting(dir int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	settingsItems[d.settingsIndex].adjust(d, dir)
+}
+
+// notifyRefreshRateChanged wakes updateLoop so the new rate applies immediately
+func (d *Display) notifyRefreshRateChanged() {
+	select {
+	case d.refreshRateChanged <- struct{}{}:
+	default:
+	}
+}
+
+// renderSettings draws the settings overlay centered over the process list
+func (d *Display) renderSettings(width, height int) {
+	boxHeight := len(settingsItems) + 4
+	x := (width - settingsWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+settingsWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, settingsWidth, boxHeight)
+
+	right := x + settingsWidth - 2
+	d.drawText(x+2, y, right, " Settings ", d.colorScheme.GetStyle(d.colorScheme.Header, false))
+
+	for i, item := range settingsItems {
+		line := fmt.Sprintf("%-20s ◀ %10s ▶", item.label, item.value(d))
+		d.drawText(x+3, y+2+i, right, line, d.colorScheme.GetStyle(d.colorScheme.Text, i == d.settingsIndex))
+	}
+
+	d.drawText(x+2, y+boxHeight-1, right, " ↑↓ select  ←→ adjust  Esc close ",
+		d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
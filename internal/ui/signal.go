@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// signalChoice is one row of the signal prompt
+type signalChoice struct {
+	name       string // As accepted by monitor.ParseSignal
+	help       string
+	terminates bool // Whether it normally ends the process
+}
+
+// signalChoices are offered in the signal prompt, most common first. Ones
+// the platform lacks are still listed and fail with a clear error.
+var signalChoices = []signalChoice{
+	{"TERM", "ask to exit", true},
+	{"KILL", "force exit", true},
+	{"HUP", "hang up / reload config", false},
+	{"INT", "interrupt, like Ctrl+C", true},
+	{"QUIT", "quit and dump core", true},
+	{"USR1", "application-defined", false},
+	{"USR2", "application-defined", false},
+	{"STOP", "pause", false},
+	{"CONT", "resume after STOP", false},
+}
+
+const signalPromptWidth = 48
+
+// OpenSignalPrompt opens the signal prompt for the selected process. The
+// target is fixed when the prompt opens, so a refresh reordering the list
+// can't redirect the signal.
+func (d *Display) OpenSignalPrompt() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.processes) == 0 || d.selectedIndex >= len(d.processes) {
+		return
+	}
+	proc := d.processes[d.selectedIndex]
+	d.signalOpen = true
+	d.signalIndex = 0
+	d.signalPID = proc.PID
+	d.signalTarget = proc.Name
+	d.signalImpact = signalImpact(proc, d.config.GetPrecision())
+}
+
+// signalImpact sums up what the target holds, from its aggregated totals,
+// so the prompt shows what stopping it would free. It's only drawn while a
+// terminating signal is highlighted. The signal only goes to
+// the parent, so children are "up to": they exit only if it takes them down.
+func signalImpact(proc *monitor.ProcessInfo, precision int) string {
+	impact := fmt.Sprintf("Frees up to %s, %.*f%% CPU", monitor.FormatBytes(proc.MemoryBytes), precision, proc.CPUPercent)
+	children := 0
+	for _, child := range proc.Children {
+		if !child.IsThread {
+			children++
+		}
+	}
+	switch children {
+	case 0:
+		return impact
+	case 1:
+		return impact + " (1 child)"
+	default:
+		return fmt.Sprintf("%s (%d children)", impact, children)
+	}
+}
+
+// CloseSignalPrompt closes the signal prompt without sending anything
+func (d *Display) CloseSignalPrompt() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.signalOpen = false
+}
+
+// SignalPromptOpen reports whether the signal prompt has keyboard focus
+func (d *Display) SignalPromptOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.signalOpen
+}
+
+// MoveSignalCursor moves the prompt selection, wrapping around
+func (d *Display) MoveSignalCursor(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.signalIndex = (d.signalIndex + delta + len(signalChoices)) % len(signalChoices)
+}
+
+// SendSelectedSignal sends the highlighted signal to the prompt's target,
+// closes the prompt and reports the outcome in the footer
+func (d *Display) SendSelectedSignal() {
+	d.mu.Lock()
+	choice := signalChoices[d.signalIndex]
+	pid, target := d.signalPID, d.signalTarget
+	d.signalOpen = false
+	d.mu.Unlock()
+
+	err := d.sendSignal(pid, target, choice.name)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if errors.Is(err, monitor.ErrProcessGone) {
+		d.setStatus(fmt.Sprintf("%s (%d) no longer exists; SIG%s not sent", target, pid, choice.name))
+		d.ForceRefresh()
+		return
+	}
+	if err != nil {
+		d.setStatus(fmt.Sprintf("Failed to send SIG%s to %s (%d): %v", choice.name, target, pid, err))
+		return
+	}
+	d.setStatus(fmt.Sprintf("Sent SIG%s to %s (%d)", choice.name, target, pid))
+	d.ForceRefresh()
+}
+
+// sendSignal validates name and sends it to pid, if pid is still target
+func (d *Display) sendSignal(pid int32, target, name string) error {
+	sig, err := monitor.ParseSignal(name)
+	if err != nil {
+		return err
+	}
+	if d.monitor == nil {
+		return syscall.ESRCH
+	}
+	return d.monitor.SendSignal(pid, target, sig)
+}
+
+// renderSignalPrompt draws the signal list centered over the process list
+func (d *Display) renderSignalPrompt(width, height int) {
+	boxHeight := len(signalChoices) + 6
+	x := (width - signalPromptWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+signalPromptWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, signalPromptWidth, boxHeight)
+
+	right := x + signalPromptWidth - 2
+	title := fmt.Sprintf(" Signal %s (%d) ", d.signalTarget, d.signalPID)
+	d.drawText(x+2, y, right, title, d.colorScheme.GetStyle(d.colorScheme.Header, false))
+
+	if signalChoices[d.signalIndex].terminates {
+		d.drawText(x+3, y+2, right, d.signalImpact, d.colorScheme.GetStyle(d.colorScheme.Warning, false))
+	}
+	for i, choice := range signalChoices {
+		line := fmt.Sprintf("SIG%-5s %s", choice.name, choice.help)
+		d.drawText(x+3, y+4+i, right, line, d.colorScheme.GetStyle(d.colorScheme.Text, i == d.signalIndex))
+	}
+
+	d.drawText(x+2, y+boxHeight-1, right, " ↑↓ select  Enter send  Esc cancel ",
+		d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSignalPromptTargetsSelection(t *testing.T) {
+	d := New(config.New(), nil)
+
+	d.OpenSignalPrompt()
+	if d.SignalPromptOpen() {
+		t.Fatal("Expected the prompt to stay closed with nothing selected")
+	}
+
+	d.processes = []*monitor.ProcessInfo{{PID: 10, Name: "nginx"}, {PID: 20, Name: "postgres"}}
+	d.selectedIndex = 1
+	d.OpenSignalPrompt()
+	if !d.SignalPromptOpen() || d.signalPID != 20 || d.signalTarget != "postgres" {
+		t.Fatalf("Expected the prompt to target postgres (20), got %q (%d)", d.signalTarget, d.signalPID)
+	}
+
+	// A refresh that reorders the list doesn't change the target
+	d.processes[0], d.processes[1] = d.processes[1], d.processes[0]
+	d.MoveSignalCursor(-1)
+	if choice := signalChoices[d.signalIndex]; choice.name != "CONT" {
+		t.Errorf("Expected the cursor to wrap to CONT, got %s", choice.name)
+	}
+	d.SendSelectedSignal()
+	if d.SignalPromptOpen() {
+		t.Error("Expected sending to close the prompt")
+	}
+	if !strings.Contains(d.statusMessage, "SIGCONT to postgres (20)") {
+		t.Errorf("Expected the footer to name the signal and target, got %q", d.statusMessage)
+	}
+}
+
+func TestSendSignalValidatesName(t *testing.T) {
+	d := New(config.New(), nil)
+	if err := d.sendSignal(1, "init", "RELOAD"); err == nil || !strings.Contains(err.Error(), "unknown signal") {
+		t.Errorf("Expected an unknown signal error, got %v", err)
+	}
+}
+
+func TestSignalImpact(t *testing.T) {
+	proc := &monitor.ProcessInfo{
+		PID:         1234,
+		Name:        "chrome",
+		CPUPercent:  40,
+		MemoryBytes: 1288490189, // 1.2 GB
+		Children:    []monitor.ChildInfo{{PID: 1235}, {PID: 1236}, {PID: 1234, IsThread: true}},
+	}
+	if got := signalImpact(proc, 1); got != "Frees up to 1.2 GB, 40.0% CPU (2 children)" {
+		t.Errorf("signalImpact = %q", got)
+	}
+	proc.Children = proc.Children[2:]
+	if got := signalImpact(proc, 0); got != "Frees up to 1.2 GB, 40% CPU" {
+		t.Errorf("signalImpact without child processes = %q", got)
+	}
+}
+
+// TestSignalPromptImpactOnlyForTerminating checks "Frees up to" is only
+// shown while the highlighted signal would end the process
+func TestSignalPromptImpactOnlyForTerminating(t *testing.T) {
+	d := New(config.New(), nil)
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 24)
+	d.screen = screen
+
+	d.processes = []*monitor.ProcessInfo{{PID: 10, Name: "nginx", MemoryBytes: 1 << 30}}
+	d.OpenSignalPrompt()
+	for i, choice := range signalChoices {
+		d.signalIndex = i
+		screen.Clear()
+		d.renderSignalPrompt(80, 24)
+		var text strings.Builder
+		for y := 0; y < 24; y++ {
+			for x := 0; x < 80; x++ {
+				r, _, _, _ := screen.GetContent(x, y)
+				text.WriteRune(r)
+			}
+		}
+		if got := strings.Contains(text.String(), "Frees up to"); got != choice.terminates {
+			t.Errorf("SIG%s: impact shown = %v, want %v", choice.name, got, choice.terminates)
+		}
+	}
+}
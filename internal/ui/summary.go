@@ -0,0 +1,80 @@
+package ui
+
+import "fmt"
+
+// summaryBarRows is how tall each bar is drawn in the summary-only view
+const summaryBarRows = 2
+
+// ToggleSummaryOnly switches between the process list and the summary-only
+// dashboard view
+func (d *Display) ToggleSummaryOnly() {
+	d.config.SetSummaryOnly(!d.config.GetSummaryOnly())
+}
+
+// summaryMetric is one metric in the summary-only view: a caption line and
+// a full-width bar
+type summaryMetric struct {
+	label   string
+	percent float64
+	details string
+}
+
+// summaryMetrics lists what the summary-only view shows
+func (d *Display) summaryMetrics() []summaryMetric {
+	m, precision := d.systemMetrics, d.config.GetPrecision()
+	metrics := []summaryMetric{
+		{"CPU", m.CPUPercent, cpuDetails(m, precision)},
+		{"MEM", m.MemoryPercent, memoryDetails(m, precision)},
+	}
+	if m.SwapTotal > 0 {
+		metrics = append(metrics, summaryMetric{"SWAP", m.SwapPercent, swapDetails(m, precision)})
+	}
+	return metrics
+}
+
+// renderSummary draws the system metrics large and centered in place of the
+// header and process list, for a wall dashboard
+func (d *Display) renderSummary(width, height int) {
+	headerText := "⚙️  " + headerTitle(d.config)
+	d.drawText(2, 1, width-4, headerText, d.colorScheme.GetStyle(d.colorScheme.Header, false))
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	mutedStyle := d.colorScheme.GetStyle(d.colorScheme.Muted, false)
+	if d.systemMetrics == nil {
+		d.drawText(4, height/2, width-4, "measuring…", mutedStyle)
+		return
+	}
+
+	var extra []string
+	if d.systemMetrics.Load1 > 0 || d.systemMetrics.Load5 > 0 || d.systemMetrics.Load15 > 0 {
+		extra = append(extra, fmt.Sprintf("Load average: %.2f %.2f %.2f",
+			d.systemMetrics.Load1, d.systemMetrics.Load5, d.systemMetrics.Load15))
+	}
+	if d.tasks.Total > 0 {
+		extra = append(extra, d.tasks.String())
+	}
+
+	metrics := d.summaryMetrics()
+	blockHeight := len(metrics)*(summaryBarRows+2) + len(extra)
+	top := 2
+	if free := height - footerRows - 2; free > blockHeight {
+		top += (free - blockHeight) / 2
+	}
+
+	left := 6
+	barWidth := width - left*2
+	y := top
+	for _, metric := range metrics {
+		d.drawText(left, y, width-2, fmt.Sprintf("%-5s %s", metric.label, metric.details), textStyle)
+		bar := CreateProgressBar(metric.percent, barWidth, d.config.GetBarGlyphs())
+		barStyle := d.colorScheme.GetStyle(d.colorScheme.GetProgressBarColor(metric.percent), false)
+		for row := 1; row <= summaryBarRows; row++ {
+			d.drawText(left, y+row, width-2, bar, barStyle)
+		}
+		y += summaryBarRows + 2
+	}
+	for _, line := range extra {
+		d.drawText(left, y, width-2, line, mutedStyle)
+		y++
+	}
+}
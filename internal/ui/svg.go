@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SVG cell metrics in pixels; the font size suits a cell of this size in
+// common monospace fonts
+const (
+	svgCellWidth  = 9
+	svgCellHeight = 18
+	svgFontSize   = 15
+)
+
+// ExportSVG saves what's on screen as an SVG image: each cell's character
+// in its colors, as text, so no font rasterizing is needed and it stays
+// crisp at any zoom
+func (d *Display) ExportSVG() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	filename := fmt.Sprintf("brieftop-screen-%s.svg", time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(filename, []byte(screenSVG(d.screen, d.colorScheme)), 0o644); err != nil {
+		d.setStatus(fmt.Sprintf("Export failed: %v", err))
+		return
+	}
+	d.setStatus("Saved screen to " + filename)
+}
+
+// svgRun is a stretch of cells on one row drawn in the same style
+type svgRun struct {
+	x    int
+	text strings.Builder
+	fg   string
+	bg   string
+	bold bool
+}
+
+// svgColor is c as an SVG color, or fallback where c is the terminal default
+func svgColor(c tcell.Color, fallback string) string {
+	if hex := c.Hex(); hex >= 0 {
+		return fmt.Sprintf("#%06x", hex)
+	}
+	return fallback
+}
+
+// screenSVG renders the screen's cells. Each run of same-styled cells is
+// one text element placed at its own column, so a glyph drawn a little
+// wider than a cell (emoji, mostly) can't push the rest of the row out of
+// line.
+func screenSVG(screen tcell.Screen, cs *ColorScheme) string {
+	width, height := screen.Size()
+	defaultFg, defaultBg := "#dcdceb", "#0f0f19"
+	if !cs.mono {
+		defaultFg, defaultBg = svgColor(cs.Text, defaultFg), svgColor(cs.Background, defaultBg)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="Menlo, Consolas, 'DejaVu Sans Mono', monospace" font-size="%d">`+"\n",
+		width*svgCellWidth, height*svgCellHeight, svgFontSize)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", defaultBg)
+
+	for y := 0; y < height; y++ {
+		var runs []*svgRun
+		var current *svgRun
+		for x := 0; x < width; {
+			r, combining, style, cellWidth := screen.GetContent(x, y)
+			fg, bg, attrs := style.Decompose()
+			fgHex, bgHex := svgColor(fg, defaultFg), svgColor(bg, defaultBg)
+			if attrs&tcell.AttrReverse != 0 {
+				fgHex, bgHex = bgHex, fgHex
+			}
+			bold := attrs&tcell.AttrBold != 0
+
+			if current == nil || current.fg != fgHex || current.bg != bgHex || current.bold != bold {
+				current = &svgRun{x: x, fg: fgHex, bg: bgHex, bold: bold}
+				runs = append(runs, current)
+			}
+			if r == 0 {
+				r = ' '
+			}
+			current.text.WriteRune(r)
+			for _, c := range combining {
+				current.text.WriteRune(c)
+			}
+			x += max(cellWidth, 1)
+			// The cell after a wide glyph starts a run of its own, on its
+			// own column
+			if cellWidth > 1 {
+				current = nil
+			}
+		}
+
+		for i, run := range runs {
+			end := width
+			if i+1 < len(runs) {
+				end = runs[i+1].x
+			}
+			if run.bg != defaultBg {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					run.x*svgCellWidth, y*svgCellHeight, (end-run.x)*svgCellWidth, svgCellHeight, run.bg)
+			}
+			text := strings.TrimRight(run.text.String(), " ")
+			if text == "" {
+				continue
+			}
+			weight := ""
+			if run.bold {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="%s"%s xml:space="preserve">%s</text>`+"\n",
+				run.x*svgCellWidth, y*svgCellHeight+svgFontSize-1, run.fg, weight, html.EscapeString(text))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScreenSVG(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 2)
+
+	cs := NewColorScheme()
+	plain := cs.GetStyle(cs.Text, false)
+	for x := 0; x < 20; x++ {
+		screen.SetContent(x, 0, ' ', nil, plain)
+		screen.SetContent(x, 1, ' ', nil, plain)
+	}
+	for i, r := range "a<b" {
+		screen.SetContent(i, 0, r, nil, plain)
+	}
+	for i, r := range "hot" {
+		screen.SetContent(5+i, 1, r, nil, cs.GetStyle(cs.HighUsage, true).Bold(true))
+	}
+
+	svg := screenSVG(screen, cs)
+	for _, want := range []string{
+		`width="180" height="36"`,
+		`<rect width="100%" height="100%" fill="#0f0f19"/>`,
+		`<text x="0" y="14" fill="#dce1eb" xml:space="preserve">a&lt;b</text>`,
+		// The selected run gets a background box starting on its column
+		`<rect x="45" y="18" width="27" height="18" fill="#4682ff"/>`,
+		`<text x="45" y="32" fill="#ff5555" font-weight="bold" xml:space="preserve">hot</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG is missing %s:\n%s", want, svg)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+)
+
+// taskHistoryLength bounds the process count history: five minutes at the
+// default refresh rate, more than the widest graph shows
+const taskHistoryLength = 300
+
+// taskGraphRows is the graph's height in screen rows
+const taskGraphRows = 8
+
+// sparkEighths are the partial blocks for one to seven eighths of a cell
+var sparkEighths = []rune("▁▂▃▄▅▆▇")
+
+// ToggleTaskGraph opens or closes the process count graph
+func (d *Display) ToggleTaskGraph() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.taskGraphOpen = !d.taskGraphOpen
+}
+
+// TaskGraphOpen reports whether the process count graph currently has focus
+func (d *Display) TaskGraphOpen() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.taskGraphOpen
+}
+
+// recordTaskCount appends one refresh's total process count, dropping the
+// oldest once the history is full; callers must hold d.mu
+func (d *Display) recordTaskCount(total int) {
+	if len(d.taskHistory) >= taskHistoryLength {
+		d.taskHistory = slices.Delete(d.taskHistory, 0, len(d.taskHistory)-taskHistoryLength+1)
+	}
+	d.taskHistory = append(d.taskHistory, total)
+}
+
+// sparkRows draws values as a bar graph rows high, one column per value,
+// returned top row first. Bars are scaled between the smallest and largest
+// value so a slow leak of a few processes still shows as a slope; the
+// smallest always gets a sliver so the baseline is visible.
+func sparkRows(values []int, rows int) []string {
+	if len(values) == 0 || rows <= 0 {
+		return nil
+	}
+	lo, hi := slices.Min(values), slices.Max(values)
+	levels := rows * 8
+	heights := make([]int, len(values))
+	for i, v := range values {
+		heights[i] = 1
+		if hi > lo {
+			heights[i] += (v - lo) * (levels - 1) / (hi - lo)
+		}
+	}
+
+	lines := make([]string, rows)
+	for r := range lines {
+		base := (rows - 1 - r) * 8
+		line := make([]rune, len(heights))
+		for i, h := range heights {
+			switch fill := h - base; {
+			case fill >= 8:
+				line[i] = '█'
+			case fill <= 0:
+				line[i] = ' '
+			default:
+				line[i] = sparkEighths[fill-1]
+			}
+		}
+		lines[r] = string(line)
+	}
+	return lines
+}
+
+// taskGraphSummary describes the graphed window: the latest count, its
+// range and how much it changed
+func taskGraphSummary(values []int) string {
+	if len(values) == 0 {
+		return "No samples yet"
+	}
+	now := values[len(values)-1]
+	return fmt.Sprintf("now %d · min %d · max %d · %+d over %d refreshes",
+		now, slices.Min(values), slices.Max(values), now-values[0], len(values))
+}
+
+// renderTaskGraph draws the total process count history centered over the
+// process list, as many recent samples as fit
+func (d *Display) renderTaskGraph(width, height int) {
+	boxWidth := width - 8
+	if maxWidth := taskHistoryLength + 6; boxWidth > maxWidth {
+		boxWidth = maxWidth
+	}
+	if boxWidth < 20 {
+		boxWidth = 20
+	}
+	boxHeight := taskGraphRows + 6
+	x := (width - boxWidth) / 2
+	y := (height - boxHeight) / 2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+
+	values := d.taskHistory
+	if graphWidth := boxWidth - 6; len(values) > graphWidth {
+		values = values[len(values)-graphWidth:]
+	}
+
+	textStyle := d.colorScheme.GetStyle(d.colorScheme.Text, false)
+	for row := y; row < y+boxHeight; row++ {
+		for col := x; col < x+boxWidth; col++ {
+			d.screen.SetContent(col, row, ' ', nil, textStyle)
+		}
+	}
+	d.drawBorder(x, y, boxWidth, boxHeight)
+
+	right := x + boxWidth - 2
+	d.drawText(x+2, y, right, " Processes over time ", d.colorScheme.GetStyle(d.colorScheme.Header, false))
+	graphStyle := d.colorScheme.GetStyle(d.colorScheme.Accent, false)
+	for i, line := range sparkRows(values, taskGraphRows) {
+		d.drawText(x+3, y+2+i, right, line, graphStyle)
+	}
+	d.drawText(x+3, y+boxHeight-3, right, taskGraphSummary(values), textStyle)
+	d.drawText(x+2, y+boxHeight-1, right, " Esc close ", d.colorScheme.GetStyle(d.colorScheme.Muted, false))
+}
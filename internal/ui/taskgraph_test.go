@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+)
+
+func TestSparkRows(t *testing.T) {
+	rows := sparkRows([]int{400, 400, 500}, 2)
+	want := []string{"  █", "▁▁█"}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+	// A flat history is a baseline sliver, not an empty graph
+	if flat := sparkRows([]int{7, 7}, 1); flat[0] != "▁▁" {
+		t.Errorf("flat history drew %q", flat[0])
+	}
+}
+
+// TestTaskHistoryIsBounded checks the oldest counts are dropped once the
+// history is full
+func TestTaskHistoryIsBounded(t *testing.T) {
+	d := New(config.New(), nil)
+	for i := 0; i < taskHistoryLength+10; i++ {
+		d.recordTaskCount(i)
+	}
+	if len(d.taskHistory) != taskHistoryLength {
+		t.Fatalf("history holds %d counts, want %d", len(d.taskHistory), taskHistoryLength)
+	}
+	if d.taskHistory[0] != 10 || d.taskHistory[taskHistoryLength-1] != taskHistoryLength+9 {
+		t.Errorf("history kept %d..%d", d.taskHistory[0], d.taskHistory[taskHistoryLength-1])
+	}
+	if got := taskGraphSummary([]int{400, 380, 450}); got != "now 450 · min 380 · max 450 · +50 over 3 refreshes" {
+		t.Errorf("summary = %q", got)
+	}
+}
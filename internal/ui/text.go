@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// textNameWidth is the process name width used for plain-text output,
+// where there's no terminal width to fill
+const textNameWidth = 60
+
+// WriteFrame takes a single sample and writes it to w as a plain-text frame
+// (system metrics header plus process table), like `top -b -n1`. It never
+// touches the terminal, so it works when stdout is a pipe or file.
+func WriteFrame(w io.Writer, config ConfigInterface, mon *monitor.Monitor) error {
+	snapshot, err := mon.TakeSnapshot()
+	if err != nil {
+		return err
+	}
+	processes, metrics := snapshot.Processes, snapshot.System
+
+	var b strings.Builder
+	b.WriteString(headerTitle(config) + "\n")
+	b.WriteString("Sampled " + config.FormatTime(snapshot.Timestamp) + "\n")
+	fmt.Fprintf(&b, "CPU:  %s %s\n", CreateProgressBar(metrics.CPUPercent, 20, config.GetBarGlyphs()), cpuDetails(metrics, config.GetPrecision()))
+	fmt.Fprintf(&b, "MEM:  %s %s\n", CreateProgressBar(metrics.MemoryPercent, 20, config.GetBarGlyphs()), memoryDetails(metrics, config.GetPrecision()))
+	if metrics.SwapTotal > 0 {
+		fmt.Fprintf(&b, "SWAP: %s %s\n", CreateProgressBar(metrics.SwapPercent, 20, config.GetBarGlyphs()), swapDetails(metrics, config.GetPrecision()))
+	} else {
+		b.WriteString("SWAP: Disabled\n")
+	}
+	b.WriteString("\n")
+
+	cols := columns{memPercent: config.GetShowMemPercent(), memTotal: metrics.MemoryTotal, cpuTime: config.GetShowCPUTime(), avgCPU: config.GetShowAvgCPU(), faults: config.GetShowFaults(), tty: config.GetShowTTY(), precision: config.GetPrecision(), order: config.GetColumnOrder(), barGlyphs: config.GetBarGlyphs()}
+	b.WriteString(columnHeaderLine(config, cols) + "\n")
+	var heaviest topRows
+	if config.GetHighlightTop() {
+		heaviest = findTopRows(processes)
+	}
+	for _, proc := range processes {
+		statusIcon := GetStatusIcon(proc.CPUPercent, proc.TreeChildren > 0, len(proc.Children)+proc.TreeChildren > 0, config.GetIconThresholds())
+		b.WriteString(formatProcessLine(statusIcon, heaviest.marker(proc), proc, cols, textNameWidth) + "\n")
+	}
+	fmt.Fprintf(&b, "\n%d processes\n", len(processes))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCompareReport writes the deltas between two snapshots as a table
+func WriteCompareReport(w io.Writer, config ConfigInterface, before, after *monitor.Snapshot) error {
+	deltas := monitor.CompareSnapshots(before, after)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Comparing %s → %s\n\n",
+		config.FormatTime(before.Timestamp), config.FormatTime(after.Timestamp))
+	fmt.Fprintf(&b, "  %-7s %-8s %9s %9s %12s %12s  %s\n",
+		"PID", "STATUS", "CPU", "ΔCPU", "MEMORY", "ΔMEMORY", "PROCESS NAME")
+
+	for _, delta := range deltas {
+		cpu, memory := delta.CPUAfter, delta.MemoryAfter
+		if delta.Status == monitor.DeltaGone {
+			cpu, memory = delta.CPUBefore, delta.MemoryBefore
+		}
+		fmt.Fprintf(&b, "  %-7d %-8s %8.1f%% %+8.1f%% %12s %12s  %s\n",
+			delta.PID, delta.Status, cpu, delta.CPUDelta,
+			monitor.FormatBytes(memory), formatBytesDelta(delta.MemoryDelta), delta.Name)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatBytesDelta formats a signed byte difference, e.g. "+1.5 MB"
+func formatBytesDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + monitor.FormatBytes(uint64(-delta))
+	}
+	return "+" + monitor.FormatBytes(uint64(delta))
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+// titleOut receives the title escape sequences; replaced in tests. tcell
+// 2.6 can't set the title, but writes to the same terminal.
+var titleOut io.Writer = os.Stdout
+
+const (
+	pushTitle = "\x1b[22;0t" // Save the terminal's title (xterm title stack)
+	popTitle  = "\x1b[23;0t" // Restore it
+)
+
+// titleText summarizes the busiest listed process and memory use for the
+// window title, e.g. "brieftop: chrome 120% | MEM 64%"
+func titleText(processes []*monitor.ProcessInfo, m *monitor.SystemMetrics) string {
+	var parts []string
+	var top *monitor.ProcessInfo
+	for _, proc := range processes {
+		if top == nil || proc.CPUPercent > top.CPUPercent {
+			top = proc
+		}
+	}
+	if top != nil {
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", top.GroupName(), top.CPUPercent))
+	}
+	if m != nil {
+		parts = append(parts, fmt.Sprintf("MEM %.0f%%", m.MemoryPercent))
+	}
+	// Process names are arbitrary bytes; control characters could end the
+	// escape sequence early
+	title := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, strings.Join(parts, " | "))
+	return "brieftop: " + title
+}
+
+// updateTitle sets the window title with --title when the summary has
+// changed, saving the previous title the first time
+func (d *Display) updateTitle() error {
+	if !d.config.GetWindowTitle() {
+		return nil
+	}
+	title := titleText(d.processes, d.systemMetrics)
+	if title == d.windowTitle {
+		return nil
+	}
+	if d.windowTitle == "" {
+		if _, err := io.WriteString(titleOut, pushTitle); err != nil {
+			return fmt.Errorf("failed to save window title: %w", err)
+		}
+	}
+	if _, err := fmt.Fprintf(titleOut, "\x1b]2;%s\x07", title); err != nil {
+		return fmt.Errorf("failed to set window title: %w", err)
+	}
+	d.windowTitle = title
+	return nil
+}
+
+// restoreTitle puts back the title saved by updateTitle, if it set one
+func (d *Display) restoreTitle() error {
+	if d.windowTitle == "" {
+		return nil
+	}
+	if _, err := io.WriteString(titleOut, popTitle); err != nil {
+		return fmt.Errorf("failed to restore window title: %w", err)
+	}
+	return nil
+}
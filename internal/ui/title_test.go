@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+)
+
+func TestTitleText(t *testing.T) {
+	processes := []*monitor.ProcessInfo{
+		{Name: "bash", CPUPercent: 3},
+		{Name: "chrome", CPUPercent: 120.4},
+		{Name: "evil\x07\x1b]2;x", CPUPercent: 1},
+	}
+	m := &monitor.SystemMetrics{MemoryPercent: 63.6}
+	if got := titleText(processes, m); got != "brieftop: chrome 120% | MEM 64%" {
+		t.Errorf("titleText = %q", got)
+	}
+	if got := titleText(processes[2:], nil); strings.ContainsAny(got, "\x07\x1b") {
+		t.Errorf("titleText kept control characters: %q", got)
+	}
+}
+
+func TestUpdateTitle(t *testing.T) {
+	var out bytes.Buffer
+	titleOut = &out
+	defer func() { titleOut = os.Stdout }()
+
+	cfg := config.New()
+	d := New(cfg, nil)
+	d.processes = []*monitor.ProcessInfo{{Name: "make", CPUPercent: 90}}
+	if err := d.updateTitle(); err != nil || out.Len() != 0 {
+		t.Fatalf("wrote %q (%v) without --title", out.String(), err)
+	}
+
+	cfg.SetWindowTitle(true)
+	for i := 0; i < 2; i++ {
+		if err := d.updateTitle(); err != nil {
+			t.Fatalf("updateTitle: %v", err)
+		}
+	}
+	want := pushTitle + "\x1b]2;brieftop: make 90%\x07"
+	if out.String() != want {
+		t.Errorf("wrote %q; expected %q, once", out.String(), want)
+	}
+	if err := d.restoreTitle(); err != nil {
+		t.Fatalf("restoreTitle: %v", err)
+	}
+	if !strings.HasSuffix(out.String(), popTitle) {
+		t.Errorf("restoreTitle didn't pop the saved title: %q", out.String())
+	}
+}
+
+// failingWriter rejects every write, like a closed terminal
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+// TestUpdateTitleWriteError checks a title that can't be written is reported
+// rather than dropped
+func TestUpdateTitleWriteError(t *testing.T) {
+	titleOut = failingWriter{}
+	defer func() { titleOut = os.Stdout }()
+
+	cfg := config.New()
+	cfg.SetWindowTitle(true)
+	d := New(cfg, nil)
+	if err := d.updateTitle(); err == nil || !strings.Contains(err.Error(), "broken pipe") {
+		t.Errorf("updateTitle = %v, want the write error", err)
+	}
+	d.windowTitle = "brieftop: make 90%"
+	if err := d.restoreTitle(); err == nil || !strings.Contains(err.Error(), "failed to restore window title") {
+		t.Errorf("restoreTitle = %v, want the write error", err)
+	}
+}
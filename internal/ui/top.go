@@ -0,0 +1,50 @@
+package ui
+
+import "github.com/SteiniDavid/brieftop/internal/monitor"
+
+// Name prefixes for the listed processes using the most CPU and the most
+// memory with --highlight-top
+const (
+	topCPUMarker    = "★cpu "
+	topMemoryMarker = "★mem "
+)
+
+// criticalMarker prefixes processes named by --critical, which are also
+// underlined
+const criticalMarker = "◆ "
+
+// topRows are the listed processes using the most CPU and the most memory;
+// nil where nothing uses any
+type topRows struct {
+	cpu, memory *monitor.ProcessInfo
+}
+
+// findTopRows picks the heaviest rows from the snapshot. Exited rows kept
+// by --linger don't count, and ties go to the row listed first.
+func findTopRows(procs []*monitor.ProcessInfo) topRows {
+	var top topRows
+	for _, proc := range procs {
+		if proc.Exited {
+			continue
+		}
+		if proc.CPUPercent > 0 && (top.cpu == nil || proc.CPUPercent > top.cpu.CPUPercent) {
+			top.cpu = proc
+		}
+		if proc.MemoryBytes > 0 && (top.memory == nil || proc.MemoryBytes > top.memory.MemoryBytes) {
+			top.memory = proc
+		}
+	}
+	return top
+}
+
+// marker is the name prefix for proc: one marker per resource it tops
+func (t topRows) marker(proc *monitor.ProcessInfo) string {
+	marker := ""
+	if proc == t.cpu {
+		marker += topCPUMarker
+	}
+	if proc == t.memory {
+		marker += topMemoryMarker
+	}
+	return marker
+}
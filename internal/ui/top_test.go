@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SteiniDavid/brieftop/internal/config"
+	"github.com/SteiniDavid/brieftop/internal/monitor"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFindTopRows(t *testing.T) {
+	busy := &monitor.ProcessInfo{PID: 1, Name: "busy", CPUPercent: 90, MemoryBytes: 100}
+	hog := &monitor.ProcessInfo{PID: 2, Name: "hog", CPUPercent: 5, MemoryBytes: 4000}
+	gone := &monitor.ProcessInfo{PID: 3, Name: "gone", CPUPercent: 99, MemoryBytes: 9000, Exited: true}
+	idle := &monitor.ProcessInfo{PID: 4, Name: "idle"}
+
+	top := findTopRows([]*monitor.ProcessInfo{hog, gone, busy, idle})
+	if top.cpu != busy || top.memory != hog {
+		t.Fatalf("findTopRows = cpu %v, memory %v; expected busy and hog", top.cpu, top.memory)
+	}
+
+	tests := []struct {
+		proc     *monitor.ProcessInfo
+		expected string
+	}{
+		{busy, topCPUMarker},
+		{hog, topMemoryMarker},
+		{idle, ""},
+	}
+	for _, tt := range tests {
+		if got := top.marker(tt.proc); got != tt.expected {
+			t.Errorf("marker(%s) = %q; expected %q", tt.proc.Name, got, tt.expected)
+		}
+	}
+
+	both := findTopRows([]*monitor.ProcessInfo{busy, idle})
+	if got := both.marker(busy); got != topCPUMarker+topMemoryMarker {
+		t.Errorf("Expected a process topping both to get both markers, got %q", got)
+	}
+	if none := findTopRows([]*monitor.ProcessInfo{idle}); none.cpu != nil || none.memory != nil {
+		t.Errorf("Expected no top rows among idle processes, got %+v", none)
+	}
+}
+
+func TestCriticalRowMarked(t *testing.T) {
+	cfg := config.New()
+	cfg.SetHighlightTop(false)
+	d := New(cfg, monitor.New(cfg))
+	d.screen = tcell.NewSimulationScreen("UTF-8")
+	if err := d.screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.screen.Fini()
+	d.screen.SetSize(120, 40)
+
+	d.processes = []*monitor.ProcessInfo{
+		{PID: 1, Name: "postgres", Critical: true},
+		{PID: 2, Name: "bash"},
+	}
+	d.renderProcesses(120, 40)
+
+	top := d.processTop()
+	for i, proc := range d.processes {
+		row := ""
+		for x := processXOffset; x < 60; x++ {
+			r, _, _, _ := d.screen.GetContent(x, top+i)
+			row += string(r)
+		}
+		_, _, style, _ := d.screen.GetContent(processXOffset, top+i)
+		_, _, attrs := style.Decompose()
+		underlined := attrs&tcell.AttrUnderline != 0
+		if marked := strings.Contains(row, criticalMarker+proc.Name); marked != proc.Critical || underlined != proc.Critical {
+			t.Errorf("row %q: marked %v, underlined %v; expected %v", row, marked, underlined, proc.Critical)
+		}
+	}
+}
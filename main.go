@@ -1,11 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"os/signal"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,12 +25,142 @@ func main() {
 	// Command line flags
 	var (
 		cpuThreshold    = flag.Float64("cpu", 5.0, "CPU threshold percentage (processes using more than this will be shown)")
-		memoryThreshold = flag.Uint64("memory", 50, "Memory threshold in MB (processes using more than this will be shown)")
+		memoryThreshold = flag.String("memory", "50", "Memory threshold (processes using more than this will be shown): a size such as 500M, 2G or 1024K; a bare number is MB")
 		refreshRate     = flag.Duration("refresh", time.Second, "Refresh rate (e.g., 500ms, 2s)")
+		cpuWindow       = flag.Duration("cpu-window", 0, "Average CPU % over this window (e.g. 3s) instead of showing each refresh's reading")
+		barChars        = flag.String("bar-chars", "blocks", "Progress bar characters: a preset (blocks, smooth, squares, ascii) or the full and empty characters followed by any partial ones, e.g. '#-'")
+		cpuBlocking     = flag.Duration("cpu-blocking", 0, "Measure the first system CPU reading over this long (e.g. 200ms), delaying startup by as much, instead of over the moments since startup")
+		linger          = flag.Duration("linger", 0, "Keep exited processes listed, grayed out, for this long (e.g. 5s)")
+		memMetric       = flag.String("mem-metric", config.MemoryMetricRSS, "Memory metric: rss, or pss (Linux only, falls back to rss)")
+		refreshOnKey    = flag.Bool("refresh-on-key", false, "Refresh immediately (at most every 250ms) when navigating or expanding")
+		quietStart      = flag.Bool("quiet-start", false, "Discard the first sample and show \"measuring…\" until CPU values are reliable")
+		intervalAlign   = flag.Bool("interval-align", false, "Take samples on wall-clock multiples of --refresh (e.g. on the second), to line up with other hosts and tools")
+		timeFormat      = flag.String("time-format", config.DefaultTimeFormat, "Go layout for displayed timestamps")
+		timeZone        = flag.String("timezone", "Local", "Time zone for displayed timestamps (e.g. UTC, America/New_York)")
+		sortMode        = flag.String("sort", "cpu", "Sort order: cpu, mem, or composite (CPU and memory weighted together)")
+		childSort       = flag.String("child-sort", "follow", "Order of an expanded process's children: follow (the main --sort), cpu, mem, or pid")
+		groupSort       = flag.String("group-sort", "follow", "Order of the per-category totals: follow (the main --sort), cpu, mem, or count")
+		cpuBar          = flag.String("cpu-bar", "off", "Inline CPU bar per process: off, absolute (full at 100%), or relative (full at the busiest process)")
+		colorProfile    = flag.String("color-profile", "auto", "Override detected terminal colors: auto, truecolor, 256, 16, or mono (no colors)")
+		precision       = flag.Int("precision", config.DefaultPrecision, fmt.Sprintf("Decimal places for CPU and memory values (0-%d)", config.MaxPrecision))
+		memPercent      = flag.Bool("mem-percent", false, "Show each process's share of system RAM as a column")
+		cpuDelta        = flag.Bool("cpu-delta", false, "Show the change in CPU since the previous refresh instead of the reading (toggle with u)")
+		showCPUTime     = flag.Bool("cpu-time", false, "Show cumulative CPU time (user+system) as a TIME column")
+		showAvgCPU      = flag.Bool("avg-cpu", false, "Show each process's CPU averaged since it started as an AVG% column")
+		showFaults      = flag.Bool("faults", false, "Show major page faults per second as a MAJF/s column (Linux); thrashing processes are highlighted")
+		showSecurity    = flag.Bool("security-context", false, "Show the SELinux context or AppArmor profile in the detail pane (Linux)")
+		summaryOnly     = flag.Bool("summary", false, "Show only the system metrics, enlarged, without the process list (toggle with v)")
+		collapseHeader  = flag.Bool("collapse-header", false, "Hide the CPU, memory and swap lines while scrolled down the list, for more rows on short terminals")
+		layout          = flag.String("layout", config.DefaultLayout, "Named layout of expanded processes: restored on start if saved, saved with y and restored with j")
+		criticalBell    = flag.Bool("critical-bell", false, "Ring the terminal bell when a --critical process stops running")
+		windowTitle     = flag.Bool("title", false, "Show the busiest process and memory use in the terminal's window title, e.g. \"brieftop: chrome 120% | MEM 64%\"")
+		headerDeltas    = flag.Bool("header-deltas", false, "Follow the header's CPU and memory percentages with their change since the previous refresh, e.g. \"34% ▲3\"")
+		onelineHeader   = flag.Bool("oneline-header", false, "Summarize CPU, memory, swap and load on one line instead of bars (automatic below 80 columns)")
+		highlightTop    = flag.Bool("highlight-top", false, "Mark the listed processes using the most CPU and the most memory in bold")
+		heat            = flag.Bool("heat", false, "Show each process's resource level as a colored block at the left edge")
+		stackedMem      = flag.Bool("stacked-mem", false, "Split the header memory bar into used, buffers and cache (free is the rest)")
+		alertCPU        = flag.Float64("alert-cpu", 0, "Alert when a process reaches this CPU percentage (0 disables)")
+		alertMemory     = flag.String("alert-memory", "0", "Alert when a process reaches this much memory, e.g. 2G; a bare number is MB (0 disables)")
+		alertCommand    = flag.String("alert-command", "", "Shell command run when a process crosses an alert threshold; gets BRIEFTOP_PID, BRIEFTOP_NAME, BRIEFTOP_CPU and BRIEFTOP_MEMORY")
+		showTTY         = flag.Bool("tty", false, "Show each process's controlling terminal as a TTY column (\"?\" for none)")
+		collapseThreads = flag.Bool("collapse-threads", false, "Merge an expanded process's threads into one summary row")
+		treeView        = flag.Bool("tree", false, "List every process as an indented hierarchy with its own usage, ignoring the thresholds (toggle with h)")
+		maxDepth        = flag.Int("max-depth", 0, "List at most this many levels below each root in the --tree view, noting how many more are hidden (0 for no limit)")
+		aggregateAll    = flag.Bool("aggregate-all", false, "Sum every child process into its parent, not only same-named ones (toggle with w)")
+		sumThreads      = flag.Bool("sum-threads", false, "Sum children taken for threads into their parent even when their name differs")
+		scanWorkers     = flag.Int("scan-workers", runtime.NumCPU(), fmt.Sprintf("Goroutines reading process info during a refresh (1-%d)", config.MaxScanWorkers))
+		profile         = flag.Bool("profile", false, "Show how long each refresh spends scanning, aggregating and rendering in the footer")
+		scope           = flag.String("scope", "", "Only list processes in the invoking shell's session or pgroup (process group), e.g. to watch just a build you started")
+		hideSelf        = flag.Bool("hide-self", false, "Hide brieftop's own process from the list")
+		selectName      = flag.String("select", "", "Select and expand the first process matching NAME once it appears")
+		jsonOut         = flag.Bool("json", false, "Print a single JSON snapshot to stdout and exit")
+		jsonStream      = flag.Bool("json-stream", false, "Print a JSON snapshot per line every refresh until interrupted (what --remote reads)")
+		folded          = flag.Bool("folded", false, "Sample every refresh until interrupted, then print CPU time per process stack in flamegraph.pl's folded format")
+		compare         = flag.Bool("compare", false, "Compare two JSON snapshots: --compare BEFORE.json AFTER.json")
+		once            = flag.Bool("once", false, "Print a single plain-text frame to stdout and exit")
+		batch           = flag.Bool("batch", false, "Print a plain-text frame every refresh until interrupted, without the interactive screen (the default when stdout isn't a terminal)")
+		inspect         = flag.Int("inspect", 0, "Print the full detail of process `PID` as JSON and exit")
+		configPath      = flag.String("config", "", "Config file (JSON); defaults to brieftop/config.json in the user config directory, if present")
+		viewState       = flag.Bool("view-state", false, "Remember sort orders and column toggles between sessions, restored over the config file's values")
+		checkConfig     = flag.Bool("check-config", false, "Validate a config file and exit: --check-config [PATH]")
 		showHelp        = flag.Bool("help", false, "Show help information")
 		showVersion     = flag.Bool("version", false, "Show version information")
 	)
 
+	// Repeatable flags are collected and applied after the config file, so
+	// they take precedence over it
+	var categoryRules []config.CategoryRule
+	flag.Func("category", "Tag processes matching PATTERN (name or cmdline) as NAME, e.g. --category build=cargo (repeatable)", func(value string) error {
+		category, pattern, ok := strings.Cut(value, "=")
+		if !ok || category == "" || pattern == "" {
+			return fmt.Errorf("expected NAME=PATTERN, got %q", value)
+		}
+		categoryRules = append(categoryRules, config.CategoryRule{Category: category, Pattern: pattern})
+		return nil
+	})
+
+	var excludes []string
+	flag.Func("exclude", "Never list processes whose name matches the glob PATTERN, e.g. --exclude 'kworker*' (repeatable)", func(value string) error {
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", value, err)
+		}
+		excludes = append(excludes, value)
+		return nil
+	})
+
+	var critical []string
+	flag.Func("critical", "Always list processes named NAME (comm or executable), highlighted, and warn when none is running, e.g. --critical postgres (repeatable)", func(value string) error {
+		if value == "" {
+			return fmt.Errorf("empty name")
+		}
+		critical = append(critical, value)
+		return nil
+	})
+
+	var remotes []string
+	flag.Func("remote", "Monitor `user@host` over ssh instead of this machine; brieftop must be installed there. Repeat for a per-host summary of several", func(value string) error {
+		if value == "" {
+			return errors.New("expected a host")
+		}
+		if strings.HasPrefix(value, "-") {
+			return fmt.Errorf("host %q must not start with \"-\"", value)
+		}
+		remotes = append(remotes, value)
+		return nil
+	})
+
+	var iconThresholds *config.IconThresholds
+	flag.Func("icon-thresholds", "CPU % breakpoints for the status icon tiers as HIGH,MEDIUM,ACTIVE (default 50,20,5)", func(value string) error {
+		parts := strings.Split(value, ",")
+		if len(parts) != 3 {
+			return fmt.Errorf("expected HIGH,MEDIUM,ACTIVE, got %q", value)
+		}
+		var values [3]float64
+		for i, part := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return fmt.Errorf("invalid threshold %q: %w", part, err)
+			}
+			values[i] = v
+		}
+		thresholds := config.IconThresholds{High: values[0], Medium: values[1], Active: values[2]}
+		if err := thresholds.Validate(); err != nil {
+			return err
+		}
+		iconThresholds = &thresholds
+		return nil
+	})
+
+	keyBindings := make(map[string][]string)
+	flag.Func("bind", "Rebind ACTION to comma-separated KEYS, e.g. --bind sort=x (repeatable; actions are listed under Controls)", func(value string) error {
+		action, keys, ok := strings.Cut(value, "=")
+		if !ok || action == "" || keys == "" {
+			return fmt.Errorf("expected ACTION=KEY[,KEY...], got %q", value)
+		}
+		keyBindings[action] = strings.Split(keys, ",")
+		return nil
+	})
+
 	// Custom usage function
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "brieftop - A focused process monitoring tool showing only the essentials\n\n")
@@ -31,11 +168,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nControls:\n")
-		fmt.Fprintf(os.Stderr, "  ↑/↓       Navigate through processes\n")
-		fmt.Fprintf(os.Stderr, "  Enter     Expand/collapse process details\n")
-		fmt.Fprintf(os.Stderr, "  Space     Pause/unpause updates\n")
-		fmt.Fprintf(os.Stderr, "  R         Force refresh\n")
-		fmt.Fprintf(os.Stderr, "  Q         Quit application\n")
+		for _, line := range ui.ControlsHelp() {
+			fmt.Fprintf(os.Stderr, "  %s\n", line)
+		}
 		fmt.Fprintf(os.Stderr, "\nExample:\n")
 		fmt.Fprintf(os.Stderr, "  %s --cpu 10 --memory 100 --refresh 2s\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\nThis will show processes using >10%% CPU or >100MB memory, refreshing every 2 seconds.\n")
@@ -55,26 +190,559 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Create config with command line values
+	if *checkConfig {
+		path := *configPath
+		if flag.NArg() > 0 {
+			path = flag.Arg(0)
+		}
+		os.Exit(runCheckConfig(path))
+	}
+
+	if *memMetric != config.MemoryMetricRSS && *memMetric != config.MemoryMetricPSS {
+		fmt.Fprintf(os.Stderr, "Invalid --mem-metric %q: must be %q or %q\n", *memMetric, config.MemoryMetricRSS, config.MemoryMetricPSS)
+		os.Exit(2)
+	}
+
+	mode, err := config.ParseSortMode(*sortMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --sort: %v\n", err)
+		os.Exit(2)
+	}
+
+	children, err := config.ParseChildSort(*childSort)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --child-sort: %v\n", err)
+		os.Exit(2)
+	}
+
+	groups, err := config.ParseGroupSort(*groupSort)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --group-sort: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *cpuWindow < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --cpu-window %v: must not be negative\n", *cpuWindow)
+		os.Exit(2)
+	}
+
+	if *inspect < 0 || *inspect > math.MaxInt32 {
+		fmt.Fprintf(os.Stderr, "Invalid --inspect %d: must be a PID\n", *inspect)
+		os.Exit(2)
+	}
+
+	if *cpuBlocking < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --cpu-blocking %v: must not be negative\n", *cpuBlocking)
+		os.Exit(2)
+	}
+
+	var scopeID int32
+	if *scope != "" {
+		if *scope != config.ScopeSession && *scope != config.ScopePGroup {
+			fmt.Fprintf(os.Stderr, "Invalid --scope %q: must be %s or %s\n", *scope, config.ScopeSession, config.ScopePGroup)
+			os.Exit(2)
+		}
+		var err error
+		if scopeID, err = monitor.ShellScopeID(*scope); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --scope %s: %v\n", *scope, err)
+			os.Exit(2)
+		}
+	}
+
+	if *linger < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --linger %v: must not be negative\n", *linger)
+		os.Exit(2)
+	}
+
+	if *precision < 0 || *precision > config.MaxPrecision {
+		fmt.Fprintf(os.Stderr, "Invalid --precision %d: must be between 0 and %d\n", *precision, config.MaxPrecision)
+		os.Exit(2)
+	}
+
+	if *alertCPU < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --alert-cpu %v: must not be negative\n", *alertCPU)
+		os.Exit(2)
+	}
+
+	if *maxDepth < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --max-depth %d: must not be negative\n", *maxDepth)
+		os.Exit(2)
+	}
+
+	if *scanWorkers < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid --scan-workers %d: must be at least 1\n", *scanWorkers)
+		os.Exit(2)
+	}
+	if *scanWorkers > config.MaxScanWorkers {
+		fmt.Fprintf(os.Stderr, "Warning: --scan-workers %d clamped to %d\n", *scanWorkers, config.MaxScanWorkers)
+	}
+
+	memoryBytes, err := config.ParseMemorySize(*memoryThreshold)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --memory: %v\n", err)
+		os.Exit(2)
+	}
+
+	alertMemoryBytes, err := config.ParseMemorySize(*alertMemory)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --alert-memory: %v\n", err)
+		os.Exit(2)
+	}
+
+	barGlyphs, err := config.ParseBarGlyphs(*barChars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --bar-chars: %v\n", err)
+		os.Exit(2)
+	}
+
+	bar, err := config.ParseCPUBar(*cpuBar)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --cpu-bar: %v\n", err)
+		os.Exit(2)
+	}
+
+	colors, err := config.ParseColorProfile(*colorProfile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --color-profile: %v\n", err)
+		os.Exit(2)
+	}
+
+	loc, err := time.LoadLocation(*timeZone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --timezone %q: %v\n", *timeZone, err)
+		os.Exit(2)
+	}
+
 	cfg := config.New()
-	cfg.SetCPUThreshold(*cpuThreshold)
-	cfg.SetMemoryThreshold(*memoryThreshold * 1024 * 1024) // Convert MB to bytes
-	cfg.SetRefreshRate(*refreshRate)
+	if err := loadConfigFile(cfg, *configPath); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	if *viewState {
+		loadViewState(cfg)
+	}
+
+	// Apply command line values that were given explicitly; everything else
+	// keeps the config file's value or the default
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	apply := func(name string, set func()) {
+		if setFlags[name] {
+			set()
+		}
+	}
+	apply("cpu", func() { cfg.SetCPUThreshold(*cpuThreshold) })
+	apply("memory", func() { cfg.SetMemoryThreshold(memoryBytes) })
+	apply("refresh", func() { cfg.SetRefreshRate(*refreshRate) })
+	apply("cpu-window", func() { cfg.SetCPUWindow(*cpuWindow) })
+	apply("linger", func() { cfg.SetLinger(*linger) })
+	apply("cpu-blocking", func() { cfg.SetCPUBlocking(*cpuBlocking) })
+	apply("bar-chars", func() { cfg.SetBarGlyphs(barGlyphs) })
+	apply("quiet-start", func() { cfg.SetQuietStart(*quietStart) })
+	apply("interval-align", func() { cfg.SetIntervalAlign(*intervalAlign) })
+	apply("refresh-on-key", func() { cfg.SetRefreshOnKey(*refreshOnKey) })
+	apply("mem-metric", func() { cfg.SetMemoryMetric(*memMetric) })
+	apply("hide-self", func() { cfg.SetHideSelf(*hideSelf) })
+	apply("scope", func() { cfg.SetScope(*scope, scopeID) })
+	apply("profile", func() { cfg.SetProfile(*profile) })
+	apply("scan-workers", func() { cfg.SetScanWorkers(*scanWorkers) })
+	apply("collapse-threads", func() { cfg.SetCollapseThreads(*collapseThreads) })
+	apply("aggregate-all", func() { cfg.SetAggregateAllChildren(*aggregateAll) })
+	apply("sum-threads", func() { cfg.SetSumThreads(*sumThreads) })
+	apply("tree", func() { cfg.SetTreeView(*treeView) })
+	apply("max-depth", func() { cfg.SetMaxTreeDepth(*maxDepth) })
+	apply("sort", func() { cfg.SetSortMode(mode) })
+	apply("child-sort", func() { cfg.SetChildSort(children) })
+	apply("group-sort", func() { cfg.SetGroupSort(groups) })
+	apply("cpu-bar", func() { cfg.SetCPUBar(bar) })
+	apply("precision", func() { cfg.SetPrecision(*precision) })
+	apply("color-profile", func() { cfg.SetColorProfile(colors) })
+	apply("mem-percent", func() { cfg.SetShowMemPercent(*memPercent) })
+	apply("cpu-delta", func() { cfg.SetCPUDelta(*cpuDelta) })
+	apply("tty", func() { cfg.SetShowTTY(*showTTY) })
+	apply("cpu-time", func() { cfg.SetShowCPUTime(*showCPUTime) })
+	apply("avg-cpu", func() { cfg.SetShowAvgCPU(*showAvgCPU) })
+	apply("faults", func() { cfg.SetShowFaults(*showFaults) })
+	apply("security-context", func() { cfg.SetShowSecurityContext(*showSecurity) })
+	apply("alert-cpu", func() { cfg.SetAlertCPU(*alertCPU) })
+	apply("alert-memory", func() { cfg.SetAlertMemory(alertMemoryBytes) })
+	apply("alert-command", func() { cfg.SetAlertCommand(*alertCommand) })
+	apply("summary", func() { cfg.SetSummaryOnly(*summaryOnly) })
+	apply("stacked-mem", func() { cfg.SetStackedMemory(*stackedMem) })
+	apply("collapse-header", func() { cfg.SetCollapseHeader(*collapseHeader) })
+	apply("oneline-header", func() { cfg.SetOnelineHeader(*onelineHeader) })
+	apply("title", func() { cfg.SetWindowTitle(*windowTitle) })
+	apply("header-deltas", func() { cfg.SetHeaderDeltas(*headerDeltas) })
+	apply("critical-bell", func() { cfg.SetCriticalBell(*criticalBell) })
+	apply("layout", func() { cfg.SetLayout(*layout) })
+	apply("highlight-top", func() { cfg.SetHighlightTop(*highlightTop) })
+	apply("heat", func() { cfg.SetHeatColumn(*heat) })
+	apply("time-format", func() { cfg.SetTimeFormat(*timeFormat) })
+	apply("timezone", func() { cfg.SetTimeZone(loc) })
+	if iconThresholds != nil {
+		cfg.SetIconThresholds(*iconThresholds)
+	}
+	for _, rule := range categoryRules {
+		cfg.AddCategoryRule(rule)
+	}
+	for _, pattern := range excludes {
+		cfg.AddExclude(pattern)
+	}
+	for _, name := range critical {
+		cfg.AddCritical(name)
+	}
+	for action, keys := range keyBindings {
+		cfg.SetKeyBinding(action, keys)
+	}
+
+	if *compare {
+		if flag.NArg() != 2 {
+			fmt.Fprintf(os.Stderr, "Usage: %s --compare [--json] BEFORE.json AFTER.json\n", os.Args[0])
+			os.Exit(2)
+		}
+		if err := runCompare(cfg, flag.Arg(0), flag.Arg(1), *jsonOut); err != nil {
+			log.Fatalf("Failed to compare snapshots: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	for _, err := range ui.CheckKeyBindings(cfg.GetKeyBindings()) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 
 	mon := monitor.New(cfg)
 
+	if len(remotes) > 0 {
+		if *jsonOut || *jsonStream || *folded || *once || *batch || *inspect > 0 || !isTerminal(os.Stdout) {
+			fmt.Fprintf(os.Stderr, "Invalid --remote: only the interactive view can show a remote host\n")
+			os.Exit(2)
+		}
+		if err := runRemote(cfg, remotes, *selectName); err != nil {
+			log.Fatalf("Failed to run display: %v", err)
+		}
+		if *viewState {
+			saveViewState(cfg)
+		}
+		os.Exit(0)
+	}
+
+	if *inspect > 0 {
+		inspection, err := mon.Inspect(int32(*inspect))
+		if err == nil {
+			err = inspection.WriteJSON(os.Stdout)
+		}
+		if err != nil {
+			log.Fatalf("Failed to inspect process: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *jsonOut {
+		snapshot, err := mon.TakeSnapshot()
+		if err == nil {
+			err = snapshot.WriteJSON(os.Stdout)
+		}
+		if err != nil {
+			log.Fatalf("Failed to write snapshot: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *jsonStream {
+		if err := runJSONStream(cfg, mon); err != nil {
+			log.Fatalf("Failed to write snapshot: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *folded {
+		if err := runFolded(cfg, mon); err != nil {
+			log.Fatalf("Failed to write folded stacks: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *once {
+		if err := ui.WriteFrame(os.Stdout, cfg, mon); err != nil {
+			log.Fatalf("Failed to collect processes: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// The interactive screen draws on the controlling terminal, not stdout,
+	// so a piped or redirected run must never reach ui.New
+	if *batch || !isTerminal(os.Stdout) {
+		if err := runBatch(cfg, mon); err != nil {
+			log.Fatalf("Failed to collect processes: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	display := ui.New(cfg, mon)
+	if *selectName != "" {
+		display.SelectOnStart(*selectName)
+	}
+	if setFlags["layout"] {
+		display.RestoreLayout()
+	}
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
+	// Stop lets Run return normally so the terminal is restored before exit
 	go func() {
 		<-c
 		display.Stop()
-		os.Exit(0)
 	}()
 
 	if err := display.Run(); err != nil {
 		log.Fatalf("Failed to run display: %v", err)
 	}
+	if *viewState {
+		saveViewState(cfg)
+	}
+}
+
+// runBatch prints a plain-text frame every refresh interval until
+// interrupted, like top -b
+func runBatch(cfg *config.Config, mon *monitor.Monitor) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+
+	for {
+		if err := ui.WriteFrame(os.Stdout, cfg, mon); err != nil {
+			return err
+		}
+		select {
+		case <-c:
+			return nil
+		case <-time.After(nextRefresh(cfg)):
+		}
+		fmt.Println()
+	}
+}
+
+// runJSONStream prints a compact JSON snapshot per line every refresh
+// interval until interrupted or stdout closes. Unprimed samples are skipped,
+// so every snapshot's CPU percentages are real readings.
+func runJSONStream(cfg *config.Config, mon *monitor.Monitor) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		snapshot, err := mon.TakeSnapshot()
+		if err != nil {
+			return err
+		}
+		if mon.IsPrimed() {
+			if err := enc.Encode(snapshot); err != nil {
+				// The reader went away, e.g. the ssh session closed
+				return nil
+			}
+		}
+		select {
+		case <-c:
+			return nil
+		case <-time.After(nextRefresh(cfg)):
+		}
+	}
+}
+
+// nextRefresh is how long the batch and streaming loops wait for the next
+// sample: the refresh rate, or until the next aligned boundary
+func nextRefresh(cfg *config.Config) time.Duration {
+	if cfg.GetIntervalAlign() {
+		return ui.AlignedDelay(time.Now(), cfg.GetRefreshRate())
+	}
+	return cfg.GetRefreshRate()
+}
+
+// runFolded samples every refresh interval until interrupted, weighting
+// each process stack by the CPU time it used since the previous sample, then
+// prints the totals as folded stacks for flamegraph.pl. The priming sample
+// only starts the clock.
+func runFolded(cfg *config.Config, mon *monitor.Monitor) error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+
+	folded := monitor.NewFoldedStacks()
+	last := time.Now()
+	for {
+		processes, err := mon.GetFilteredProcesses()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if mon.IsPrimed() {
+			folded.Add(processes, now.Sub(last))
+		}
+		last = now
+
+		select {
+		case <-c:
+			_, err := folded.WriteTo(os.Stdout)
+			return err
+		case <-time.After(nextRefresh(cfg)):
+		}
+	}
+}
+
+// runRemote runs the interactive view fed by brieftop on the targets over
+// ssh: one target's processes, or a row per host for several
+func runRemote(cfg *config.Config, targets []string, selectName string) (err error) {
+	var source interface {
+		ui.DataSource
+		Start()
+		Close() error
+	}
+	if len(targets) == 1 {
+		source = monitor.NewRemote(targets[0], cfg)
+	} else {
+		source = monitor.NewFleet(targets, cfg)
+	}
+	source.Start()
+	defer func() {
+		if closeErr := source.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	display := ui.New(cfg, source)
+	if selectName != "" {
+		display.SelectOnStart(selectName)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		display.Stop()
+	}()
+	return display.Run()
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runCompare prints the per-process deltas between two --json snapshots,
+// as a table or, with asJSON, as a JSON array
+func runCompare(cfg *config.Config, beforePath, afterPath string, asJSON bool) error {
+	before, err := monitor.LoadSnapshot(beforePath)
+	if err != nil {
+		return err
+	}
+	after, err := monitor.LoadSnapshot(afterPath)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(monitor.CompareSnapshots(before, after))
+	}
+	return ui.WriteCompareReport(os.Stdout, cfg, before, after)
+}
+
+// loadViewState restores the last session's view state over the config
+// file's settings. The state file is written by brieftop, so problems with
+// it are only warnings.
+func loadViewState(cfg *config.Config) {
+	path, err := config.StatePath()
+	if err != nil {
+		return
+	}
+	f, err := config.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring saved view state: %v\n", err)
+		}
+		return
+	}
+	if errs := f.Validate(); len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring saved view state %s: %v\n", path, errors.Join(errs...))
+		return
+	}
+	f.Apply(cfg)
+}
+
+// saveViewState remembers the view state for the next session
+func saveViewState(cfg *config.Config) {
+	path, err := config.StatePath()
+	if err != nil {
+		return
+	}
+	if err := cfg.ViewState().WriteFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save view state: %v\n", err)
+	}
+}
+
+// configFile returns the config file to use: path if given, otherwise the
+// default location. explicit is false for the default, which may be absent.
+func configFile(path string) (file string, explicit bool, err error) {
+	if path != "" {
+		return path, true, nil
+	}
+	file, err = config.DefaultPath()
+	return file, false, err
+}
+
+// loadConfigFile applies the config file to cfg. An invalid file is an
+// error rather than a silent fallback to defaults.
+func loadConfigFile(cfg *config.Config, path string) error {
+	file, explicit, err := configFile(path)
+	if err != nil {
+		return nil // No user config directory, so no default config file
+	}
+
+	f, err := config.ReadFile(file)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("invalid config file: %w", err)
+	}
+	if errs := configProblems(f); len(errs) > 0 {
+		return fmt.Errorf("invalid config file %s: %w (run --check-config for all problems)", file, errs[0])
+	}
+	f.Apply(cfg)
+	return nil
+}
+
+// configProblems validates a config file, including key binding actions
+func configProblems(f *config.File) []error {
+	return append(f.Validate(), ui.CheckKeyBindings(f.KeyBindings)...)
+}
+
+// runCheckConfig validates a config file for --check-config, printing "OK"
+// or every problem found, and returns the exit code
+func runCheckConfig(path string) int {
+	file, _, err := configFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "No config file given and no default location: %v\n", err)
+		return 2
+	}
+
+	f, err := config.ReadFile(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	errs := configProblems(f)
+	if len(errs) == 0 {
+		fmt.Printf("%s: OK\n", file)
+		return 0
+	}
+	fmt.Printf("%s: %d problem(s)\n", file, len(errs))
+	for _, err := range errs {
+		fmt.Printf("  - %v\n", err)
+	}
+	return 1
 }